@@ -7,6 +7,7 @@
 package v1
 
 import (
+	v1 "github.com/lukabrx/uber-clone/api/proto/money/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -22,11 +23,20 @@ const (
 )
 
 type Driver struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Lat           float64                `protobuf:"fixed64,3,opt,name=lat,proto3" json:"lat,omitempty"`
-	Lon           float64                `protobuf:"fixed64,4,opt,name=lon,proto3" json:"lon,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Lat   float64                `protobuf:"fixed64,3,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lon   float64                `protobuf:"fixed64,4,opt,name=lon,proto3" json:"lon,omitempty"`
+	// The user account the driver signs in with.
+	UserId string `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Degrees clockwise from north.
+	Heading     float64 `protobuf:"fixed64,6,opt,name=heading,proto3" json:"heading,omitempty"`
+	SpeedKmh    float64 `protobuf:"fixed64,7,opt,name=speed_kmh,json=speedKmh,proto3" json:"speed_kmh,omitempty"`
+	VehicleType string  `protobuf:"bytes,8,opt,name=vehicle_type,json=vehicleType,proto3" json:"vehicle_type,omitempty"`
+	// Only set in FindAvailableDrivers responses, relative to the query point.
+	DistanceKm    float64 `protobuf:"fixed64,9,opt,name=distance_km,json=distanceKm,proto3" json:"distance_km,omitempty"`
+	EtaSeconds    int64   `protobuf:"varint,10,opt,name=eta_seconds,json=etaSeconds,proto3" json:"eta_seconds,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -89,11 +99,57 @@ func (x *Driver) GetLon() float64 {
 	return 0
 }
 
+func (x *Driver) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Driver) GetHeading() float64 {
+	if x != nil {
+		return x.Heading
+	}
+	return 0
+}
+
+func (x *Driver) GetSpeedKmh() float64 {
+	if x != nil {
+		return x.SpeedKmh
+	}
+	return 0
+}
+
+func (x *Driver) GetVehicleType() string {
+	if x != nil {
+		return x.VehicleType
+	}
+	return ""
+}
+
+func (x *Driver) GetDistanceKm() float64 {
+	if x != nil {
+		return x.DistanceKm
+	}
+	return 0
+}
+
+func (x *Driver) GetEtaSeconds() int64 {
+	if x != nil {
+		return x.EtaSeconds
+	}
+	return 0
+}
+
 type RegisterDriverRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Lat           float64                `protobuf:"fixed64,2,opt,name=lat,proto3" json:"lat,omitempty"`
-	Lon           float64                `protobuf:"fixed64,3,opt,name=lon,proto3" json:"lon,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Lat   float64                `protobuf:"fixed64,2,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lon   float64                `protobuf:"fixed64,3,opt,name=lon,proto3" json:"lon,omitempty"`
+	// A user can register at most one driver.
+	UserId string `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// "economy" (default), "comfort" or "xl".
+	VehicleType   string `protobuf:"bytes,5,opt,name=vehicle_type,json=vehicleType,proto3" json:"vehicle_type,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -149,6 +205,20 @@ func (x *RegisterDriverRequest) GetLon() float64 {
 	return 0
 }
 
+func (x *RegisterDriverRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RegisterDriverRequest) GetVehicleType() string {
+	if x != nil {
+		return x.VehicleType
+	}
+	return ""
+}
+
 type RegisterDriverResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Driver        *Driver                `protobuf:"bytes,1,opt,name=driver,proto3" json:"driver,omitempty"`
@@ -193,17 +263,111 @@ func (x *RegisterDriverResponse) GetDriver() *Driver {
 	return nil
 }
 
-type FindAvailableDriversRequest struct {
+type GetDriverByUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDriverByUserRequest) Reset() {
+	*x = GetDriverByUserRequest{}
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDriverByUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDriverByUserRequest) ProtoMessage() {}
+
+func (x *GetDriverByUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDriverByUserRequest.ProtoReflect.Descriptor instead.
+func (*GetDriverByUserRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_driver_v1_driver_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetDriverByUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetDriverByUserResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Lat           float64                `protobuf:"fixed64,1,opt,name=lat,proto3" json:"lat,omitempty"`
-	Lon           float64                `protobuf:"fixed64,2,opt,name=lon,proto3" json:"lon,omitempty"`
+	Driver        *Driver                `protobuf:"bytes,1,opt,name=driver,proto3" json:"driver,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDriverByUserResponse) Reset() {
+	*x = GetDriverByUserResponse{}
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDriverByUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDriverByUserResponse) ProtoMessage() {}
+
+func (x *GetDriverByUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDriverByUserResponse.ProtoReflect.Descriptor instead.
+func (*GetDriverByUserResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_driver_v1_driver_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetDriverByUserResponse) GetDriver() *Driver {
+	if x != nil {
+		return x.Driver
+	}
+	return nil
+}
+
+type FindAvailableDriversRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Lat   float64                `protobuf:"fixed64,1,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lon   float64                `protobuf:"fixed64,2,opt,name=lon,proto3" json:"lon,omitempty"`
+	// Defaults to 10 km.
+	RadiusKm float64 `protobuf:"fixed64,3,opt,name=radius_km,json=radiusKm,proto3" json:"radius_km,omitempty"`
+	// Defaults to 20, at most 100.
+	Limit int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Empty matches every vehicle type.
+	VehicleTypes  []string `protobuf:"bytes,5,rep,name=vehicle_types,json=vehicleTypes,proto3" json:"vehicle_types,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *FindAvailableDriversRequest) Reset() {
 	*x = FindAvailableDriversRequest{}
-	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[3]
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -215,7 +379,7 @@ func (x *FindAvailableDriversRequest) String() string {
 func (*FindAvailableDriversRequest) ProtoMessage() {}
 
 func (x *FindAvailableDriversRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[3]
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -228,7 +392,7 @@ func (x *FindAvailableDriversRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FindAvailableDriversRequest.ProtoReflect.Descriptor instead.
 func (*FindAvailableDriversRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_driver_v1_driver_proto_rawDescGZIP(), []int{3}
+	return file_api_proto_driver_v1_driver_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *FindAvailableDriversRequest) GetLat() float64 {
@@ -245,6 +409,27 @@ func (x *FindAvailableDriversRequest) GetLon() float64 {
 	return 0
 }
 
+func (x *FindAvailableDriversRequest) GetRadiusKm() float64 {
+	if x != nil {
+		return x.RadiusKm
+	}
+	return 0
+}
+
+func (x *FindAvailableDriversRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *FindAvailableDriversRequest) GetVehicleTypes() []string {
+	if x != nil {
+		return x.VehicleTypes
+	}
+	return nil
+}
+
 type FindAvailableDriversResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Drivers       []*Driver              `protobuf:"bytes,1,rep,name=drivers,proto3" json:"drivers,omitempty"`
@@ -254,7 +439,7 @@ type FindAvailableDriversResponse struct {
 
 func (x *FindAvailableDriversResponse) Reset() {
 	*x = FindAvailableDriversResponse{}
-	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[4]
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -266,7 +451,7 @@ func (x *FindAvailableDriversResponse) String() string {
 func (*FindAvailableDriversResponse) ProtoMessage() {}
 
 func (x *FindAvailableDriversResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[4]
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -279,7 +464,7 @@ func (x *FindAvailableDriversResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FindAvailableDriversResponse.ProtoReflect.Descriptor instead.
 func (*FindAvailableDriversResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_driver_v1_driver_proto_rawDescGZIP(), []int{4}
+	return file_api_proto_driver_v1_driver_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *FindAvailableDriversResponse) GetDrivers() []*Driver {
@@ -299,7 +484,7 @@ type UpdateDriverStatusRequest struct {
 
 func (x *UpdateDriverStatusRequest) Reset() {
 	*x = UpdateDriverStatusRequest{}
-	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[5]
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -311,7 +496,7 @@ func (x *UpdateDriverStatusRequest) String() string {
 func (*UpdateDriverStatusRequest) ProtoMessage() {}
 
 func (x *UpdateDriverStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[5]
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -324,7 +509,7 @@ func (x *UpdateDriverStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateDriverStatusRequest.ProtoReflect.Descriptor instead.
 func (*UpdateDriverStatusRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_driver_v1_driver_proto_rawDescGZIP(), []int{5}
+	return file_api_proto_driver_v1_driver_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *UpdateDriverStatusRequest) GetId() string {
@@ -349,7 +534,7 @@ type UpdateDriverStatusResponse struct {
 
 func (x *UpdateDriverStatusResponse) Reset() {
 	*x = UpdateDriverStatusResponse{}
-	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[6]
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -361,7 +546,7 @@ func (x *UpdateDriverStatusResponse) String() string {
 func (*UpdateDriverStatusResponse) ProtoMessage() {}
 
 func (x *UpdateDriverStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[6]
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -374,38 +559,1067 @@ func (x *UpdateDriverStatusResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateDriverStatusResponse.ProtoReflect.Descriptor instead.
 func (*UpdateDriverStatusResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_driver_v1_driver_proto_rawDescGZIP(), []int{6}
+	return file_api_proto_driver_v1_driver_proto_rawDescGZIP(), []int{8}
 }
 
-var File_api_proto_driver_v1_driver_proto protoreflect.FileDescriptor
+type TripOffer struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DriverId   string                 `protobuf:"bytes,2,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	TripId     string                 `protobuf:"bytes,3,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	PickupLat  float64                `protobuf:"fixed64,4,opt,name=pickup_lat,json=pickupLat,proto3" json:"pickup_lat,omitempty"`
+	PickupLon  float64                `protobuf:"fixed64,5,opt,name=pickup_lon,json=pickupLon,proto3" json:"pickup_lon,omitempty"`
+	DropoffLat float64                `protobuf:"fixed64,6,opt,name=dropoff_lat,json=dropoffLat,proto3" json:"dropoff_lat,omitempty"`
+	DropoffLon float64                `protobuf:"fixed64,7,opt,name=dropoff_lon,json=dropoffLon,proto3" json:"dropoff_lon,omitempty"`
+	// Unix seconds.
+	ExpiresAt     int64     `protobuf:"varint,9,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Price         *v1.Money `protobuf:"bytes,10,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_api_proto_driver_v1_driver_proto_rawDesc = "" +
-	"\n" +
-	" api/proto/driver/v1/driver.proto\x12\tdriver.v1\"P\n" +
-	"\x06Driver\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x10\n" +
-	"\x03lat\x18\x03 \x01(\x01R\x03lat\x12\x10\n" +
-	"\x03lon\x18\x04 \x01(\x01R\x03lon\"O\n" +
-	"\x15RegisterDriverRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
-	"\x03lat\x18\x02 \x01(\x01R\x03lat\x12\x10\n" +
-	"\x03lon\x18\x03 \x01(\x01R\x03lon\"C\n" +
-	"\x16RegisterDriverResponse\x12)\n" +
-	"\x06driver\x18\x01 \x01(\v2\x11.driver.v1.DriverR\x06driver\"A\n" +
-	"\x1bFindAvailableDriversRequest\x12\x10\n" +
-	"\x03lat\x18\x01 \x01(\x01R\x03lat\x12\x10\n" +
-	"\x03lon\x18\x02 \x01(\x01R\x03lon\"K\n" +
-	"\x1cFindAvailableDriversResponse\x12+\n" +
-	"\adrivers\x18\x01 \x03(\v2\x11.driver.v1.DriverR\adrivers\"N\n" +
-	"\x19UpdateDriverStatusRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
-	"\fis_available\x18\x02 \x01(\bR\visAvailable\"\x1c\n" +
-	"\x1aUpdateDriverStatusResponse2\xb2\x02\n" +
+func (x *TripOffer) Reset() {
+	*x = TripOffer{}
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TripOffer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TripOffer) ProtoMessage() {}
+
+func (x *TripOffer) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TripOffer.ProtoReflect.Descriptor instead.
+func (*TripOffer) Descriptor() ([]byte, []int) {
+	return file_api_proto_driver_v1_driver_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TripOffer) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TripOffer) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *TripOffer) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *TripOffer) GetPickupLat() float64 {
+	if x != nil {
+		return x.PickupLat
+	}
+	return 0
+}
+
+func (x *TripOffer) GetPickupLon() float64 {
+	if x != nil {
+		return x.PickupLon
+	}
+	return 0
+}
+
+func (x *TripOffer) GetDropoffLat() float64 {
+	if x != nil {
+		return x.DropoffLat
+	}
+	return 0
+}
+
+func (x *TripOffer) GetDropoffLon() float64 {
+	if x != nil {
+		return x.DropoffLon
+	}
+	return 0
+}
+
+func (x *TripOffer) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *TripOffer) GetPrice() *v1.Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+type OfferTripRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverId      string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	TripId        string                 `protobuf:"bytes,2,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	PickupLat     float64                `protobuf:"fixed64,3,opt,name=pickup_lat,json=pickupLat,proto3" json:"pickup_lat,omitempty"`
+	PickupLon     float64                `protobuf:"fixed64,4,opt,name=pickup_lon,json=pickupLon,proto3" json:"pickup_lon,omitempty"`
+	DropoffLat    float64                `protobuf:"fixed64,5,opt,name=dropoff_lat,json=dropoffLat,proto3" json:"dropoff_lat,omitempty"`
+	DropoffLon    float64                `protobuf:"fixed64,6,opt,name=dropoff_lon,json=dropoffLon,proto3" json:"dropoff_lon,omitempty"`
+	Price         *v1.Money              `protobuf:"bytes,8,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OfferTripRequest) Reset() {
+	*x = OfferTripRequest{}
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OfferTripRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OfferTripRequest) ProtoMessage() {}
+
+func (x *OfferTripRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OfferTripRequest.ProtoReflect.Descriptor instead.
+func (*OfferTripRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_driver_v1_driver_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *OfferTripRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *OfferTripRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *OfferTripRequest) GetPickupLat() float64 {
+	if x != nil {
+		return x.PickupLat
+	}
+	return 0
+}
+
+func (x *OfferTripRequest) GetPickupLon() float64 {
+	if x != nil {
+		return x.PickupLon
+	}
+	return 0
+}
+
+func (x *OfferTripRequest) GetDropoffLat() float64 {
+	if x != nil {
+		return x.DropoffLat
+	}
+	return 0
+}
+
+func (x *OfferTripRequest) GetDropoffLon() float64 {
+	if x != nil {
+		return x.DropoffLon
+	}
+	return 0
+}
+
+func (x *OfferTripRequest) GetPrice() *v1.Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+type OfferTripResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Offer *TripOffer             `protobuf:"bytes,1,opt,name=offer,proto3" json:"offer,omitempty"`
+	// "accepted", "declined", "expired" or "withdrawn".
+	Outcome       string `protobuf:"bytes,2,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OfferTripResponse) Reset() {
+	*x = OfferTripResponse{}
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OfferTripResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OfferTripResponse) ProtoMessage() {}
+
+func (x *OfferTripResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OfferTripResponse.ProtoReflect.Descriptor instead.
+func (*OfferTripResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_driver_v1_driver_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *OfferTripResponse) GetOffer() *TripOffer {
+	if x != nil {
+		return x.Offer
+	}
+	return nil
+}
+
+func (x *OfferTripResponse) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+type AcceptTripOfferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OfferId       string                 `protobuf:"bytes,1,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
+	DriverId      string                 `protobuf:"bytes,2,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptTripOfferRequest) Reset() {
+	*x = AcceptTripOfferRequest{}
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptTripOfferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptTripOfferRequest) ProtoMessage() {}
+
+func (x *AcceptTripOfferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptTripOfferRequest.ProtoReflect.Descriptor instead.
+func (*AcceptTripOfferRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_driver_v1_driver_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AcceptTripOfferRequest) GetOfferId() string {
+	if x != nil {
+		return x.OfferId
+	}
+	return ""
+}
+
+func (x *AcceptTripOfferRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+type AcceptTripOfferResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Offer         *TripOffer             `protobuf:"bytes,1,opt,name=offer,proto3" json:"offer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptTripOfferResponse) Reset() {
+	*x = AcceptTripOfferResponse{}
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptTripOfferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptTripOfferResponse) ProtoMessage() {}
+
+func (x *AcceptTripOfferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptTripOfferResponse.ProtoReflect.Descriptor instead.
+func (*AcceptTripOfferResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_driver_v1_driver_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AcceptTripOfferResponse) GetOffer() *TripOffer {
+	if x != nil {
+		return x.Offer
+	}
+	return nil
+}
+
+type DeclineTripOfferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OfferId       string                 `protobuf:"bytes,1,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
+	DriverId      string                 `protobuf:"bytes,2,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeclineTripOfferRequest) Reset() {
+	*x = DeclineTripOfferRequest{}
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeclineTripOfferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeclineTripOfferRequest) ProtoMessage() {}
+
+func (x *DeclineTripOfferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeclineTripOfferRequest.ProtoReflect.Descriptor instead.
+func (*DeclineTripOfferRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_driver_v1_driver_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeclineTripOfferRequest) GetOfferId() string {
+	if x != nil {
+		return x.OfferId
+	}
+	return ""
+}
+
+func (x *DeclineTripOfferRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *DeclineTripOfferRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type DeclineTripOfferResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeclineTripOfferResponse) Reset() {
+	*x = DeclineTripOfferResponse{}
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeclineTripOfferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeclineTripOfferResponse) ProtoMessage() {}
+
+func (x *DeclineTripOfferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeclineTripOfferResponse.ProtoReflect.Descriptor instead.
+func (*DeclineTripOfferResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_driver_v1_driver_proto_rawDescGZIP(), []int{15}
+}
+
+type GetOfferStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverId      string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOfferStatsRequest) Reset() {
+	*x = GetOfferStatsRequest{}
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOfferStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOfferStatsRequest) ProtoMessage() {}
+
+func (x *GetOfferStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOfferStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetOfferStatsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_driver_v1_driver_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetOfferStatsRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+type GetOfferStatsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Offered        int64                  `protobuf:"varint,1,opt,name=offered,proto3" json:"offered,omitempty"`
+	Accepted       int64                  `protobuf:"varint,2,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Declined       int64                  `protobuf:"varint,3,opt,name=declined,proto3" json:"declined,omitempty"`
+	Expired        int64                  `protobuf:"varint,4,opt,name=expired,proto3" json:"expired,omitempty"`
+	AcceptanceRate float64                `protobuf:"fixed64,5,opt,name=acceptance_rate,json=acceptanceRate,proto3" json:"acceptance_rate,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetOfferStatsResponse) Reset() {
+	*x = GetOfferStatsResponse{}
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOfferStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOfferStatsResponse) ProtoMessage() {}
+
+func (x *GetOfferStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOfferStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetOfferStatsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_driver_v1_driver_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetOfferStatsResponse) GetOffered() int64 {
+	if x != nil {
+		return x.Offered
+	}
+	return 0
+}
+
+func (x *GetOfferStatsResponse) GetAccepted() int64 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+func (x *GetOfferStatsResponse) GetDeclined() int64 {
+	if x != nil {
+		return x.Declined
+	}
+	return 0
+}
+
+func (x *GetOfferStatsResponse) GetExpired() int64 {
+	if x != nil {
+		return x.Expired
+	}
+	return 0
+}
+
+func (x *GetOfferStatsResponse) GetAcceptanceRate() float64 {
+	if x != nil {
+		return x.AcceptanceRate
+	}
+	return 0
+}
+
+type ReserveDriverRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverId      string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	TripId        string                 `protobuf:"bytes,2,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveDriverRequest) Reset() {
+	*x = ReserveDriverRequest{}
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveDriverRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveDriverRequest) ProtoMessage() {}
+
+func (x *ReserveDriverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveDriverRequest.ProtoReflect.Descriptor instead.
+func (*ReserveDriverRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_driver_v1_driver_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ReserveDriverRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *ReserveDriverRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+type ReserveDriverResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Driver        *Driver                `protobuf:"bytes,1,opt,name=driver,proto3" json:"driver,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveDriverResponse) Reset() {
+	*x = ReserveDriverResponse{}
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveDriverResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveDriverResponse) ProtoMessage() {}
+
+func (x *ReserveDriverResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveDriverResponse.ProtoReflect.Descriptor instead.
+func (*ReserveDriverResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_driver_v1_driver_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ReserveDriverResponse) GetDriver() *Driver {
+	if x != nil {
+		return x.Driver
+	}
+	return nil
+}
+
+type ReleaseDriverRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverId      string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	TripId        string                 `protobuf:"bytes,2,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseDriverRequest) Reset() {
+	*x = ReleaseDriverRequest{}
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseDriverRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseDriverRequest) ProtoMessage() {}
+
+func (x *ReleaseDriverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseDriverRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseDriverRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_driver_v1_driver_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ReleaseDriverRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *ReleaseDriverRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+type ReleaseDriverResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseDriverResponse) Reset() {
+	*x = ReleaseDriverResponse{}
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseDriverResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseDriverResponse) ProtoMessage() {}
+
+func (x *ReleaseDriverResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseDriverResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseDriverResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_driver_v1_driver_proto_rawDescGZIP(), []int{21}
+}
+
+type UpdateLocationRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	DriverId string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	Lat      float64                `protobuf:"fixed64,2,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lon      float64                `protobuf:"fixed64,3,opt,name=lon,proto3" json:"lon,omitempty"`
+	// Degrees clockwise from north.
+	Heading  float64 `protobuf:"fixed64,4,opt,name=heading,proto3" json:"heading,omitempty"`
+	SpeedKmh float64 `protobuf:"fixed64,5,opt,name=speed_kmh,json=speedKmh,proto3" json:"speed_kmh,omitempty"`
+	// Unix milliseconds when the ping was taken; defaults to now.
+	Timestamp     int64 `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateLocationRequest) Reset() {
+	*x = UpdateLocationRequest{}
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLocationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLocationRequest) ProtoMessage() {}
+
+func (x *UpdateLocationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLocationRequest.ProtoReflect.Descriptor instead.
+func (*UpdateLocationRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_driver_v1_driver_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *UpdateLocationRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *UpdateLocationRequest) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *UpdateLocationRequest) GetLon() float64 {
+	if x != nil {
+		return x.Lon
+	}
+	return 0
+}
+
+func (x *UpdateLocationRequest) GetHeading() float64 {
+	if x != nil {
+		return x.Heading
+	}
+	return 0
+}
+
+func (x *UpdateLocationRequest) GetSpeedKmh() float64 {
+	if x != nil {
+		return x.SpeedKmh
+	}
+	return 0
+}
+
+func (x *UpdateLocationRequest) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+type UpdateLocationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Driver        *Driver                `protobuf:"bytes,1,opt,name=driver,proto3" json:"driver,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateLocationResponse) Reset() {
+	*x = UpdateLocationResponse{}
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLocationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLocationResponse) ProtoMessage() {}
+
+func (x *UpdateLocationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLocationResponse.ProtoReflect.Descriptor instead.
+func (*UpdateLocationResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_driver_v1_driver_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UpdateLocationResponse) GetDriver() *Driver {
+	if x != nil {
+		return x.Driver
+	}
+	return nil
+}
+
+type StreamLocationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accepted      int64                  `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Rejected      int64                  `protobuf:"varint,2,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamLocationResponse) Reset() {
+	*x = StreamLocationResponse{}
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamLocationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamLocationResponse) ProtoMessage() {}
+
+func (x *StreamLocationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_driver_v1_driver_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamLocationResponse.ProtoReflect.Descriptor instead.
+func (*StreamLocationResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_driver_v1_driver_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *StreamLocationResponse) GetAccepted() int64 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+func (x *StreamLocationResponse) GetRejected() int64 {
+	if x != nil {
+		return x.Rejected
+	}
+	return 0
+}
+
+var File_api_proto_driver_v1_driver_proto protoreflect.FileDescriptor
+
+const file_api_proto_driver_v1_driver_proto_rawDesc = "" +
+	"\n" +
+	" api/proto/driver/v1/driver.proto\x12\tdriver.v1\x1a\x1eapi/proto/money/v1/money.proto\"\x85\x02\n" +
+	"\x06Driver\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x10\n" +
+	"\x03lat\x18\x03 \x01(\x01R\x03lat\x12\x10\n" +
+	"\x03lon\x18\x04 \x01(\x01R\x03lon\x12\x17\n" +
+	"\auser_id\x18\x05 \x01(\tR\x06userId\x12\x18\n" +
+	"\aheading\x18\x06 \x01(\x01R\aheading\x12\x1b\n" +
+	"\tspeed_kmh\x18\a \x01(\x01R\bspeedKmh\x12!\n" +
+	"\fvehicle_type\x18\b \x01(\tR\vvehicleType\x12\x1f\n" +
+	"\vdistance_km\x18\t \x01(\x01R\n" +
+	"distanceKm\x12\x1f\n" +
+	"\veta_seconds\x18\n" +
+	" \x01(\x03R\n" +
+	"etaSeconds\"\x8b\x01\n" +
+	"\x15RegisterDriverRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
+	"\x03lat\x18\x02 \x01(\x01R\x03lat\x12\x10\n" +
+	"\x03lon\x18\x03 \x01(\x01R\x03lon\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12!\n" +
+	"\fvehicle_type\x18\x05 \x01(\tR\vvehicleType\"C\n" +
+	"\x16RegisterDriverResponse\x12)\n" +
+	"\x06driver\x18\x01 \x01(\v2\x11.driver.v1.DriverR\x06driver\"1\n" +
+	"\x16GetDriverByUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"D\n" +
+	"\x17GetDriverByUserResponse\x12)\n" +
+	"\x06driver\x18\x01 \x01(\v2\x11.driver.v1.DriverR\x06driver\"\x99\x01\n" +
+	"\x1bFindAvailableDriversRequest\x12\x10\n" +
+	"\x03lat\x18\x01 \x01(\x01R\x03lat\x12\x10\n" +
+	"\x03lon\x18\x02 \x01(\x01R\x03lon\x12\x1b\n" +
+	"\tradius_km\x18\x03 \x01(\x01R\bradiusKm\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12#\n" +
+	"\rvehicle_types\x18\x05 \x03(\tR\fvehicleTypes\"K\n" +
+	"\x1cFindAvailableDriversResponse\x12+\n" +
+	"\adrivers\x18\x01 \x03(\v2\x11.driver.v1.DriverR\adrivers\"N\n" +
+	"\x19UpdateDriverStatusRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\fis_available\x18\x02 \x01(\bR\visAvailable\"\x1c\n" +
+	"\x1aUpdateDriverStatusResponse\"\x9d\x02\n" +
+	"\tTripOffer\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tdriver_id\x18\x02 \x01(\tR\bdriverId\x12\x17\n" +
+	"\atrip_id\x18\x03 \x01(\tR\x06tripId\x12\x1d\n" +
+	"\n" +
+	"pickup_lat\x18\x04 \x01(\x01R\tpickupLat\x12\x1d\n" +
+	"\n" +
+	"pickup_lon\x18\x05 \x01(\x01R\tpickupLon\x12\x1f\n" +
+	"\vdropoff_lat\x18\x06 \x01(\x01R\n" +
+	"dropoffLat\x12\x1f\n" +
+	"\vdropoff_lon\x18\a \x01(\x01R\n" +
+	"dropoffLon\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\t \x01(\x03R\texpiresAt\x12%\n" +
+	"\x05price\x18\n" +
+	" \x01(\v2\x0f.money.v1.MoneyR\x05priceJ\x04\b\b\x10\t\"\xf5\x01\n" +
+	"\x10OfferTripRequest\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12\x17\n" +
+	"\atrip_id\x18\x02 \x01(\tR\x06tripId\x12\x1d\n" +
+	"\n" +
+	"pickup_lat\x18\x03 \x01(\x01R\tpickupLat\x12\x1d\n" +
+	"\n" +
+	"pickup_lon\x18\x04 \x01(\x01R\tpickupLon\x12\x1f\n" +
+	"\vdropoff_lat\x18\x05 \x01(\x01R\n" +
+	"dropoffLat\x12\x1f\n" +
+	"\vdropoff_lon\x18\x06 \x01(\x01R\n" +
+	"dropoffLon\x12%\n" +
+	"\x05price\x18\b \x01(\v2\x0f.money.v1.MoneyR\x05priceJ\x04\b\a\x10\b\"Y\n" +
+	"\x11OfferTripResponse\x12*\n" +
+	"\x05offer\x18\x01 \x01(\v2\x14.driver.v1.TripOfferR\x05offer\x12\x18\n" +
+	"\aoutcome\x18\x02 \x01(\tR\aoutcome\"P\n" +
+	"\x16AcceptTripOfferRequest\x12\x19\n" +
+	"\boffer_id\x18\x01 \x01(\tR\aofferId\x12\x1b\n" +
+	"\tdriver_id\x18\x02 \x01(\tR\bdriverId\"E\n" +
+	"\x17AcceptTripOfferResponse\x12*\n" +
+	"\x05offer\x18\x01 \x01(\v2\x14.driver.v1.TripOfferR\x05offer\"i\n" +
+	"\x17DeclineTripOfferRequest\x12\x19\n" +
+	"\boffer_id\x18\x01 \x01(\tR\aofferId\x12\x1b\n" +
+	"\tdriver_id\x18\x02 \x01(\tR\bdriverId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\x1a\n" +
+	"\x18DeclineTripOfferResponse\"3\n" +
+	"\x14GetOfferStatsRequest\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\"\xac\x01\n" +
+	"\x15GetOfferStatsResponse\x12\x18\n" +
+	"\aoffered\x18\x01 \x01(\x03R\aoffered\x12\x1a\n" +
+	"\baccepted\x18\x02 \x01(\x03R\baccepted\x12\x1a\n" +
+	"\bdeclined\x18\x03 \x01(\x03R\bdeclined\x12\x18\n" +
+	"\aexpired\x18\x04 \x01(\x03R\aexpired\x12'\n" +
+	"\x0facceptance_rate\x18\x05 \x01(\x01R\x0eacceptanceRate\"L\n" +
+	"\x14ReserveDriverRequest\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12\x17\n" +
+	"\atrip_id\x18\x02 \x01(\tR\x06tripId\"B\n" +
+	"\x15ReserveDriverResponse\x12)\n" +
+	"\x06driver\x18\x01 \x01(\v2\x11.driver.v1.DriverR\x06driver\"L\n" +
+	"\x14ReleaseDriverRequest\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12\x17\n" +
+	"\atrip_id\x18\x02 \x01(\tR\x06tripId\"\x17\n" +
+	"\x15ReleaseDriverResponse\"\xad\x01\n" +
+	"\x15UpdateLocationRequest\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12\x10\n" +
+	"\x03lat\x18\x02 \x01(\x01R\x03lat\x12\x10\n" +
+	"\x03lon\x18\x03 \x01(\x01R\x03lon\x12\x18\n" +
+	"\aheading\x18\x04 \x01(\x01R\aheading\x12\x1b\n" +
+	"\tspeed_kmh\x18\x05 \x01(\x01R\bspeedKmh\x12\x1c\n" +
+	"\ttimestamp\x18\x06 \x01(\x03R\ttimestamp\"C\n" +
+	"\x16UpdateLocationResponse\x12)\n" +
+	"\x06driver\x18\x01 \x01(\v2\x11.driver.v1.DriverR\x06driver\"P\n" +
+	"\x16StreamLocationResponse\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\x03R\baccepted\x12\x1a\n" +
+	"\brejected\x18\x02 \x01(\x03R\brejected2\xb7\b\n" +
 	"\rDriverService\x12U\n" +
-	"\x0eRegisterDriver\x12 .driver.v1.RegisterDriverRequest\x1a!.driver.v1.RegisterDriverResponse\x12g\n" +
+	"\x0eRegisterDriver\x12 .driver.v1.RegisterDriverRequest\x1a!.driver.v1.RegisterDriverResponse\x12X\n" +
+	"\x0fGetDriverByUser\x12!.driver.v1.GetDriverByUserRequest\x1a\".driver.v1.GetDriverByUserResponse\x12g\n" +
 	"\x14FindAvailableDrivers\x12&.driver.v1.FindAvailableDriversRequest\x1a'.driver.v1.FindAvailableDriversResponse\x12a\n" +
-	"\x12UpdateDriverStatus\x12$.driver.v1.UpdateDriverStatusRequest\x1a%.driver.v1.UpdateDriverStatusResponseB\x1aZ\x18uber-clone/pkg/driver/v1b\x06proto3"
+	"\x12UpdateDriverStatus\x12$.driver.v1.UpdateDriverStatusRequest\x1a%.driver.v1.UpdateDriverStatusResponse\x12R\n" +
+	"\rReserveDriver\x12\x1f.driver.v1.ReserveDriverRequest\x1a .driver.v1.ReserveDriverResponse\x12R\n" +
+	"\rReleaseDriver\x12\x1f.driver.v1.ReleaseDriverRequest\x1a .driver.v1.ReleaseDriverResponse\x12F\n" +
+	"\tOfferTrip\x12\x1b.driver.v1.OfferTripRequest\x1a\x1c.driver.v1.OfferTripResponse\x12X\n" +
+	"\x0fAcceptTripOffer\x12!.driver.v1.AcceptTripOfferRequest\x1a\".driver.v1.AcceptTripOfferResponse\x12[\n" +
+	"\x10DeclineTripOffer\x12\".driver.v1.DeclineTripOfferRequest\x1a#.driver.v1.DeclineTripOfferResponse\x12R\n" +
+	"\rGetOfferStats\x12\x1f.driver.v1.GetOfferStatsRequest\x1a .driver.v1.GetOfferStatsResponse\x12U\n" +
+	"\x0eUpdateLocation\x12 .driver.v1.UpdateLocationRequest\x1a!.driver.v1.UpdateLocationResponse\x12W\n" +
+	"\x0eStreamLocation\x12 .driver.v1.UpdateLocationRequest\x1a!.driver.v1.StreamLocationResponse(\x01B\x1aZ\x18uber-clone/pkg/driver/v1b\x06proto3"
 
 var (
 	file_api_proto_driver_v1_driver_proto_rawDescOnce sync.Once
@@ -419,30 +1633,74 @@ func file_api_proto_driver_v1_driver_proto_rawDescGZIP() []byte {
 	return file_api_proto_driver_v1_driver_proto_rawDescData
 }
 
-var file_api_proto_driver_v1_driver_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_api_proto_driver_v1_driver_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_api_proto_driver_v1_driver_proto_goTypes = []any{
 	(*Driver)(nil),                       // 0: driver.v1.Driver
 	(*RegisterDriverRequest)(nil),        // 1: driver.v1.RegisterDriverRequest
 	(*RegisterDriverResponse)(nil),       // 2: driver.v1.RegisterDriverResponse
-	(*FindAvailableDriversRequest)(nil),  // 3: driver.v1.FindAvailableDriversRequest
-	(*FindAvailableDriversResponse)(nil), // 4: driver.v1.FindAvailableDriversResponse
-	(*UpdateDriverStatusRequest)(nil),    // 5: driver.v1.UpdateDriverStatusRequest
-	(*UpdateDriverStatusResponse)(nil),   // 6: driver.v1.UpdateDriverStatusResponse
+	(*GetDriverByUserRequest)(nil),       // 3: driver.v1.GetDriverByUserRequest
+	(*GetDriverByUserResponse)(nil),      // 4: driver.v1.GetDriverByUserResponse
+	(*FindAvailableDriversRequest)(nil),  // 5: driver.v1.FindAvailableDriversRequest
+	(*FindAvailableDriversResponse)(nil), // 6: driver.v1.FindAvailableDriversResponse
+	(*UpdateDriverStatusRequest)(nil),    // 7: driver.v1.UpdateDriverStatusRequest
+	(*UpdateDriverStatusResponse)(nil),   // 8: driver.v1.UpdateDriverStatusResponse
+	(*TripOffer)(nil),                    // 9: driver.v1.TripOffer
+	(*OfferTripRequest)(nil),             // 10: driver.v1.OfferTripRequest
+	(*OfferTripResponse)(nil),            // 11: driver.v1.OfferTripResponse
+	(*AcceptTripOfferRequest)(nil),       // 12: driver.v1.AcceptTripOfferRequest
+	(*AcceptTripOfferResponse)(nil),      // 13: driver.v1.AcceptTripOfferResponse
+	(*DeclineTripOfferRequest)(nil),      // 14: driver.v1.DeclineTripOfferRequest
+	(*DeclineTripOfferResponse)(nil),     // 15: driver.v1.DeclineTripOfferResponse
+	(*GetOfferStatsRequest)(nil),         // 16: driver.v1.GetOfferStatsRequest
+	(*GetOfferStatsResponse)(nil),        // 17: driver.v1.GetOfferStatsResponse
+	(*ReserveDriverRequest)(nil),         // 18: driver.v1.ReserveDriverRequest
+	(*ReserveDriverResponse)(nil),        // 19: driver.v1.ReserveDriverResponse
+	(*ReleaseDriverRequest)(nil),         // 20: driver.v1.ReleaseDriverRequest
+	(*ReleaseDriverResponse)(nil),        // 21: driver.v1.ReleaseDriverResponse
+	(*UpdateLocationRequest)(nil),        // 22: driver.v1.UpdateLocationRequest
+	(*UpdateLocationResponse)(nil),       // 23: driver.v1.UpdateLocationResponse
+	(*StreamLocationResponse)(nil),       // 24: driver.v1.StreamLocationResponse
+	(*v1.Money)(nil),                     // 25: money.v1.Money
 }
 var file_api_proto_driver_v1_driver_proto_depIdxs = []int32{
-	0, // 0: driver.v1.RegisterDriverResponse.driver:type_name -> driver.v1.Driver
-	0, // 1: driver.v1.FindAvailableDriversResponse.drivers:type_name -> driver.v1.Driver
-	1, // 2: driver.v1.DriverService.RegisterDriver:input_type -> driver.v1.RegisterDriverRequest
-	3, // 3: driver.v1.DriverService.FindAvailableDrivers:input_type -> driver.v1.FindAvailableDriversRequest
-	5, // 4: driver.v1.DriverService.UpdateDriverStatus:input_type -> driver.v1.UpdateDriverStatusRequest
-	2, // 5: driver.v1.DriverService.RegisterDriver:output_type -> driver.v1.RegisterDriverResponse
-	4, // 6: driver.v1.DriverService.FindAvailableDrivers:output_type -> driver.v1.FindAvailableDriversResponse
-	6, // 7: driver.v1.DriverService.UpdateDriverStatus:output_type -> driver.v1.UpdateDriverStatusResponse
-	5, // [5:8] is the sub-list for method output_type
-	2, // [2:5] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	0,  // 0: driver.v1.RegisterDriverResponse.driver:type_name -> driver.v1.Driver
+	0,  // 1: driver.v1.GetDriverByUserResponse.driver:type_name -> driver.v1.Driver
+	0,  // 2: driver.v1.FindAvailableDriversResponse.drivers:type_name -> driver.v1.Driver
+	25, // 3: driver.v1.TripOffer.price:type_name -> money.v1.Money
+	25, // 4: driver.v1.OfferTripRequest.price:type_name -> money.v1.Money
+	9,  // 5: driver.v1.OfferTripResponse.offer:type_name -> driver.v1.TripOffer
+	9,  // 6: driver.v1.AcceptTripOfferResponse.offer:type_name -> driver.v1.TripOffer
+	0,  // 7: driver.v1.ReserveDriverResponse.driver:type_name -> driver.v1.Driver
+	0,  // 8: driver.v1.UpdateLocationResponse.driver:type_name -> driver.v1.Driver
+	1,  // 9: driver.v1.DriverService.RegisterDriver:input_type -> driver.v1.RegisterDriverRequest
+	3,  // 10: driver.v1.DriverService.GetDriverByUser:input_type -> driver.v1.GetDriverByUserRequest
+	5,  // 11: driver.v1.DriverService.FindAvailableDrivers:input_type -> driver.v1.FindAvailableDriversRequest
+	7,  // 12: driver.v1.DriverService.UpdateDriverStatus:input_type -> driver.v1.UpdateDriverStatusRequest
+	18, // 13: driver.v1.DriverService.ReserveDriver:input_type -> driver.v1.ReserveDriverRequest
+	20, // 14: driver.v1.DriverService.ReleaseDriver:input_type -> driver.v1.ReleaseDriverRequest
+	10, // 15: driver.v1.DriverService.OfferTrip:input_type -> driver.v1.OfferTripRequest
+	12, // 16: driver.v1.DriverService.AcceptTripOffer:input_type -> driver.v1.AcceptTripOfferRequest
+	14, // 17: driver.v1.DriverService.DeclineTripOffer:input_type -> driver.v1.DeclineTripOfferRequest
+	16, // 18: driver.v1.DriverService.GetOfferStats:input_type -> driver.v1.GetOfferStatsRequest
+	22, // 19: driver.v1.DriverService.UpdateLocation:input_type -> driver.v1.UpdateLocationRequest
+	22, // 20: driver.v1.DriverService.StreamLocation:input_type -> driver.v1.UpdateLocationRequest
+	2,  // 21: driver.v1.DriverService.RegisterDriver:output_type -> driver.v1.RegisterDriverResponse
+	4,  // 22: driver.v1.DriverService.GetDriverByUser:output_type -> driver.v1.GetDriverByUserResponse
+	6,  // 23: driver.v1.DriverService.FindAvailableDrivers:output_type -> driver.v1.FindAvailableDriversResponse
+	8,  // 24: driver.v1.DriverService.UpdateDriverStatus:output_type -> driver.v1.UpdateDriverStatusResponse
+	19, // 25: driver.v1.DriverService.ReserveDriver:output_type -> driver.v1.ReserveDriverResponse
+	21, // 26: driver.v1.DriverService.ReleaseDriver:output_type -> driver.v1.ReleaseDriverResponse
+	11, // 27: driver.v1.DriverService.OfferTrip:output_type -> driver.v1.OfferTripResponse
+	13, // 28: driver.v1.DriverService.AcceptTripOffer:output_type -> driver.v1.AcceptTripOfferResponse
+	15, // 29: driver.v1.DriverService.DeclineTripOffer:output_type -> driver.v1.DeclineTripOfferResponse
+	17, // 30: driver.v1.DriverService.GetOfferStats:output_type -> driver.v1.GetOfferStatsResponse
+	23, // 31: driver.v1.DriverService.UpdateLocation:output_type -> driver.v1.UpdateLocationResponse
+	24, // 32: driver.v1.DriverService.StreamLocation:output_type -> driver.v1.StreamLocationResponse
+	21, // [21:33] is the sub-list for method output_type
+	9,  // [9:21] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_driver_v1_driver_proto_init() }
@@ -456,7 +1714,7 @@ func file_api_proto_driver_v1_driver_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_driver_v1_driver_proto_rawDesc), len(file_api_proto_driver_v1_driver_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
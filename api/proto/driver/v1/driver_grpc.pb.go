@@ -20,8 +20,17 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	DriverService_RegisterDriver_FullMethodName       = "/driver.v1.DriverService/RegisterDriver"
+	DriverService_GetDriverByUser_FullMethodName      = "/driver.v1.DriverService/GetDriverByUser"
 	DriverService_FindAvailableDrivers_FullMethodName = "/driver.v1.DriverService/FindAvailableDrivers"
 	DriverService_UpdateDriverStatus_FullMethodName   = "/driver.v1.DriverService/UpdateDriverStatus"
+	DriverService_ReserveDriver_FullMethodName        = "/driver.v1.DriverService/ReserveDriver"
+	DriverService_ReleaseDriver_FullMethodName        = "/driver.v1.DriverService/ReleaseDriver"
+	DriverService_OfferTrip_FullMethodName            = "/driver.v1.DriverService/OfferTrip"
+	DriverService_AcceptTripOffer_FullMethodName      = "/driver.v1.DriverService/AcceptTripOffer"
+	DriverService_DeclineTripOffer_FullMethodName     = "/driver.v1.DriverService/DeclineTripOffer"
+	DriverService_GetOfferStats_FullMethodName        = "/driver.v1.DriverService/GetOfferStats"
+	DriverService_UpdateLocation_FullMethodName       = "/driver.v1.DriverService/UpdateLocation"
+	DriverService_StreamLocation_FullMethodName       = "/driver.v1.DriverService/StreamLocation"
 )
 
 // DriverServiceClient is the client API for DriverService service.
@@ -29,8 +38,28 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type DriverServiceClient interface {
 	RegisterDriver(ctx context.Context, in *RegisterDriverRequest, opts ...grpc.CallOption) (*RegisterDriverResponse, error)
+	// GetDriverByUser returns the driver registered by a user, or NOT_FOUND if
+	// the user is not a driver.
+	GetDriverByUser(ctx context.Context, in *GetDriverByUserRequest, opts ...grpc.CallOption) (*GetDriverByUserResponse, error)
 	FindAvailableDrivers(ctx context.Context, in *FindAvailableDriversRequest, opts ...grpc.CallOption) (*FindAvailableDriversResponse, error)
+	// UpdateDriverStatus takes a driver on or off duty. It fails with
+	// FAILED_PRECONDITION while the driver is reserved for a trip; the trip
+	// frees them through ReleaseDriver.
 	UpdateDriverStatus(ctx context.Context, in *UpdateDriverStatusRequest, opts ...grpc.CallOption) (*UpdateDriverStatusResponse, error)
+	// ReserveDriver claims an available driver for a trip. It fails with
+	// FAILED_PRECONDITION if the driver is busy or reserved for another trip.
+	ReserveDriver(ctx context.Context, in *ReserveDriverRequest, opts ...grpc.CallOption) (*ReserveDriverResponse, error)
+	// ReleaseDriver frees a driver reserved for the given trip.
+	ReleaseDriver(ctx context.Context, in *ReleaseDriverRequest, opts ...grpc.CallOption) (*ReleaseDriverResponse, error)
+	// OfferTrip blocks until the driver accepts or declines, or the offer expires.
+	OfferTrip(ctx context.Context, in *OfferTripRequest, opts ...grpc.CallOption) (*OfferTripResponse, error)
+	AcceptTripOffer(ctx context.Context, in *AcceptTripOfferRequest, opts ...grpc.CallOption) (*AcceptTripOfferResponse, error)
+	DeclineTripOffer(ctx context.Context, in *DeclineTripOfferRequest, opts ...grpc.CallOption) (*DeclineTripOfferResponse, error)
+	GetOfferStats(ctx context.Context, in *GetOfferStatsRequest, opts ...grpc.CallOption) (*GetOfferStatsResponse, error)
+	UpdateLocation(ctx context.Context, in *UpdateLocationRequest, opts ...grpc.CallOption) (*UpdateLocationResponse, error)
+	// StreamLocation accepts a stream of pings from one or more drivers and
+	// reports how many were applied when the client closes the stream.
+	StreamLocation(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UpdateLocationRequest, StreamLocationResponse], error)
 }
 
 type driverServiceClient struct {
@@ -51,6 +80,16 @@ func (c *driverServiceClient) RegisterDriver(ctx context.Context, in *RegisterDr
 	return out, nil
 }
 
+func (c *driverServiceClient) GetDriverByUser(ctx context.Context, in *GetDriverByUserRequest, opts ...grpc.CallOption) (*GetDriverByUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDriverByUserResponse)
+	err := c.cc.Invoke(ctx, DriverService_GetDriverByUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *driverServiceClient) FindAvailableDrivers(ctx context.Context, in *FindAvailableDriversRequest, opts ...grpc.CallOption) (*FindAvailableDriversResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(FindAvailableDriversResponse)
@@ -71,13 +110,116 @@ func (c *driverServiceClient) UpdateDriverStatus(ctx context.Context, in *Update
 	return out, nil
 }
 
+func (c *driverServiceClient) ReserveDriver(ctx context.Context, in *ReserveDriverRequest, opts ...grpc.CallOption) (*ReserveDriverResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReserveDriverResponse)
+	err := c.cc.Invoke(ctx, DriverService_ReserveDriver_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverServiceClient) ReleaseDriver(ctx context.Context, in *ReleaseDriverRequest, opts ...grpc.CallOption) (*ReleaseDriverResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseDriverResponse)
+	err := c.cc.Invoke(ctx, DriverService_ReleaseDriver_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverServiceClient) OfferTrip(ctx context.Context, in *OfferTripRequest, opts ...grpc.CallOption) (*OfferTripResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OfferTripResponse)
+	err := c.cc.Invoke(ctx, DriverService_OfferTrip_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverServiceClient) AcceptTripOffer(ctx context.Context, in *AcceptTripOfferRequest, opts ...grpc.CallOption) (*AcceptTripOfferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AcceptTripOfferResponse)
+	err := c.cc.Invoke(ctx, DriverService_AcceptTripOffer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverServiceClient) DeclineTripOffer(ctx context.Context, in *DeclineTripOfferRequest, opts ...grpc.CallOption) (*DeclineTripOfferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeclineTripOfferResponse)
+	err := c.cc.Invoke(ctx, DriverService_DeclineTripOffer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverServiceClient) GetOfferStats(ctx context.Context, in *GetOfferStatsRequest, opts ...grpc.CallOption) (*GetOfferStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOfferStatsResponse)
+	err := c.cc.Invoke(ctx, DriverService_GetOfferStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverServiceClient) UpdateLocation(ctx context.Context, in *UpdateLocationRequest, opts ...grpc.CallOption) (*UpdateLocationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateLocationResponse)
+	err := c.cc.Invoke(ctx, DriverService_UpdateLocation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverServiceClient) StreamLocation(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UpdateLocationRequest, StreamLocationResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DriverService_ServiceDesc.Streams[0], DriverService_StreamLocation_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UpdateLocationRequest, StreamLocationResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DriverService_StreamLocationClient = grpc.ClientStreamingClient[UpdateLocationRequest, StreamLocationResponse]
+
 // DriverServiceServer is the server API for DriverService service.
 // All implementations must embed UnimplementedDriverServiceServer
 // for forward compatibility.
 type DriverServiceServer interface {
 	RegisterDriver(context.Context, *RegisterDriverRequest) (*RegisterDriverResponse, error)
+	// GetDriverByUser returns the driver registered by a user, or NOT_FOUND if
+	// the user is not a driver.
+	GetDriverByUser(context.Context, *GetDriverByUserRequest) (*GetDriverByUserResponse, error)
 	FindAvailableDrivers(context.Context, *FindAvailableDriversRequest) (*FindAvailableDriversResponse, error)
+	// UpdateDriverStatus takes a driver on or off duty. It fails with
+	// FAILED_PRECONDITION while the driver is reserved for a trip; the trip
+	// frees them through ReleaseDriver.
 	UpdateDriverStatus(context.Context, *UpdateDriverStatusRequest) (*UpdateDriverStatusResponse, error)
+	// ReserveDriver claims an available driver for a trip. It fails with
+	// FAILED_PRECONDITION if the driver is busy or reserved for another trip.
+	ReserveDriver(context.Context, *ReserveDriverRequest) (*ReserveDriverResponse, error)
+	// ReleaseDriver frees a driver reserved for the given trip.
+	ReleaseDriver(context.Context, *ReleaseDriverRequest) (*ReleaseDriverResponse, error)
+	// OfferTrip blocks until the driver accepts or declines, or the offer expires.
+	OfferTrip(context.Context, *OfferTripRequest) (*OfferTripResponse, error)
+	AcceptTripOffer(context.Context, *AcceptTripOfferRequest) (*AcceptTripOfferResponse, error)
+	DeclineTripOffer(context.Context, *DeclineTripOfferRequest) (*DeclineTripOfferResponse, error)
+	GetOfferStats(context.Context, *GetOfferStatsRequest) (*GetOfferStatsResponse, error)
+	UpdateLocation(context.Context, *UpdateLocationRequest) (*UpdateLocationResponse, error)
+	// StreamLocation accepts a stream of pings from one or more drivers and
+	// reports how many were applied when the client closes the stream.
+	StreamLocation(grpc.ClientStreamingServer[UpdateLocationRequest, StreamLocationResponse]) error
 	mustEmbedUnimplementedDriverServiceServer()
 }
 
@@ -91,12 +233,39 @@ type UnimplementedDriverServiceServer struct{}
 func (UnimplementedDriverServiceServer) RegisterDriver(context.Context, *RegisterDriverRequest) (*RegisterDriverResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RegisterDriver not implemented")
 }
+func (UnimplementedDriverServiceServer) GetDriverByUser(context.Context, *GetDriverByUserRequest) (*GetDriverByUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDriverByUser not implemented")
+}
 func (UnimplementedDriverServiceServer) FindAvailableDrivers(context.Context, *FindAvailableDriversRequest) (*FindAvailableDriversResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FindAvailableDrivers not implemented")
 }
 func (UnimplementedDriverServiceServer) UpdateDriverStatus(context.Context, *UpdateDriverStatusRequest) (*UpdateDriverStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateDriverStatus not implemented")
 }
+func (UnimplementedDriverServiceServer) ReserveDriver(context.Context, *ReserveDriverRequest) (*ReserveDriverResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveDriver not implemented")
+}
+func (UnimplementedDriverServiceServer) ReleaseDriver(context.Context, *ReleaseDriverRequest) (*ReleaseDriverResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseDriver not implemented")
+}
+func (UnimplementedDriverServiceServer) OfferTrip(context.Context, *OfferTripRequest) (*OfferTripResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OfferTrip not implemented")
+}
+func (UnimplementedDriverServiceServer) AcceptTripOffer(context.Context, *AcceptTripOfferRequest) (*AcceptTripOfferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptTripOffer not implemented")
+}
+func (UnimplementedDriverServiceServer) DeclineTripOffer(context.Context, *DeclineTripOfferRequest) (*DeclineTripOfferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeclineTripOffer not implemented")
+}
+func (UnimplementedDriverServiceServer) GetOfferStats(context.Context, *GetOfferStatsRequest) (*GetOfferStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOfferStats not implemented")
+}
+func (UnimplementedDriverServiceServer) UpdateLocation(context.Context, *UpdateLocationRequest) (*UpdateLocationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLocation not implemented")
+}
+func (UnimplementedDriverServiceServer) StreamLocation(grpc.ClientStreamingServer[UpdateLocationRequest, StreamLocationResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamLocation not implemented")
+}
 func (UnimplementedDriverServiceServer) mustEmbedUnimplementedDriverServiceServer() {}
 func (UnimplementedDriverServiceServer) testEmbeddedByValue()                       {}
 
@@ -136,6 +305,24 @@ func _DriverService_RegisterDriver_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DriverService_GetDriverByUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDriverByUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServiceServer).GetDriverByUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DriverService_GetDriverByUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServiceServer).GetDriverByUser(ctx, req.(*GetDriverByUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DriverService_FindAvailableDrivers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FindAvailableDriversRequest)
 	if err := dec(in); err != nil {
@@ -172,6 +359,139 @@ func _DriverService_UpdateDriverStatus_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DriverService_ReserveDriver_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveDriverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServiceServer).ReserveDriver(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DriverService_ReserveDriver_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServiceServer).ReserveDriver(ctx, req.(*ReserveDriverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DriverService_ReleaseDriver_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseDriverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServiceServer).ReleaseDriver(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DriverService_ReleaseDriver_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServiceServer).ReleaseDriver(ctx, req.(*ReleaseDriverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DriverService_OfferTrip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OfferTripRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServiceServer).OfferTrip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DriverService_OfferTrip_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServiceServer).OfferTrip(ctx, req.(*OfferTripRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DriverService_AcceptTripOffer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptTripOfferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServiceServer).AcceptTripOffer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DriverService_AcceptTripOffer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServiceServer).AcceptTripOffer(ctx, req.(*AcceptTripOfferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DriverService_DeclineTripOffer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeclineTripOfferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServiceServer).DeclineTripOffer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DriverService_DeclineTripOffer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServiceServer).DeclineTripOffer(ctx, req.(*DeclineTripOfferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DriverService_GetOfferStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOfferStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServiceServer).GetOfferStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DriverService_GetOfferStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServiceServer).GetOfferStats(ctx, req.(*GetOfferStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DriverService_UpdateLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLocationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServiceServer).UpdateLocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DriverService_UpdateLocation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServiceServer).UpdateLocation(ctx, req.(*UpdateLocationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DriverService_StreamLocation_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DriverServiceServer).StreamLocation(&grpc.GenericServerStream[UpdateLocationRequest, StreamLocationResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DriverService_StreamLocationServer = grpc.ClientStreamingServer[UpdateLocationRequest, StreamLocationResponse]
+
 // DriverService_ServiceDesc is the grpc.ServiceDesc for DriverService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -183,6 +503,10 @@ var DriverService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RegisterDriver",
 			Handler:    _DriverService_RegisterDriver_Handler,
 		},
+		{
+			MethodName: "GetDriverByUser",
+			Handler:    _DriverService_GetDriverByUser_Handler,
+		},
 		{
 			MethodName: "FindAvailableDrivers",
 			Handler:    _DriverService_FindAvailableDrivers_Handler,
@@ -191,7 +515,41 @@ var DriverService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateDriverStatus",
 			Handler:    _DriverService_UpdateDriverStatus_Handler,
 		},
+		{
+			MethodName: "ReserveDriver",
+			Handler:    _DriverService_ReserveDriver_Handler,
+		},
+		{
+			MethodName: "ReleaseDriver",
+			Handler:    _DriverService_ReleaseDriver_Handler,
+		},
+		{
+			MethodName: "OfferTrip",
+			Handler:    _DriverService_OfferTrip_Handler,
+		},
+		{
+			MethodName: "AcceptTripOffer",
+			Handler:    _DriverService_AcceptTripOffer_Handler,
+		},
+		{
+			MethodName: "DeclineTripOffer",
+			Handler:    _DriverService_DeclineTripOffer_Handler,
+		},
+		{
+			MethodName: "GetOfferStats",
+			Handler:    _DriverService_GetOfferStats_Handler,
+		},
+		{
+			MethodName: "UpdateLocation",
+			Handler:    _DriverService_UpdateLocation_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamLocation",
+			Handler:       _DriverService_StreamLocation_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/driver/v1/driver.proto",
 }
@@ -0,0 +1,708 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: api/proto/events/v1/events.proto
+
+package v1
+
+import (
+	v1 "github.com/lukabrx/uber-clone/api/proto/money/v1"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Envelope wraps every event published on trip_events, driver_locations and
+// driver_offers.
+//
+// Fields may be added but never removed, renumbered or retyped; reserve the
+// number of a field that is no longer used. `task schema-check` and this
+// package's tests compare this file against events.lock.json and fail on
+// breaking changes.
+type Envelope struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unique per event, so consumers can drop redeliveries.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// What happened, e.g. TRIP_CREATED, DRIVER_UPDATED or TRIP_OFFER_CLOSED.
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// Version of the payload's meaning. Bump it when existing fields are
+	// interpreted differently; consumers reject versions newer than they know.
+	SchemaVersion int32                  `protobuf:"varint,3,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	Trace         *TraceContext          `protobuf:"bytes,5,opt,name=trace,proto3" json:"trace,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*Envelope_TripEvent
+	//	*Envelope_DriverUpdated
+	//	*Envelope_TripOffer
+	Payload       isEnvelope_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Envelope) Reset() {
+	*x = Envelope{}
+	mi := &file_api_proto_events_v1_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Envelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Envelope) ProtoMessage() {}
+
+func (x *Envelope) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_events_v1_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Envelope.ProtoReflect.Descriptor instead.
+func (*Envelope) Descriptor() ([]byte, []int) {
+	return file_api_proto_events_v1_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Envelope) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Envelope) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Envelope) GetSchemaVersion() int32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *Envelope) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *Envelope) GetTrace() *TraceContext {
+	if x != nil {
+		return x.Trace
+	}
+	return nil
+}
+
+func (x *Envelope) GetPayload() isEnvelope_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Envelope) GetTripEvent() *TripEvent {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_TripEvent); ok {
+			return x.TripEvent
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetDriverUpdated() *DriverUpdated {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_DriverUpdated); ok {
+			return x.DriverUpdated
+		}
+	}
+	return nil
+}
+
+func (x *Envelope) GetTripOffer() *TripOffer {
+	if x != nil {
+		if x, ok := x.Payload.(*Envelope_TripOffer); ok {
+			return x.TripOffer
+		}
+	}
+	return nil
+}
+
+type isEnvelope_Payload interface {
+	isEnvelope_Payload()
+}
+
+type Envelope_TripEvent struct {
+	TripEvent *TripEvent `protobuf:"bytes,10,opt,name=trip_event,json=tripEvent,proto3,oneof"`
+}
+
+type Envelope_DriverUpdated struct {
+	DriverUpdated *DriverUpdated `protobuf:"bytes,11,opt,name=driver_updated,json=driverUpdated,proto3,oneof"`
+}
+
+type Envelope_TripOffer struct {
+	TripOffer *TripOffer `protobuf:"bytes,12,opt,name=trip_offer,json=tripOffer,proto3,oneof"`
+}
+
+func (*Envelope_TripEvent) isEnvelope_Payload() {}
+
+func (*Envelope_DriverUpdated) isEnvelope_Payload() {}
+
+func (*Envelope_TripOffer) isEnvelope_Payload() {}
+
+// TraceContext is the W3C trace context of the request that caused the event.
+type TraceContext struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Traceparent   string                 `protobuf:"bytes,1,opt,name=traceparent,proto3" json:"traceparent,omitempty"`
+	Tracestate    string                 `protobuf:"bytes,2,opt,name=tracestate,proto3" json:"tracestate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TraceContext) Reset() {
+	*x = TraceContext{}
+	mi := &file_api_proto_events_v1_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TraceContext) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TraceContext) ProtoMessage() {}
+
+func (x *TraceContext) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_events_v1_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TraceContext.ProtoReflect.Descriptor instead.
+func (*TraceContext) Descriptor() ([]byte, []int) {
+	return file_api_proto_events_v1_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TraceContext) GetTraceparent() string {
+	if x != nil {
+		return x.Traceparent
+	}
+	return ""
+}
+
+func (x *TraceContext) GetTracestate() string {
+	if x != nil {
+		return x.Tracestate
+	}
+	return ""
+}
+
+// TripEvent is published on trip_events, keyed by trip ID.
+type TripEvent struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TripId string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	// Orders the events of one trip, starting at 1.
+	Version        int64   `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	DriverId       string  `protobuf:"bytes,3,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	Status         string  `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	PreviousStatus string  `protobuf:"bytes,5,opt,name=previous_status,json=previousStatus,proto3" json:"previous_status,omitempty"`
+	CancelledBy    string  `protobuf:"bytes,6,opt,name=cancelled_by,json=cancelledBy,proto3" json:"cancelled_by,omitempty"`
+	Reason         string  `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`
+	PickupLat      float64 `protobuf:"fixed64,8,opt,name=pickup_lat,json=pickupLat,proto3" json:"pickup_lat,omitempty"`
+	PickupLon      float64 `protobuf:"fixed64,9,opt,name=pickup_lon,json=pickupLon,proto3" json:"pickup_lon,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TripEvent) Reset() {
+	*x = TripEvent{}
+	mi := &file_api_proto_events_v1_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TripEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TripEvent) ProtoMessage() {}
+
+func (x *TripEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_events_v1_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TripEvent.ProtoReflect.Descriptor instead.
+func (*TripEvent) Descriptor() ([]byte, []int) {
+	return file_api_proto_events_v1_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TripEvent) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *TripEvent) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *TripEvent) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *TripEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TripEvent) GetPreviousStatus() string {
+	if x != nil {
+		return x.PreviousStatus
+	}
+	return ""
+}
+
+func (x *TripEvent) GetCancelledBy() string {
+	if x != nil {
+		return x.CancelledBy
+	}
+	return ""
+}
+
+func (x *TripEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *TripEvent) GetPickupLat() float64 {
+	if x != nil {
+		return x.PickupLat
+	}
+	return 0
+}
+
+func (x *TripEvent) GetPickupLon() float64 {
+	if x != nil {
+		return x.PickupLon
+	}
+	return 0
+}
+
+// DriverUpdated is published on driver_locations, keyed by driver ID, whenever
+// a driver registers, changes availability or reports a new location.
+type DriverUpdated struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	DriverId    string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	VehicleType string                 `protobuf:"bytes,3,opt,name=vehicle_type,json=vehicleType,proto3" json:"vehicle_type,omitempty"`
+	IsAvailable bool                   `protobuf:"varint,4,opt,name=is_available,json=isAvailable,proto3" json:"is_available,omitempty"`
+	Lat         float64                `protobuf:"fixed64,5,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lon         float64                `protobuf:"fixed64,6,opt,name=lon,proto3" json:"lon,omitempty"`
+	// Degrees clockwise from north.
+	Heading           float64                `protobuf:"fixed64,7,opt,name=heading,proto3" json:"heading,omitempty"`
+	SpeedKmh          float64                `protobuf:"fixed64,8,opt,name=speed_kmh,json=speedKmh,proto3" json:"speed_kmh,omitempty"`
+	LocationUpdatedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=location_updated_at,json=locationUpdatedAt,proto3" json:"location_updated_at,omitempty"`
+	// Trip the driver is reserved for, if any.
+	TripId        string `protobuf:"bytes,10,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DriverUpdated) Reset() {
+	*x = DriverUpdated{}
+	mi := &file_api_proto_events_v1_events_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriverUpdated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriverUpdated) ProtoMessage() {}
+
+func (x *DriverUpdated) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_events_v1_events_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverUpdated.ProtoReflect.Descriptor instead.
+func (*DriverUpdated) Descriptor() ([]byte, []int) {
+	return file_api_proto_events_v1_events_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DriverUpdated) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *DriverUpdated) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DriverUpdated) GetVehicleType() string {
+	if x != nil {
+		return x.VehicleType
+	}
+	return ""
+}
+
+func (x *DriverUpdated) GetIsAvailable() bool {
+	if x != nil {
+		return x.IsAvailable
+	}
+	return false
+}
+
+func (x *DriverUpdated) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *DriverUpdated) GetLon() float64 {
+	if x != nil {
+		return x.Lon
+	}
+	return 0
+}
+
+func (x *DriverUpdated) GetHeading() float64 {
+	if x != nil {
+		return x.Heading
+	}
+	return 0
+}
+
+func (x *DriverUpdated) GetSpeedKmh() float64 {
+	if x != nil {
+		return x.SpeedKmh
+	}
+	return 0
+}
+
+func (x *DriverUpdated) GetLocationUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LocationUpdatedAt
+	}
+	return nil
+}
+
+func (x *DriverUpdated) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+// TripOffer is published on driver_offers, keyed by driver ID, when a trip is
+// offered to a driver and when the offer closes without an answer.
+type TripOffer struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	OfferId    string                 `protobuf:"bytes,1,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
+	DriverId   string                 `protobuf:"bytes,2,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	TripId     string                 `protobuf:"bytes,3,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	PickupLat  float64                `protobuf:"fixed64,4,opt,name=pickup_lat,json=pickupLat,proto3" json:"pickup_lat,omitempty"`
+	PickupLon  float64                `protobuf:"fixed64,5,opt,name=pickup_lon,json=pickupLon,proto3" json:"pickup_lon,omitempty"`
+	DropoffLat float64                `protobuf:"fixed64,6,opt,name=dropoff_lat,json=dropoffLat,proto3" json:"dropoff_lat,omitempty"`
+	DropoffLon float64                `protobuf:"fixed64,7,opt,name=dropoff_lon,json=dropoffLon,proto3" json:"dropoff_lon,omitempty"`
+	Price      *v1.Money              `protobuf:"bytes,8,opt,name=price,proto3" json:"price,omitempty"`
+	ExpiresAt  *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// "expired" or "withdrawn" when the offer closed; empty when it was made.
+	Outcome       string `protobuf:"bytes,10,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TripOffer) Reset() {
+	*x = TripOffer{}
+	mi := &file_api_proto_events_v1_events_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TripOffer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TripOffer) ProtoMessage() {}
+
+func (x *TripOffer) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_events_v1_events_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TripOffer.ProtoReflect.Descriptor instead.
+func (*TripOffer) Descriptor() ([]byte, []int) {
+	return file_api_proto_events_v1_events_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TripOffer) GetOfferId() string {
+	if x != nil {
+		return x.OfferId
+	}
+	return ""
+}
+
+func (x *TripOffer) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *TripOffer) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *TripOffer) GetPickupLat() float64 {
+	if x != nil {
+		return x.PickupLat
+	}
+	return 0
+}
+
+func (x *TripOffer) GetPickupLon() float64 {
+	if x != nil {
+		return x.PickupLon
+	}
+	return 0
+}
+
+func (x *TripOffer) GetDropoffLat() float64 {
+	if x != nil {
+		return x.DropoffLat
+	}
+	return 0
+}
+
+func (x *TripOffer) GetDropoffLon() float64 {
+	if x != nil {
+		return x.DropoffLon
+	}
+	return 0
+}
+
+func (x *TripOffer) GetPrice() *v1.Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *TripOffer) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *TripOffer) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+var File_api_proto_events_v1_events_proto protoreflect.FileDescriptor
+
+const file_api_proto_events_v1_events_proto_rawDesc = "" +
+	"\n" +
+	" api/proto/events/v1/events.proto\x12\tevents.v1\x1a\x1eapi/proto/money/v1/money.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xfd\x02\n" +
+	"\bEnvelope\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12%\n" +
+	"\x0eschema_version\x18\x03 \x01(\x05R\rschemaVersion\x12;\n" +
+	"\voccurred_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12-\n" +
+	"\x05trace\x18\x05 \x01(\v2\x17.events.v1.TraceContextR\x05trace\x125\n" +
+	"\n" +
+	"trip_event\x18\n" +
+	" \x01(\v2\x14.events.v1.TripEventH\x00R\ttripEvent\x12A\n" +
+	"\x0edriver_updated\x18\v \x01(\v2\x18.events.v1.DriverUpdatedH\x00R\rdriverUpdated\x125\n" +
+	"\n" +
+	"trip_offer\x18\f \x01(\v2\x14.events.v1.TripOfferH\x00R\ttripOfferB\t\n" +
+	"\apayload\"P\n" +
+	"\fTraceContext\x12 \n" +
+	"\vtraceparent\x18\x01 \x01(\tR\vtraceparent\x12\x1e\n" +
+	"\n" +
+	"tracestate\x18\x02 \x01(\tR\n" +
+	"tracestate\"\x95\x02\n" +
+	"\tTripEvent\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\x12\x1b\n" +
+	"\tdriver_id\x18\x03 \x01(\tR\bdriverId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12'\n" +
+	"\x0fprevious_status\x18\x05 \x01(\tR\x0epreviousStatus\x12!\n" +
+	"\fcancelled_by\x18\x06 \x01(\tR\vcancelledBy\x12\x16\n" +
+	"\x06reason\x18\a \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"pickup_lat\x18\b \x01(\x01R\tpickupLat\x12\x1d\n" +
+	"\n" +
+	"pickup_lon\x18\t \x01(\x01R\tpickupLon\"\xc6\x02\n" +
+	"\rDriverUpdated\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12!\n" +
+	"\fvehicle_type\x18\x03 \x01(\tR\vvehicleType\x12!\n" +
+	"\fis_available\x18\x04 \x01(\bR\visAvailable\x12\x10\n" +
+	"\x03lat\x18\x05 \x01(\x01R\x03lat\x12\x10\n" +
+	"\x03lon\x18\x06 \x01(\x01R\x03lon\x12\x18\n" +
+	"\aheading\x18\a \x01(\x01R\aheading\x12\x1b\n" +
+	"\tspeed_kmh\x18\b \x01(\x01R\bspeedKmh\x12J\n" +
+	"\x13location_updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x11locationUpdatedAt\x12\x17\n" +
+	"\atrip_id\x18\n" +
+	" \x01(\tR\x06tripId\"\xd8\x02\n" +
+	"\tTripOffer\x12\x19\n" +
+	"\boffer_id\x18\x01 \x01(\tR\aofferId\x12\x1b\n" +
+	"\tdriver_id\x18\x02 \x01(\tR\bdriverId\x12\x17\n" +
+	"\atrip_id\x18\x03 \x01(\tR\x06tripId\x12\x1d\n" +
+	"\n" +
+	"pickup_lat\x18\x04 \x01(\x01R\tpickupLat\x12\x1d\n" +
+	"\n" +
+	"pickup_lon\x18\x05 \x01(\x01R\tpickupLon\x12\x1f\n" +
+	"\vdropoff_lat\x18\x06 \x01(\x01R\n" +
+	"dropoffLat\x12\x1f\n" +
+	"\vdropoff_lon\x18\a \x01(\x01R\n" +
+	"dropoffLon\x12%\n" +
+	"\x05price\x18\b \x01(\v2\x0f.money.v1.MoneyR\x05price\x129\n" +
+	"\n" +
+	"expires_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x18\n" +
+	"\aoutcome\x18\n" +
+	" \x01(\tR\aoutcomeB3Z1github.com/lukabrx/uber-clone/api/proto/events/v1b\x06proto3"
+
+var (
+	file_api_proto_events_v1_events_proto_rawDescOnce sync.Once
+	file_api_proto_events_v1_events_proto_rawDescData []byte
+)
+
+func file_api_proto_events_v1_events_proto_rawDescGZIP() []byte {
+	file_api_proto_events_v1_events_proto_rawDescOnce.Do(func() {
+		file_api_proto_events_v1_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_proto_events_v1_events_proto_rawDesc), len(file_api_proto_events_v1_events_proto_rawDesc)))
+	})
+	return file_api_proto_events_v1_events_proto_rawDescData
+}
+
+var file_api_proto_events_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_api_proto_events_v1_events_proto_goTypes = []any{
+	(*Envelope)(nil),              // 0: events.v1.Envelope
+	(*TraceContext)(nil),          // 1: events.v1.TraceContext
+	(*TripEvent)(nil),             // 2: events.v1.TripEvent
+	(*DriverUpdated)(nil),         // 3: events.v1.DriverUpdated
+	(*TripOffer)(nil),             // 4: events.v1.TripOffer
+	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+	(*v1.Money)(nil),              // 6: money.v1.Money
+}
+var file_api_proto_events_v1_events_proto_depIdxs = []int32{
+	5, // 0: events.v1.Envelope.occurred_at:type_name -> google.protobuf.Timestamp
+	1, // 1: events.v1.Envelope.trace:type_name -> events.v1.TraceContext
+	2, // 2: events.v1.Envelope.trip_event:type_name -> events.v1.TripEvent
+	3, // 3: events.v1.Envelope.driver_updated:type_name -> events.v1.DriverUpdated
+	4, // 4: events.v1.Envelope.trip_offer:type_name -> events.v1.TripOffer
+	5, // 5: events.v1.DriverUpdated.location_updated_at:type_name -> google.protobuf.Timestamp
+	6, // 6: events.v1.TripOffer.price:type_name -> money.v1.Money
+	5, // 7: events.v1.TripOffer.expires_at:type_name -> google.protobuf.Timestamp
+	8, // [8:8] is the sub-list for method output_type
+	8, // [8:8] is the sub-list for method input_type
+	8, // [8:8] is the sub-list for extension type_name
+	8, // [8:8] is the sub-list for extension extendee
+	0, // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_api_proto_events_v1_events_proto_init() }
+func file_api_proto_events_v1_events_proto_init() {
+	if File_api_proto_events_v1_events_proto != nil {
+		return
+	}
+	file_api_proto_events_v1_events_proto_msgTypes[0].OneofWrappers = []any{
+		(*Envelope_TripEvent)(nil),
+		(*Envelope_DriverUpdated)(nil),
+		(*Envelope_TripOffer)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_events_v1_events_proto_rawDesc), len(file_api_proto_events_v1_events_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_api_proto_events_v1_events_proto_goTypes,
+		DependencyIndexes: file_api_proto_events_v1_events_proto_depIdxs,
+		MessageInfos:      file_api_proto_events_v1_events_proto_msgTypes,
+	}.Build()
+	File_api_proto_events_v1_events_proto = out.File
+	file_api_proto_events_v1_events_proto_goTypes = nil
+	file_api_proto_events_v1_events_proto_depIdxs = nil
+}
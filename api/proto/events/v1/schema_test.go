@@ -0,0 +1,28 @@
+package v1_test
+
+import (
+	"reflect"
+	"testing"
+
+	eventsv1 "github.com/lukabrx/uber-clone/api/proto/events/v1"
+	"github.com/lukabrx/uber-clone/internal/schema"
+)
+
+// TestSchemaCompatible fails on changes to events.proto, or the files it
+// imports, that would break consumers built against events.lock.json, the
+// same check as
+// `task schema-check`.
+func TestSchemaCompatible(t *testing.T) {
+	old, err := schema.ReadFile("events.lock.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	current := schema.Snapshot(eventsv1.File_api_proto_events_v1_events_proto)
+
+	for _, problem := range schema.Compare(old, current) {
+		t.Error(problem)
+	}
+	if !t.Failed() && !reflect.DeepEqual(old, current) {
+		t.Error("events.proto has additions missing from events.lock.json; run `task schema-check -- -update`")
+	}
+}
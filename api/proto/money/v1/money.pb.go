@@ -0,0 +1,132 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: api/proto/money/v1/money.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Money is an amount in minor units (e.g. cents) of an ISO 4217 currency.
+type Money struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Amount        int64                  `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Money) Reset() {
+	*x = Money{}
+	mi := &file_api_proto_money_v1_money_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_money_v1_money_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_api_proto_money_v1_money_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Money) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Money) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+var File_api_proto_money_v1_money_proto protoreflect.FileDescriptor
+
+const file_api_proto_money_v1_money_proto_rawDesc = "" +
+	"\n" +
+	"\x1eapi/proto/money/v1/money.proto\x12\bmoney.v1\";\n" +
+	"\x05Money\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrencyB2Z0github.com/lukabrx/uber-clone/api/proto/money/v1b\x06proto3"
+
+var (
+	file_api_proto_money_v1_money_proto_rawDescOnce sync.Once
+	file_api_proto_money_v1_money_proto_rawDescData []byte
+)
+
+func file_api_proto_money_v1_money_proto_rawDescGZIP() []byte {
+	file_api_proto_money_v1_money_proto_rawDescOnce.Do(func() {
+		file_api_proto_money_v1_money_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_proto_money_v1_money_proto_rawDesc), len(file_api_proto_money_v1_money_proto_rawDesc)))
+	})
+	return file_api_proto_money_v1_money_proto_rawDescData
+}
+
+var file_api_proto_money_v1_money_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_api_proto_money_v1_money_proto_goTypes = []any{
+	(*Money)(nil), // 0: money.v1.Money
+}
+var file_api_proto_money_v1_money_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_api_proto_money_v1_money_proto_init() }
+func file_api_proto_money_v1_money_proto_init() {
+	if File_api_proto_money_v1_money_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_money_v1_money_proto_rawDesc), len(file_api_proto_money_v1_money_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_api_proto_money_v1_money_proto_goTypes,
+		DependencyIndexes: file_api_proto_money_v1_money_proto_depIdxs,
+		MessageInfos:      file_api_proto_money_v1_money_proto_msgTypes,
+	}.Build()
+	File_api_proto_money_v1_money_proto = out.File
+	file_api_proto_money_v1_money_proto_goTypes = nil
+	file_api_proto_money_v1_money_proto_depIdxs = nil
+}
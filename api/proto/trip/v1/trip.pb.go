@@ -7,6 +7,7 @@
 package v1
 
 import (
+	v1 "github.com/lukabrx/uber-clone/api/proto/money/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -22,12 +23,32 @@ const (
 )
 
 type Trip struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	RiderId       string                 `protobuf:"bytes,2,opt,name=rider_id,json=riderId,proto3" json:"rider_id,omitempty"`
-	DriverId      string                 `protobuf:"bytes,3,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
-	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
-	Price         float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RiderId            string                 `protobuf:"bytes,2,opt,name=rider_id,json=riderId,proto3" json:"rider_id,omitempty"`
+	DriverId           string                 `protobuf:"bytes,3,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	Status             string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	CancelledBy        string                 `protobuf:"bytes,6,opt,name=cancelled_by,json=cancelledBy,proto3" json:"cancelled_by,omitempty"`
+	CancellationReason string                 `protobuf:"bytes,7,opt,name=cancellation_reason,json=cancellationReason,proto3" json:"cancellation_reason,omitempty"`
+	StartLat           float64                `protobuf:"fixed64,9,opt,name=start_lat,json=startLat,proto3" json:"start_lat,omitempty"`
+	StartLon           float64                `protobuf:"fixed64,10,opt,name=start_lon,json=startLon,proto3" json:"start_lon,omitempty"`
+	EndLat             float64                `protobuf:"fixed64,11,opt,name=end_lat,json=endLat,proto3" json:"end_lat,omitempty"`
+	EndLon             float64                `protobuf:"fixed64,12,opt,name=end_lon,json=endLon,proto3" json:"end_lon,omitempty"`
+	// Unix seconds.
+	RequestTime int64  `protobuf:"varint,13,opt,name=request_time,json=requestTime,proto3" json:"request_time,omitempty"`
+	UpdatedAt   int64  `protobuf:"varint,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	VehicleType string `protobuf:"bytes,15,opt,name=vehicle_type,json=vehicleType,proto3" json:"vehicle_type,omitempty"`
+	// Surge multiplier the price was computed with; 1 when there was no surge.
+	SurgeMultiplier float64   `protobuf:"fixed64,16,opt,name=surge_multiplier,json=surgeMultiplier,proto3" json:"surge_multiplier,omitempty"`
+	Price           *v1.Money `protobuf:"bytes,17,opt,name=price,proto3" json:"price,omitempty"`
+	CancellationFee *v1.Money `protobuf:"bytes,18,opt,name=cancellation_fee,json=cancellationFee,proto3" json:"cancellation_fee,omitempty"`
+	// Set at completion. final_price is what the rider is charged, based on
+	// the distance_km actually driven; price stays the upfront estimate.
+	FinalPrice *v1.Money `protobuf:"bytes,19,opt,name=final_price,json=finalPrice,proto3" json:"final_price,omitempty"`
+	DistanceKm float64   `protobuf:"fixed64,20,opt,name=distance_km,json=distanceKm,proto3" json:"distance_km,omitempty"`
+	// Unix seconds.
+	StartedAt     int64 `protobuf:"varint,21,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	CompletedAt   int64 `protobuf:"varint,22,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -90,21 +111,130 @@ func (x *Trip) GetStatus() string {
 	return ""
 }
 
-func (x *Trip) GetPrice() float64 {
+func (x *Trip) GetCancelledBy() string {
+	if x != nil {
+		return x.CancelledBy
+	}
+	return ""
+}
+
+func (x *Trip) GetCancellationReason() string {
+	if x != nil {
+		return x.CancellationReason
+	}
+	return ""
+}
+
+func (x *Trip) GetStartLat() float64 {
+	if x != nil {
+		return x.StartLat
+	}
+	return 0
+}
+
+func (x *Trip) GetStartLon() float64 {
+	if x != nil {
+		return x.StartLon
+	}
+	return 0
+}
+
+func (x *Trip) GetEndLat() float64 {
+	if x != nil {
+		return x.EndLat
+	}
+	return 0
+}
+
+func (x *Trip) GetEndLon() float64 {
+	if x != nil {
+		return x.EndLon
+	}
+	return 0
+}
+
+func (x *Trip) GetRequestTime() int64 {
+	if x != nil {
+		return x.RequestTime
+	}
+	return 0
+}
+
+func (x *Trip) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *Trip) GetVehicleType() string {
+	if x != nil {
+		return x.VehicleType
+	}
+	return ""
+}
+
+func (x *Trip) GetSurgeMultiplier() float64 {
+	if x != nil {
+		return x.SurgeMultiplier
+	}
+	return 0
+}
+
+func (x *Trip) GetPrice() *v1.Money {
 	if x != nil {
 		return x.Price
 	}
+	return nil
+}
+
+func (x *Trip) GetCancellationFee() *v1.Money {
+	if x != nil {
+		return x.CancellationFee
+	}
+	return nil
+}
+
+func (x *Trip) GetFinalPrice() *v1.Money {
+	if x != nil {
+		return x.FinalPrice
+	}
+	return nil
+}
+
+func (x *Trip) GetDistanceKm() float64 {
+	if x != nil {
+		return x.DistanceKm
+	}
+	return 0
+}
+
+func (x *Trip) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *Trip) GetCompletedAt() int64 {
+	if x != nil {
+		return x.CompletedAt
+	}
 	return 0
 }
 
 type CreateTripRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RiderId       string                 `protobuf:"bytes,1,opt,name=rider_id,json=riderId,proto3" json:"rider_id,omitempty"`
-	StartLat      float64                `protobuf:"fixed64,2,opt,name=start_lat,json=startLat,proto3" json:"start_lat,omitempty"`
-	StartLon      float64                `protobuf:"fixed64,3,opt,name=start_lon,json=startLon,proto3" json:"start_lon,omitempty"`
-	EndLat        float64                `protobuf:"fixed64,4,opt,name=end_lat,json=endLat,proto3" json:"end_lat,omitempty"`
-	EndLon        float64                `protobuf:"fixed64,5,opt,name=end_lon,json=endLon,proto3" json:"end_lon,omitempty"`
-	DriverId      string                 `protobuf:"bytes,6,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	RiderId  string                 `protobuf:"bytes,1,opt,name=rider_id,json=riderId,proto3" json:"rider_id,omitempty"`
+	StartLat float64                `protobuf:"fixed64,2,opt,name=start_lat,json=startLat,proto3" json:"start_lat,omitempty"`
+	StartLon float64                `protobuf:"fixed64,3,opt,name=start_lon,json=startLon,proto3" json:"start_lon,omitempty"`
+	EndLat   float64                `protobuf:"fixed64,4,opt,name=end_lat,json=endLat,proto3" json:"end_lat,omitempty"`
+	EndLon   float64                `protobuf:"fixed64,5,opt,name=end_lon,json=endLon,proto3" json:"end_lon,omitempty"`
+	// economy, comfort or xl. Defaults to economy.
+	VehicleType string `protobuf:"bytes,7,opt,name=vehicle_type,json=vehicleType,proto3" json:"vehicle_type,omitempty"`
+	// Books the trip at a price from GetFareQuote. Coordinates and vehicle type
+	// may be omitted and are then taken from the quote.
+	QuoteId       string `protobuf:"bytes,8,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -174,9 +304,16 @@ func (x *CreateTripRequest) GetEndLon() float64 {
 	return 0
 }
 
-func (x *CreateTripRequest) GetDriverId() string {
+func (x *CreateTripRequest) GetVehicleType() string {
 	if x != nil {
-		return x.DriverId
+		return x.VehicleType
+	}
+	return ""
+}
+
+func (x *CreateTripRequest) GetQuoteId() string {
+	if x != nil {
+		return x.QuoteId
 	}
 	return ""
 }
@@ -313,73 +450,1316 @@ func (x *CompleteTripResponse) GetTrip() *Trip {
 	return nil
 }
 
-var File_api_proto_trip_v1_trip_proto protoreflect.FileDescriptor
+type UpdateTripStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripId        string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_api_proto_trip_v1_trip_proto_rawDesc = "" +
-	"\n" +
-	"\x1capi/proto/trip/v1/trip.proto\x12\atrip.v1\"|\n" +
-	"\x04Trip\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
-	"\brider_id\x18\x02 \x01(\tR\ariderId\x12\x1b\n" +
-	"\tdriver_id\x18\x03 \x01(\tR\bdriverId\x12\x16\n" +
-	"\x06status\x18\x04 \x01(\tR\x06status\x12\x14\n" +
-	"\x05price\x18\x05 \x01(\x01R\x05price\"\xb7\x01\n" +
-	"\x11CreateTripRequest\x12\x19\n" +
-	"\brider_id\x18\x01 \x01(\tR\ariderId\x12\x1b\n" +
-	"\tstart_lat\x18\x02 \x01(\x01R\bstartLat\x12\x1b\n" +
-	"\tstart_lon\x18\x03 \x01(\x01R\bstartLon\x12\x17\n" +
-	"\aend_lat\x18\x04 \x01(\x01R\x06endLat\x12\x17\n" +
-	"\aend_lon\x18\x05 \x01(\x01R\x06endLon\x12\x1b\n" +
-	"\tdriver_id\x18\x06 \x01(\tR\bdriverId\"7\n" +
-	"\x12CreateTripResponse\x12!\n" +
-	"\x04trip\x18\x01 \x01(\v2\r.trip.v1.TripR\x04trip\".\n" +
-	"\x13CompleteTripRequest\x12\x17\n" +
-	"\atrip_id\x18\x01 \x01(\tR\x06tripId\"9\n" +
-	"\x14CompleteTripResponse\x12!\n" +
-	"\x04trip\x18\x01 \x01(\v2\r.trip.v1.TripR\x04trip2\xa1\x01\n" +
-	"\vTripService\x12E\n" +
-	"\n" +
-	"CreateTrip\x12\x1a.trip.v1.CreateTripRequest\x1a\x1b.trip.v1.CreateTripResponse\x12K\n" +
-	"\fCompleteTrip\x12\x1c.trip.v1.CompleteTripRequest\x1a\x1d.trip.v1.CompleteTripResponseB\x18Z\x16uber-clone/pkg/trip/v1b\x06proto3"
+func (x *UpdateTripStatusRequest) Reset() {
+	*x = UpdateTripStatusRequest{}
+	mi := &file_api_proto_trip_v1_trip_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-var (
-	file_api_proto_trip_v1_trip_proto_rawDescOnce sync.Once
-	file_api_proto_trip_v1_trip_proto_rawDescData []byte
-)
+func (x *UpdateTripStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func file_api_proto_trip_v1_trip_proto_rawDescGZIP() []byte {
-	file_api_proto_trip_v1_trip_proto_rawDescOnce.Do(func() {
-		file_api_proto_trip_v1_trip_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_proto_trip_v1_trip_proto_rawDesc), len(file_api_proto_trip_v1_trip_proto_rawDesc)))
-	})
-	return file_api_proto_trip_v1_trip_proto_rawDescData
+func (*UpdateTripStatusRequest) ProtoMessage() {}
+
+func (x *UpdateTripStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trip_v1_trip_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var file_api_proto_trip_v1_trip_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
-var file_api_proto_trip_v1_trip_proto_goTypes = []any{
-	(*Trip)(nil),                 // 0: trip.v1.Trip
-	(*CreateTripRequest)(nil),    // 1: trip.v1.CreateTripRequest
-	(*CreateTripResponse)(nil),   // 2: trip.v1.CreateTripResponse
-	(*CompleteTripRequest)(nil),  // 3: trip.v1.CompleteTripRequest
-	(*CompleteTripResponse)(nil), // 4: trip.v1.CompleteTripResponse
+// Deprecated: Use UpdateTripStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTripStatusRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_trip_v1_trip_proto_rawDescGZIP(), []int{5}
 }
-var file_api_proto_trip_v1_trip_proto_depIdxs = []int32{
-	0, // 0: trip.v1.CreateTripResponse.trip:type_name -> trip.v1.Trip
-	0, // 1: trip.v1.CompleteTripResponse.trip:type_name -> trip.v1.Trip
-	1, // 2: trip.v1.TripService.CreateTrip:input_type -> trip.v1.CreateTripRequest
-	3, // 3: trip.v1.TripService.CompleteTrip:input_type -> trip.v1.CompleteTripRequest
-	2, // 4: trip.v1.TripService.CreateTrip:output_type -> trip.v1.CreateTripResponse
-	4, // 5: trip.v1.TripService.CompleteTrip:output_type -> trip.v1.CompleteTripResponse
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+
+func (x *UpdateTripStatusRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
 }
 
-func init() { file_api_proto_trip_v1_trip_proto_init() }
-func file_api_proto_trip_v1_trip_proto_init() {
-	if File_api_proto_trip_v1_trip_proto != nil {
-		return
+func (x *UpdateTripStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type UpdateTripStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Trip          *Trip                  `protobuf:"bytes,1,opt,name=trip,proto3" json:"trip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTripStatusResponse) Reset() {
+	*x = UpdateTripStatusResponse{}
+	mi := &file_api_proto_trip_v1_trip_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTripStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTripStatusResponse) ProtoMessage() {}
+
+func (x *UpdateTripStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trip_v1_trip_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTripStatusResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTripStatusResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_trip_v1_trip_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateTripStatusResponse) GetTrip() *Trip {
+	if x != nil {
+		return x.Trip
+	}
+	return nil
+}
+
+type CancelTripRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TripId string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	// "rider" or "driver".
+	CancelledBy string `protobuf:"bytes,2,opt,name=cancelled_by,json=cancelledBy,proto3" json:"cancelled_by,omitempty"`
+	// The rider or driver ID of whoever is cancelling.
+	CancellerId   string `protobuf:"bytes,3,opt,name=canceller_id,json=cancellerId,proto3" json:"canceller_id,omitempty"`
+	Reason        string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelTripRequest) Reset() {
+	*x = CancelTripRequest{}
+	mi := &file_api_proto_trip_v1_trip_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTripRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTripRequest) ProtoMessage() {}
+
+func (x *CancelTripRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trip_v1_trip_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTripRequest.ProtoReflect.Descriptor instead.
+func (*CancelTripRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_trip_v1_trip_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CancelTripRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *CancelTripRequest) GetCancelledBy() string {
+	if x != nil {
+		return x.CancelledBy
+	}
+	return ""
+}
+
+func (x *CancelTripRequest) GetCancellerId() string {
+	if x != nil {
+		return x.CancellerId
+	}
+	return ""
+}
+
+func (x *CancelTripRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CancelTripResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Trip          *Trip                  `protobuf:"bytes,1,opt,name=trip,proto3" json:"trip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelTripResponse) Reset() {
+	*x = CancelTripResponse{}
+	mi := &file_api_proto_trip_v1_trip_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelTripResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelTripResponse) ProtoMessage() {}
+
+func (x *CancelTripResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trip_v1_trip_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelTripResponse.ProtoReflect.Descriptor instead.
+func (*CancelTripResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_trip_v1_trip_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CancelTripResponse) GetTrip() *Trip {
+	if x != nil {
+		return x.Trip
+	}
+	return nil
+}
+
+type GetTripRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripId        string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTripRequest) Reset() {
+	*x = GetTripRequest{}
+	mi := &file_api_proto_trip_v1_trip_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTripRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTripRequest) ProtoMessage() {}
+
+func (x *GetTripRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trip_v1_trip_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTripRequest.ProtoReflect.Descriptor instead.
+func (*GetTripRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_trip_v1_trip_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetTripRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+type GetTripResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Trip          *Trip                  `protobuf:"bytes,1,opt,name=trip,proto3" json:"trip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTripResponse) Reset() {
+	*x = GetTripResponse{}
+	mi := &file_api_proto_trip_v1_trip_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTripResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTripResponse) ProtoMessage() {}
+
+func (x *GetTripResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trip_v1_trip_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTripResponse.ProtoReflect.Descriptor instead.
+func (*GetTripResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_trip_v1_trip_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetTripResponse) GetTrip() *Trip {
+	if x != nil {
+		return x.Trip
+	}
+	return nil
+}
+
+type ListTripsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	RiderId  string                 `protobuf:"bytes,1,opt,name=rider_id,json=riderId,proto3" json:"rider_id,omitempty"`
+	DriverId string                 `protobuf:"bytes,2,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	Status   string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	// Unix seconds bounding the request time, from inclusive and to exclusive;
+	// zero means unbounded. Bounds are whole seconds, so every trip requested
+	// during the second of from is included and none during the second of to.
+	From          int64  `protobuf:"varint,4,opt,name=from,proto3" json:"from,omitempty"`
+	To            int64  `protobuf:"varint,5,opt,name=to,proto3" json:"to,omitempty"`
+	PageSize      int32  `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTripsRequest) Reset() {
+	*x = ListTripsRequest{}
+	mi := &file_api_proto_trip_v1_trip_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTripsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTripsRequest) ProtoMessage() {}
+
+func (x *ListTripsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trip_v1_trip_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTripsRequest.ProtoReflect.Descriptor instead.
+func (*ListTripsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_trip_v1_trip_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListTripsRequest) GetRiderId() string {
+	if x != nil {
+		return x.RiderId
+	}
+	return ""
+}
+
+func (x *ListTripsRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *ListTripsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListTripsRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *ListTripsRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *ListTripsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTripsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListTripsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Trips         []*Trip                `protobuf:"bytes,1,rep,name=trips,proto3" json:"trips,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTripsResponse) Reset() {
+	*x = ListTripsResponse{}
+	mi := &file_api_proto_trip_v1_trip_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTripsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTripsResponse) ProtoMessage() {}
+
+func (x *ListTripsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trip_v1_trip_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTripsResponse.ProtoReflect.Descriptor instead.
+func (*ListTripsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_trip_v1_trip_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListTripsResponse) GetTrips() []*Trip {
+	if x != nil {
+		return x.Trips
+	}
+	return nil
+}
+
+func (x *ListTripsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetSurgeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lat           float64                `protobuf:"fixed64,1,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lon           float64                `protobuf:"fixed64,2,opt,name=lon,proto3" json:"lon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSurgeRequest) Reset() {
+	*x = GetSurgeRequest{}
+	mi := &file_api_proto_trip_v1_trip_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSurgeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSurgeRequest) ProtoMessage() {}
+
+func (x *GetSurgeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trip_v1_trip_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSurgeRequest.ProtoReflect.Descriptor instead.
+func (*GetSurgeRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_trip_v1_trip_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetSurgeRequest) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *GetSurgeRequest) GetLon() float64 {
+	if x != nil {
+		return x.Lon
+	}
+	return 0
+}
+
+type GetSurgeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ZoneId        string                 `protobuf:"bytes,1,opt,name=zone_id,json=zoneId,proto3" json:"zone_id,omitempty"`
+	Multiplier    float64                `protobuf:"fixed64,2,opt,name=multiplier,proto3" json:"multiplier,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSurgeResponse) Reset() {
+	*x = GetSurgeResponse{}
+	mi := &file_api_proto_trip_v1_trip_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSurgeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSurgeResponse) ProtoMessage() {}
+
+func (x *GetSurgeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trip_v1_trip_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSurgeResponse.ProtoReflect.Descriptor instead.
+func (*GetSurgeResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_trip_v1_trip_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetSurgeResponse) GetZoneId() string {
+	if x != nil {
+		return x.ZoneId
+	}
+	return ""
+}
+
+func (x *GetSurgeResponse) GetMultiplier() float64 {
+	if x != nil {
+		return x.Multiplier
+	}
+	return 0
+}
+
+type GetFareQuoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RiderId       string                 `protobuf:"bytes,1,opt,name=rider_id,json=riderId,proto3" json:"rider_id,omitempty"`
+	StartLat      float64                `protobuf:"fixed64,2,opt,name=start_lat,json=startLat,proto3" json:"start_lat,omitempty"`
+	StartLon      float64                `protobuf:"fixed64,3,opt,name=start_lon,json=startLon,proto3" json:"start_lon,omitempty"`
+	EndLat        float64                `protobuf:"fixed64,4,opt,name=end_lat,json=endLat,proto3" json:"end_lat,omitempty"`
+	EndLon        float64                `protobuf:"fixed64,5,opt,name=end_lon,json=endLon,proto3" json:"end_lon,omitempty"`
+	VehicleType   string                 `protobuf:"bytes,6,opt,name=vehicle_type,json=vehicleType,proto3" json:"vehicle_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFareQuoteRequest) Reset() {
+	*x = GetFareQuoteRequest{}
+	mi := &file_api_proto_trip_v1_trip_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFareQuoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFareQuoteRequest) ProtoMessage() {}
+
+func (x *GetFareQuoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trip_v1_trip_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFareQuoteRequest.ProtoReflect.Descriptor instead.
+func (*GetFareQuoteRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_trip_v1_trip_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetFareQuoteRequest) GetRiderId() string {
+	if x != nil {
+		return x.RiderId
+	}
+	return ""
+}
+
+func (x *GetFareQuoteRequest) GetStartLat() float64 {
+	if x != nil {
+		return x.StartLat
+	}
+	return 0
+}
+
+func (x *GetFareQuoteRequest) GetStartLon() float64 {
+	if x != nil {
+		return x.StartLon
+	}
+	return 0
+}
+
+func (x *GetFareQuoteRequest) GetEndLat() float64 {
+	if x != nil {
+		return x.EndLat
+	}
+	return 0
+}
+
+func (x *GetFareQuoteRequest) GetEndLon() float64 {
+	if x != nil {
+		return x.EndLon
+	}
+	return 0
+}
+
+func (x *GetFareQuoteRequest) GetVehicleType() string {
+	if x != nil {
+		return x.VehicleType
+	}
+	return ""
+}
+
+type FareBreakdown struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	City                  string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	BaseFare              *v1.Money              `protobuf:"bytes,2,opt,name=base_fare,json=baseFare,proto3" json:"base_fare,omitempty"`
+	DistanceFare          *v1.Money              `protobuf:"bytes,3,opt,name=distance_fare,json=distanceFare,proto3" json:"distance_fare,omitempty"`
+	TimeFare              *v1.Money              `protobuf:"bytes,4,opt,name=time_fare,json=timeFare,proto3" json:"time_fare,omitempty"`
+	MinimumFareAdjustment *v1.Money              `protobuf:"bytes,5,opt,name=minimum_fare_adjustment,json=minimumFareAdjustment,proto3" json:"minimum_fare_adjustment,omitempty"`
+	SurgeMultiplier       float64                `protobuf:"fixed64,6,opt,name=surge_multiplier,json=surgeMultiplier,proto3" json:"surge_multiplier,omitempty"`
+	Surge                 *v1.Money              `protobuf:"bytes,7,opt,name=surge,proto3" json:"surge,omitempty"`
+	BookingFee            *v1.Money              `protobuf:"bytes,8,opt,name=booking_fee,json=bookingFee,proto3" json:"booking_fee,omitempty"`
+	Tolls                 *v1.Money              `protobuf:"bytes,9,opt,name=tolls,proto3" json:"tolls,omitempty"`
+	Total                 *v1.Money              `protobuf:"bytes,10,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *FareBreakdown) Reset() {
+	*x = FareBreakdown{}
+	mi := &file_api_proto_trip_v1_trip_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FareBreakdown) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FareBreakdown) ProtoMessage() {}
+
+func (x *FareBreakdown) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trip_v1_trip_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FareBreakdown.ProtoReflect.Descriptor instead.
+func (*FareBreakdown) Descriptor() ([]byte, []int) {
+	return file_api_proto_trip_v1_trip_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *FareBreakdown) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *FareBreakdown) GetBaseFare() *v1.Money {
+	if x != nil {
+		return x.BaseFare
+	}
+	return nil
+}
+
+func (x *FareBreakdown) GetDistanceFare() *v1.Money {
+	if x != nil {
+		return x.DistanceFare
+	}
+	return nil
+}
+
+func (x *FareBreakdown) GetTimeFare() *v1.Money {
+	if x != nil {
+		return x.TimeFare
+	}
+	return nil
+}
+
+func (x *FareBreakdown) GetMinimumFareAdjustment() *v1.Money {
+	if x != nil {
+		return x.MinimumFareAdjustment
+	}
+	return nil
+}
+
+func (x *FareBreakdown) GetSurgeMultiplier() float64 {
+	if x != nil {
+		return x.SurgeMultiplier
+	}
+	return 0
+}
+
+func (x *FareBreakdown) GetSurge() *v1.Money {
+	if x != nil {
+		return x.Surge
+	}
+	return nil
+}
+
+func (x *FareBreakdown) GetBookingFee() *v1.Money {
+	if x != nil {
+		return x.BookingFee
+	}
+	return nil
+}
+
+func (x *FareBreakdown) GetTolls() *v1.Money {
+	if x != nil {
+		return x.Tolls
+	}
+	return nil
+}
+
+func (x *FareBreakdown) GetTotal() *v1.Money {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+type GetFareQuoteResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Pass to CreateTrip to book at this price.
+	QuoteId     string    `protobuf:"bytes,1,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`
+	Price       *v1.Money `protobuf:"bytes,2,opt,name=price,proto3" json:"price,omitempty"`
+	VehicleType string    `protobuf:"bytes,3,opt,name=vehicle_type,json=vehicleType,proto3" json:"vehicle_type,omitempty"`
+	DistanceKm  float64   `protobuf:"fixed64,4,opt,name=distance_km,json=distanceKm,proto3" json:"distance_km,omitempty"`
+	// Estimated time from pickup to dropoff.
+	DurationSeconds int64 `protobuf:"varint,5,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	// Estimated time for the nearest driver to reach the pickup; 0 if no driver
+	// is available.
+	PickupEtaSeconds int64 `protobuf:"varint,6,opt,name=pickup_eta_seconds,json=pickupEtaSeconds,proto3" json:"pickup_eta_seconds,omitempty"`
+	// Unix seconds.
+	ExpiresAt     int64          `protobuf:"varint,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Breakdown     *FareBreakdown `protobuf:"bytes,8,opt,name=breakdown,proto3" json:"breakdown,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFareQuoteResponse) Reset() {
+	*x = GetFareQuoteResponse{}
+	mi := &file_api_proto_trip_v1_trip_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFareQuoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFareQuoteResponse) ProtoMessage() {}
+
+func (x *GetFareQuoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trip_v1_trip_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFareQuoteResponse.ProtoReflect.Descriptor instead.
+func (*GetFareQuoteResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_trip_v1_trip_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetFareQuoteResponse) GetQuoteId() string {
+	if x != nil {
+		return x.QuoteId
+	}
+	return ""
+}
+
+func (x *GetFareQuoteResponse) GetPrice() *v1.Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *GetFareQuoteResponse) GetVehicleType() string {
+	if x != nil {
+		return x.VehicleType
+	}
+	return ""
+}
+
+func (x *GetFareQuoteResponse) GetDistanceKm() float64 {
+	if x != nil {
+		return x.DistanceKm
+	}
+	return 0
+}
+
+func (x *GetFareQuoteResponse) GetDurationSeconds() int64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *GetFareQuoteResponse) GetPickupEtaSeconds() int64 {
+	if x != nil {
+		return x.PickupEtaSeconds
+	}
+	return 0
+}
+
+func (x *GetFareQuoteResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *GetFareQuoteResponse) GetBreakdown() *FareBreakdown {
+	if x != nil {
+		return x.Breakdown
+	}
+	return nil
+}
+
+type WatchTripRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripId        string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchTripRequest) Reset() {
+	*x = WatchTripRequest{}
+	mi := &file_api_proto_trip_v1_trip_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTripRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTripRequest) ProtoMessage() {}
+
+func (x *WatchTripRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trip_v1_trip_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTripRequest.ProtoReflect.Descriptor instead.
+func (*WatchTripRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_trip_v1_trip_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *WatchTripRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+type DriverLocation struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	DriverId string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	Lat      float64                `protobuf:"fixed64,2,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lon      float64                `protobuf:"fixed64,3,opt,name=lon,proto3" json:"lon,omitempty"`
+	// Degrees clockwise from north.
+	Heading  float64 `protobuf:"fixed64,4,opt,name=heading,proto3" json:"heading,omitempty"`
+	SpeedKmh float64 `protobuf:"fixed64,5,opt,name=speed_kmh,json=speedKmh,proto3" json:"speed_kmh,omitempty"`
+	// Unix seconds.
+	RecordedAt    int64 `protobuf:"varint,6,opt,name=recorded_at,json=recordedAt,proto3" json:"recorded_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DriverLocation) Reset() {
+	*x = DriverLocation{}
+	mi := &file_api_proto_trip_v1_trip_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriverLocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriverLocation) ProtoMessage() {}
+
+func (x *DriverLocation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trip_v1_trip_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverLocation.ProtoReflect.Descriptor instead.
+func (*DriverLocation) Descriptor() ([]byte, []int) {
+	return file_api_proto_trip_v1_trip_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DriverLocation) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *DriverLocation) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *DriverLocation) GetLon() float64 {
+	if x != nil {
+		return x.Lon
+	}
+	return 0
+}
+
+func (x *DriverLocation) GetHeading() float64 {
+	if x != nil {
+		return x.Heading
+	}
+	return 0
+}
+
+func (x *DriverLocation) GetSpeedKmh() float64 {
+	if x != nil {
+		return x.SpeedKmh
+	}
+	return 0
+}
+
+func (x *DriverLocation) GetRecordedAt() int64 {
+	if x != nil {
+		return x.RecordedAt
+	}
+	return 0
+}
+
+type TripUpdate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Update:
+	//
+	//	*TripUpdate_Trip
+	//	*TripUpdate_DriverLocation
+	Update isTripUpdate_Update `protobuf_oneof:"update"`
+	// Estimated time for the driver to reach the pickup, or the dropoff once
+	// the trip has started; 0 while there is no driver or no known location.
+	EtaSeconds    int64 `protobuf:"varint,3,opt,name=eta_seconds,json=etaSeconds,proto3" json:"eta_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TripUpdate) Reset() {
+	*x = TripUpdate{}
+	mi := &file_api_proto_trip_v1_trip_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TripUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TripUpdate) ProtoMessage() {}
+
+func (x *TripUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_trip_v1_trip_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TripUpdate.ProtoReflect.Descriptor instead.
+func (*TripUpdate) Descriptor() ([]byte, []int) {
+	return file_api_proto_trip_v1_trip_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *TripUpdate) GetUpdate() isTripUpdate_Update {
+	if x != nil {
+		return x.Update
+	}
+	return nil
+}
+
+func (x *TripUpdate) GetTrip() *Trip {
+	if x != nil {
+		if x, ok := x.Update.(*TripUpdate_Trip); ok {
+			return x.Trip
+		}
+	}
+	return nil
+}
+
+func (x *TripUpdate) GetDriverLocation() *DriverLocation {
+	if x != nil {
+		if x, ok := x.Update.(*TripUpdate_DriverLocation); ok {
+			return x.DriverLocation
+		}
+	}
+	return nil
+}
+
+func (x *TripUpdate) GetEtaSeconds() int64 {
+	if x != nil {
+		return x.EtaSeconds
+	}
+	return 0
+}
+
+type isTripUpdate_Update interface {
+	isTripUpdate_Update()
+}
+
+type TripUpdate_Trip struct {
+	Trip *Trip `protobuf:"bytes,1,opt,name=trip,proto3,oneof"`
+}
+
+type TripUpdate_DriverLocation struct {
+	DriverLocation *DriverLocation `protobuf:"bytes,2,opt,name=driver_location,json=driverLocation,proto3,oneof"`
+}
+
+func (*TripUpdate_Trip) isTripUpdate_Update() {}
+
+func (*TripUpdate_DriverLocation) isTripUpdate_Update() {}
+
+var File_api_proto_trip_v1_trip_proto protoreflect.FileDescriptor
+
+const file_api_proto_trip_v1_trip_proto_rawDesc = "" +
+	"\n" +
+	"\x1capi/proto/trip/v1/trip.proto\x12\atrip.v1\x1a\x1eapi/proto/money/v1/money.proto\"\xba\x05\n" +
+	"\x04Trip\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\brider_id\x18\x02 \x01(\tR\ariderId\x12\x1b\n" +
+	"\tdriver_id\x18\x03 \x01(\tR\bdriverId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12!\n" +
+	"\fcancelled_by\x18\x06 \x01(\tR\vcancelledBy\x12/\n" +
+	"\x13cancellation_reason\x18\a \x01(\tR\x12cancellationReason\x12\x1b\n" +
+	"\tstart_lat\x18\t \x01(\x01R\bstartLat\x12\x1b\n" +
+	"\tstart_lon\x18\n" +
+	" \x01(\x01R\bstartLon\x12\x17\n" +
+	"\aend_lat\x18\v \x01(\x01R\x06endLat\x12\x17\n" +
+	"\aend_lon\x18\f \x01(\x01R\x06endLon\x12!\n" +
+	"\frequest_time\x18\r \x01(\x03R\vrequestTime\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x0e \x01(\x03R\tupdatedAt\x12!\n" +
+	"\fvehicle_type\x18\x0f \x01(\tR\vvehicleType\x12)\n" +
+	"\x10surge_multiplier\x18\x10 \x01(\x01R\x0fsurgeMultiplier\x12%\n" +
+	"\x05price\x18\x11 \x01(\v2\x0f.money.v1.MoneyR\x05price\x12:\n" +
+	"\x10cancellation_fee\x18\x12 \x01(\v2\x0f.money.v1.MoneyR\x0fcancellationFee\x120\n" +
+	"\vfinal_price\x18\x13 \x01(\v2\x0f.money.v1.MoneyR\n" +
+	"finalPrice\x12\x1f\n" +
+	"\vdistance_km\x18\x14 \x01(\x01R\n" +
+	"distanceKm\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x15 \x01(\x03R\tstartedAt\x12!\n" +
+	"\fcompleted_at\x18\x16 \x01(\x03R\vcompletedAtJ\x04\b\x05\x10\x06J\x04\b\b\x10\t\"\xe9\x01\n" +
+	"\x11CreateTripRequest\x12\x19\n" +
+	"\brider_id\x18\x01 \x01(\tR\ariderId\x12\x1b\n" +
+	"\tstart_lat\x18\x02 \x01(\x01R\bstartLat\x12\x1b\n" +
+	"\tstart_lon\x18\x03 \x01(\x01R\bstartLon\x12\x17\n" +
+	"\aend_lat\x18\x04 \x01(\x01R\x06endLat\x12\x17\n" +
+	"\aend_lon\x18\x05 \x01(\x01R\x06endLon\x12!\n" +
+	"\fvehicle_type\x18\a \x01(\tR\vvehicleType\x12\x19\n" +
+	"\bquote_id\x18\b \x01(\tR\aquoteIdJ\x04\b\x06\x10\aR\tdriver_id\"7\n" +
+	"\x12CreateTripResponse\x12!\n" +
+	"\x04trip\x18\x01 \x01(\v2\r.trip.v1.TripR\x04trip\".\n" +
+	"\x13CompleteTripRequest\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\"9\n" +
+	"\x14CompleteTripResponse\x12!\n" +
+	"\x04trip\x18\x01 \x01(\v2\r.trip.v1.TripR\x04trip\"J\n" +
+	"\x17UpdateTripStatusRequest\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"=\n" +
+	"\x18UpdateTripStatusResponse\x12!\n" +
+	"\x04trip\x18\x01 \x01(\v2\r.trip.v1.TripR\x04trip\"\x8a\x01\n" +
+	"\x11CancelTripRequest\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x12!\n" +
+	"\fcancelled_by\x18\x02 \x01(\tR\vcancelledBy\x12!\n" +
+	"\fcanceller_id\x18\x03 \x01(\tR\vcancellerId\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"7\n" +
+	"\x12CancelTripResponse\x12!\n" +
+	"\x04trip\x18\x01 \x01(\v2\r.trip.v1.TripR\x04trip\")\n" +
+	"\x0eGetTripRequest\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\"4\n" +
+	"\x0fGetTripResponse\x12!\n" +
+	"\x04trip\x18\x01 \x01(\v2\r.trip.v1.TripR\x04trip\"\xc2\x01\n" +
+	"\x10ListTripsRequest\x12\x19\n" +
+	"\brider_id\x18\x01 \x01(\tR\ariderId\x12\x1b\n" +
+	"\tdriver_id\x18\x02 \x01(\tR\bdriverId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x12\n" +
+	"\x04from\x18\x04 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x05 \x01(\x03R\x02to\x12\x1b\n" +
+	"\tpage_size\x18\x06 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\a \x01(\tR\tpageToken\"`\n" +
+	"\x11ListTripsResponse\x12#\n" +
+	"\x05trips\x18\x01 \x03(\v2\r.trip.v1.TripR\x05trips\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"5\n" +
+	"\x0fGetSurgeRequest\x12\x10\n" +
+	"\x03lat\x18\x01 \x01(\x01R\x03lat\x12\x10\n" +
+	"\x03lon\x18\x02 \x01(\x01R\x03lon\"K\n" +
+	"\x10GetSurgeResponse\x12\x17\n" +
+	"\azone_id\x18\x01 \x01(\tR\x06zoneId\x12\x1e\n" +
+	"\n" +
+	"multiplier\x18\x02 \x01(\x01R\n" +
+	"multiplier\"\xbf\x01\n" +
+	"\x13GetFareQuoteRequest\x12\x19\n" +
+	"\brider_id\x18\x01 \x01(\tR\ariderId\x12\x1b\n" +
+	"\tstart_lat\x18\x02 \x01(\x01R\bstartLat\x12\x1b\n" +
+	"\tstart_lon\x18\x03 \x01(\x01R\bstartLon\x12\x17\n" +
+	"\aend_lat\x18\x04 \x01(\x01R\x06endLat\x12\x17\n" +
+	"\aend_lon\x18\x05 \x01(\x01R\x06endLon\x12!\n" +
+	"\fvehicle_type\x18\x06 \x01(\tR\vvehicleType\"\xd0\x03\n" +
+	"\rFareBreakdown\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12,\n" +
+	"\tbase_fare\x18\x02 \x01(\v2\x0f.money.v1.MoneyR\bbaseFare\x124\n" +
+	"\rdistance_fare\x18\x03 \x01(\v2\x0f.money.v1.MoneyR\fdistanceFare\x12,\n" +
+	"\ttime_fare\x18\x04 \x01(\v2\x0f.money.v1.MoneyR\btimeFare\x12G\n" +
+	"\x17minimum_fare_adjustment\x18\x05 \x01(\v2\x0f.money.v1.MoneyR\x15minimumFareAdjustment\x12)\n" +
+	"\x10surge_multiplier\x18\x06 \x01(\x01R\x0fsurgeMultiplier\x12%\n" +
+	"\x05surge\x18\a \x01(\v2\x0f.money.v1.MoneyR\x05surge\x120\n" +
+	"\vbooking_fee\x18\b \x01(\v2\x0f.money.v1.MoneyR\n" +
+	"bookingFee\x12%\n" +
+	"\x05tolls\x18\t \x01(\v2\x0f.money.v1.MoneyR\x05tolls\x12%\n" +
+	"\x05total\x18\n" +
+	" \x01(\v2\x0f.money.v1.MoneyR\x05total\"\xca\x02\n" +
+	"\x14GetFareQuoteResponse\x12\x19\n" +
+	"\bquote_id\x18\x01 \x01(\tR\aquoteId\x12%\n" +
+	"\x05price\x18\x02 \x01(\v2\x0f.money.v1.MoneyR\x05price\x12!\n" +
+	"\fvehicle_type\x18\x03 \x01(\tR\vvehicleType\x12\x1f\n" +
+	"\vdistance_km\x18\x04 \x01(\x01R\n" +
+	"distanceKm\x12)\n" +
+	"\x10duration_seconds\x18\x05 \x01(\x03R\x0fdurationSeconds\x12,\n" +
+	"\x12pickup_eta_seconds\x18\x06 \x01(\x03R\x10pickupEtaSeconds\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\x03R\texpiresAt\x124\n" +
+	"\tbreakdown\x18\b \x01(\v2\x16.trip.v1.FareBreakdownR\tbreakdown\"+\n" +
+	"\x10WatchTripRequest\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\"\xa9\x01\n" +
+	"\x0eDriverLocation\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12\x10\n" +
+	"\x03lat\x18\x02 \x01(\x01R\x03lat\x12\x10\n" +
+	"\x03lon\x18\x03 \x01(\x01R\x03lon\x12\x18\n" +
+	"\aheading\x18\x04 \x01(\x01R\aheading\x12\x1b\n" +
+	"\tspeed_kmh\x18\x05 \x01(\x01R\bspeedKmh\x12\x1f\n" +
+	"\vrecorded_at\x18\x06 \x01(\x03R\n" +
+	"recordedAt\"\xa0\x01\n" +
+	"\n" +
+	"TripUpdate\x12#\n" +
+	"\x04trip\x18\x01 \x01(\v2\r.trip.v1.TripH\x00R\x04trip\x12B\n" +
+	"\x0fdriver_location\x18\x02 \x01(\v2\x17.trip.v1.DriverLocationH\x00R\x0edriverLocation\x12\x1f\n" +
+	"\veta_seconds\x18\x03 \x01(\x03R\n" +
+	"etaSecondsB\b\n" +
+	"\x06update2\x90\x05\n" +
+	"\vTripService\x12E\n" +
+	"\n" +
+	"CreateTrip\x12\x1a.trip.v1.CreateTripRequest\x1a\x1b.trip.v1.CreateTripResponse\x12K\n" +
+	"\fCompleteTrip\x12\x1c.trip.v1.CompleteTripRequest\x1a\x1d.trip.v1.CompleteTripResponse\x12W\n" +
+	"\x10UpdateTripStatus\x12 .trip.v1.UpdateTripStatusRequest\x1a!.trip.v1.UpdateTripStatusResponse\x12E\n" +
+	"\n" +
+	"CancelTrip\x12\x1a.trip.v1.CancelTripRequest\x1a\x1b.trip.v1.CancelTripResponse\x12<\n" +
+	"\aGetTrip\x12\x17.trip.v1.GetTripRequest\x1a\x18.trip.v1.GetTripResponse\x12B\n" +
+	"\tListTrips\x12\x19.trip.v1.ListTripsRequest\x1a\x1a.trip.v1.ListTripsResponse\x12?\n" +
+	"\bGetSurge\x12\x18.trip.v1.GetSurgeRequest\x1a\x19.trip.v1.GetSurgeResponse\x12K\n" +
+	"\fGetFareQuote\x12\x1c.trip.v1.GetFareQuoteRequest\x1a\x1d.trip.v1.GetFareQuoteResponse\x12=\n" +
+	"\tWatchTrip\x12\x19.trip.v1.WatchTripRequest\x1a\x13.trip.v1.TripUpdate0\x01B\x18Z\x16uber-clone/pkg/trip/v1b\x06proto3"
+
+var (
+	file_api_proto_trip_v1_trip_proto_rawDescOnce sync.Once
+	file_api_proto_trip_v1_trip_proto_rawDescData []byte
+)
+
+func file_api_proto_trip_v1_trip_proto_rawDescGZIP() []byte {
+	file_api_proto_trip_v1_trip_proto_rawDescOnce.Do(func() {
+		file_api_proto_trip_v1_trip_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_proto_trip_v1_trip_proto_rawDesc), len(file_api_proto_trip_v1_trip_proto_rawDesc)))
+	})
+	return file_api_proto_trip_v1_trip_proto_rawDescData
+}
+
+var file_api_proto_trip_v1_trip_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_api_proto_trip_v1_trip_proto_goTypes = []any{
+	(*Trip)(nil),                     // 0: trip.v1.Trip
+	(*CreateTripRequest)(nil),        // 1: trip.v1.CreateTripRequest
+	(*CreateTripResponse)(nil),       // 2: trip.v1.CreateTripResponse
+	(*CompleteTripRequest)(nil),      // 3: trip.v1.CompleteTripRequest
+	(*CompleteTripResponse)(nil),     // 4: trip.v1.CompleteTripResponse
+	(*UpdateTripStatusRequest)(nil),  // 5: trip.v1.UpdateTripStatusRequest
+	(*UpdateTripStatusResponse)(nil), // 6: trip.v1.UpdateTripStatusResponse
+	(*CancelTripRequest)(nil),        // 7: trip.v1.CancelTripRequest
+	(*CancelTripResponse)(nil),       // 8: trip.v1.CancelTripResponse
+	(*GetTripRequest)(nil),           // 9: trip.v1.GetTripRequest
+	(*GetTripResponse)(nil),          // 10: trip.v1.GetTripResponse
+	(*ListTripsRequest)(nil),         // 11: trip.v1.ListTripsRequest
+	(*ListTripsResponse)(nil),        // 12: trip.v1.ListTripsResponse
+	(*GetSurgeRequest)(nil),          // 13: trip.v1.GetSurgeRequest
+	(*GetSurgeResponse)(nil),         // 14: trip.v1.GetSurgeResponse
+	(*GetFareQuoteRequest)(nil),      // 15: trip.v1.GetFareQuoteRequest
+	(*FareBreakdown)(nil),            // 16: trip.v1.FareBreakdown
+	(*GetFareQuoteResponse)(nil),     // 17: trip.v1.GetFareQuoteResponse
+	(*WatchTripRequest)(nil),         // 18: trip.v1.WatchTripRequest
+	(*DriverLocation)(nil),           // 19: trip.v1.DriverLocation
+	(*TripUpdate)(nil),               // 20: trip.v1.TripUpdate
+	(*v1.Money)(nil),                 // 21: money.v1.Money
+}
+var file_api_proto_trip_v1_trip_proto_depIdxs = []int32{
+	21, // 0: trip.v1.Trip.price:type_name -> money.v1.Money
+	21, // 1: trip.v1.Trip.cancellation_fee:type_name -> money.v1.Money
+	21, // 2: trip.v1.Trip.final_price:type_name -> money.v1.Money
+	0,  // 3: trip.v1.CreateTripResponse.trip:type_name -> trip.v1.Trip
+	0,  // 4: trip.v1.CompleteTripResponse.trip:type_name -> trip.v1.Trip
+	0,  // 5: trip.v1.UpdateTripStatusResponse.trip:type_name -> trip.v1.Trip
+	0,  // 6: trip.v1.CancelTripResponse.trip:type_name -> trip.v1.Trip
+	0,  // 7: trip.v1.GetTripResponse.trip:type_name -> trip.v1.Trip
+	0,  // 8: trip.v1.ListTripsResponse.trips:type_name -> trip.v1.Trip
+	21, // 9: trip.v1.FareBreakdown.base_fare:type_name -> money.v1.Money
+	21, // 10: trip.v1.FareBreakdown.distance_fare:type_name -> money.v1.Money
+	21, // 11: trip.v1.FareBreakdown.time_fare:type_name -> money.v1.Money
+	21, // 12: trip.v1.FareBreakdown.minimum_fare_adjustment:type_name -> money.v1.Money
+	21, // 13: trip.v1.FareBreakdown.surge:type_name -> money.v1.Money
+	21, // 14: trip.v1.FareBreakdown.booking_fee:type_name -> money.v1.Money
+	21, // 15: trip.v1.FareBreakdown.tolls:type_name -> money.v1.Money
+	21, // 16: trip.v1.FareBreakdown.total:type_name -> money.v1.Money
+	21, // 17: trip.v1.GetFareQuoteResponse.price:type_name -> money.v1.Money
+	16, // 18: trip.v1.GetFareQuoteResponse.breakdown:type_name -> trip.v1.FareBreakdown
+	0,  // 19: trip.v1.TripUpdate.trip:type_name -> trip.v1.Trip
+	19, // 20: trip.v1.TripUpdate.driver_location:type_name -> trip.v1.DriverLocation
+	1,  // 21: trip.v1.TripService.CreateTrip:input_type -> trip.v1.CreateTripRequest
+	3,  // 22: trip.v1.TripService.CompleteTrip:input_type -> trip.v1.CompleteTripRequest
+	5,  // 23: trip.v1.TripService.UpdateTripStatus:input_type -> trip.v1.UpdateTripStatusRequest
+	7,  // 24: trip.v1.TripService.CancelTrip:input_type -> trip.v1.CancelTripRequest
+	9,  // 25: trip.v1.TripService.GetTrip:input_type -> trip.v1.GetTripRequest
+	11, // 26: trip.v1.TripService.ListTrips:input_type -> trip.v1.ListTripsRequest
+	13, // 27: trip.v1.TripService.GetSurge:input_type -> trip.v1.GetSurgeRequest
+	15, // 28: trip.v1.TripService.GetFareQuote:input_type -> trip.v1.GetFareQuoteRequest
+	18, // 29: trip.v1.TripService.WatchTrip:input_type -> trip.v1.WatchTripRequest
+	2,  // 30: trip.v1.TripService.CreateTrip:output_type -> trip.v1.CreateTripResponse
+	4,  // 31: trip.v1.TripService.CompleteTrip:output_type -> trip.v1.CompleteTripResponse
+	6,  // 32: trip.v1.TripService.UpdateTripStatus:output_type -> trip.v1.UpdateTripStatusResponse
+	8,  // 33: trip.v1.TripService.CancelTrip:output_type -> trip.v1.CancelTripResponse
+	10, // 34: trip.v1.TripService.GetTrip:output_type -> trip.v1.GetTripResponse
+	12, // 35: trip.v1.TripService.ListTrips:output_type -> trip.v1.ListTripsResponse
+	14, // 36: trip.v1.TripService.GetSurge:output_type -> trip.v1.GetSurgeResponse
+	17, // 37: trip.v1.TripService.GetFareQuote:output_type -> trip.v1.GetFareQuoteResponse
+	20, // 38: trip.v1.TripService.WatchTrip:output_type -> trip.v1.TripUpdate
+	30, // [30:39] is the sub-list for method output_type
+	21, // [21:30] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
+}
+
+func init() { file_api_proto_trip_v1_trip_proto_init() }
+func file_api_proto_trip_v1_trip_proto_init() {
+	if File_api_proto_trip_v1_trip_proto != nil {
+		return
+	}
+	file_api_proto_trip_v1_trip_proto_msgTypes[20].OneofWrappers = []any{
+		(*TripUpdate_Trip)(nil),
+		(*TripUpdate_DriverLocation)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -387,7 +1767,7 @@ func file_api_proto_trip_v1_trip_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_trip_v1_trip_proto_rawDesc), len(file_api_proto_trip_v1_trip_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
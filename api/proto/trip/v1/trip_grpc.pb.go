@@ -19,8 +19,15 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	TripService_CreateTrip_FullMethodName   = "/trip.v1.TripService/CreateTrip"
-	TripService_CompleteTrip_FullMethodName = "/trip.v1.TripService/CompleteTrip"
+	TripService_CreateTrip_FullMethodName       = "/trip.v1.TripService/CreateTrip"
+	TripService_CompleteTrip_FullMethodName     = "/trip.v1.TripService/CompleteTrip"
+	TripService_UpdateTripStatus_FullMethodName = "/trip.v1.TripService/UpdateTripStatus"
+	TripService_CancelTrip_FullMethodName       = "/trip.v1.TripService/CancelTrip"
+	TripService_GetTrip_FullMethodName          = "/trip.v1.TripService/GetTrip"
+	TripService_ListTrips_FullMethodName        = "/trip.v1.TripService/ListTrips"
+	TripService_GetSurge_FullMethodName         = "/trip.v1.TripService/GetSurge"
+	TripService_GetFareQuote_FullMethodName     = "/trip.v1.TripService/GetFareQuote"
+	TripService_WatchTrip_FullMethodName        = "/trip.v1.TripService/WatchTrip"
 )
 
 // TripServiceClient is the client API for TripService service.
@@ -29,6 +36,15 @@ const (
 type TripServiceClient interface {
 	CreateTrip(ctx context.Context, in *CreateTripRequest, opts ...grpc.CallOption) (*CreateTripResponse, error)
 	CompleteTrip(ctx context.Context, in *CompleteTripRequest, opts ...grpc.CallOption) (*CompleteTripResponse, error)
+	UpdateTripStatus(ctx context.Context, in *UpdateTripStatusRequest, opts ...grpc.CallOption) (*UpdateTripStatusResponse, error)
+	CancelTrip(ctx context.Context, in *CancelTripRequest, opts ...grpc.CallOption) (*CancelTripResponse, error)
+	GetTrip(ctx context.Context, in *GetTripRequest, opts ...grpc.CallOption) (*GetTripResponse, error)
+	ListTrips(ctx context.Context, in *ListTripsRequest, opts ...grpc.CallOption) (*ListTripsResponse, error)
+	GetSurge(ctx context.Context, in *GetSurgeRequest, opts ...grpc.CallOption) (*GetSurgeResponse, error)
+	GetFareQuote(ctx context.Context, in *GetFareQuoteRequest, opts ...grpc.CallOption) (*GetFareQuoteResponse, error)
+	// WatchTrip sends the trip as it is now, then every status change and
+	// driver location until the trip ends.
+	WatchTrip(ctx context.Context, in *WatchTripRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TripUpdate], error)
 }
 
 type tripServiceClient struct {
@@ -59,12 +75,100 @@ func (c *tripServiceClient) CompleteTrip(ctx context.Context, in *CompleteTripRe
 	return out, nil
 }
 
+func (c *tripServiceClient) UpdateTripStatus(ctx context.Context, in *UpdateTripStatusRequest, opts ...grpc.CallOption) (*UpdateTripStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateTripStatusResponse)
+	err := c.cc.Invoke(ctx, TripService_UpdateTripStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) CancelTrip(ctx context.Context, in *CancelTripRequest, opts ...grpc.CallOption) (*CancelTripResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelTripResponse)
+	err := c.cc.Invoke(ctx, TripService_CancelTrip_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) GetTrip(ctx context.Context, in *GetTripRequest, opts ...grpc.CallOption) (*GetTripResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTripResponse)
+	err := c.cc.Invoke(ctx, TripService_GetTrip_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) ListTrips(ctx context.Context, in *ListTripsRequest, opts ...grpc.CallOption) (*ListTripsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTripsResponse)
+	err := c.cc.Invoke(ctx, TripService_ListTrips_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) GetSurge(ctx context.Context, in *GetSurgeRequest, opts ...grpc.CallOption) (*GetSurgeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSurgeResponse)
+	err := c.cc.Invoke(ctx, TripService_GetSurge_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) GetFareQuote(ctx context.Context, in *GetFareQuoteRequest, opts ...grpc.CallOption) (*GetFareQuoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetFareQuoteResponse)
+	err := c.cc.Invoke(ctx, TripService_GetFareQuote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) WatchTrip(ctx context.Context, in *WatchTripRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TripUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TripService_ServiceDesc.Streams[0], TripService_WatchTrip_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchTripRequest, TripUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TripService_WatchTripClient = grpc.ServerStreamingClient[TripUpdate]
+
 // TripServiceServer is the server API for TripService service.
 // All implementations must embed UnimplementedTripServiceServer
 // for forward compatibility.
 type TripServiceServer interface {
 	CreateTrip(context.Context, *CreateTripRequest) (*CreateTripResponse, error)
 	CompleteTrip(context.Context, *CompleteTripRequest) (*CompleteTripResponse, error)
+	UpdateTripStatus(context.Context, *UpdateTripStatusRequest) (*UpdateTripStatusResponse, error)
+	CancelTrip(context.Context, *CancelTripRequest) (*CancelTripResponse, error)
+	GetTrip(context.Context, *GetTripRequest) (*GetTripResponse, error)
+	ListTrips(context.Context, *ListTripsRequest) (*ListTripsResponse, error)
+	GetSurge(context.Context, *GetSurgeRequest) (*GetSurgeResponse, error)
+	GetFareQuote(context.Context, *GetFareQuoteRequest) (*GetFareQuoteResponse, error)
+	// WatchTrip sends the trip as it is now, then every status change and
+	// driver location until the trip ends.
+	WatchTrip(*WatchTripRequest, grpc.ServerStreamingServer[TripUpdate]) error
 	mustEmbedUnimplementedTripServiceServer()
 }
 
@@ -81,6 +185,27 @@ func (UnimplementedTripServiceServer) CreateTrip(context.Context, *CreateTripReq
 func (UnimplementedTripServiceServer) CompleteTrip(context.Context, *CompleteTripRequest) (*CompleteTripResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CompleteTrip not implemented")
 }
+func (UnimplementedTripServiceServer) UpdateTripStatus(context.Context, *UpdateTripStatusRequest) (*UpdateTripStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTripStatus not implemented")
+}
+func (UnimplementedTripServiceServer) CancelTrip(context.Context, *CancelTripRequest) (*CancelTripResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelTrip not implemented")
+}
+func (UnimplementedTripServiceServer) GetTrip(context.Context, *GetTripRequest) (*GetTripResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrip not implemented")
+}
+func (UnimplementedTripServiceServer) ListTrips(context.Context, *ListTripsRequest) (*ListTripsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrips not implemented")
+}
+func (UnimplementedTripServiceServer) GetSurge(context.Context, *GetSurgeRequest) (*GetSurgeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSurge not implemented")
+}
+func (UnimplementedTripServiceServer) GetFareQuote(context.Context, *GetFareQuoteRequest) (*GetFareQuoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFareQuote not implemented")
+}
+func (UnimplementedTripServiceServer) WatchTrip(*WatchTripRequest, grpc.ServerStreamingServer[TripUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchTrip not implemented")
+}
 func (UnimplementedTripServiceServer) mustEmbedUnimplementedTripServiceServer() {}
 func (UnimplementedTripServiceServer) testEmbeddedByValue()                     {}
 
@@ -138,6 +263,125 @@ func _TripService_CompleteTrip_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TripService_UpdateTripStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTripStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).UpdateTripStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_UpdateTripStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).UpdateTripStatus(ctx, req.(*UpdateTripStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_CancelTrip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelTripRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).CancelTrip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_CancelTrip_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).CancelTrip(ctx, req.(*CancelTripRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_GetTrip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTripRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).GetTrip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_GetTrip_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).GetTrip(ctx, req.(*GetTripRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_ListTrips_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTripsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).ListTrips(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_ListTrips_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).ListTrips(ctx, req.(*ListTripsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_GetSurge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSurgeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).GetSurge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_GetSurge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).GetSurge(ctx, req.(*GetSurgeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_GetFareQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFareQuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).GetFareQuote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_GetFareQuote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).GetFareQuote(ctx, req.(*GetFareQuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_WatchTrip_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchTripRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TripServiceServer).WatchTrip(m, &grpc.GenericServerStream[WatchTripRequest, TripUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TripService_WatchTripServer = grpc.ServerStreamingServer[TripUpdate]
+
 // TripService_ServiceDesc is the grpc.ServiceDesc for TripService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,7 +397,37 @@ var TripService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CompleteTrip",
 			Handler:    _TripService_CompleteTrip_Handler,
 		},
+		{
+			MethodName: "UpdateTripStatus",
+			Handler:    _TripService_UpdateTripStatus_Handler,
+		},
+		{
+			MethodName: "CancelTrip",
+			Handler:    _TripService_CancelTrip_Handler,
+		},
+		{
+			MethodName: "GetTrip",
+			Handler:    _TripService_GetTrip_Handler,
+		},
+		{
+			MethodName: "ListTrips",
+			Handler:    _TripService_ListTrips_Handler,
+		},
+		{
+			MethodName: "GetSurge",
+			Handler:    _TripService_GetSurge_Handler,
+		},
+		{
+			MethodName: "GetFareQuote",
+			Handler:    _TripService_GetFareQuote_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchTrip",
+			Handler:       _TripService_WatchTrip_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/trip/v1/trip.proto",
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net"
 	"os"
@@ -8,6 +9,7 @@ import (
 	"github.com/joho/godotenv"
 	pb "github.com/lukabrx/uber-clone/api/proto/auth/v1"
 	"github.com/lukabrx/uber-clone/internal/auth"
+	"github.com/lukabrx/uber-clone/internal/database"
 	"github.com/lukabrx/uber-clone/internal/user"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
@@ -53,8 +55,22 @@ func main() {
 		log.Fatalf("failed to create paseto maker: %v", err)
 	}
 
-	userRepo := user.NewMemoryRepository()
-	refreshTokenRepo := auth.NewRefreshTokenRepository()
+	dbConfig, err := database.ConfigFromEnv()
+	if err != nil {
+		log.Fatalf("Invalid storage config: %v", err)
+	}
+	var userRepo user.UserRepository = user.NewMemoryRepository()
+	var refreshTokenRepo auth.RefreshTokenStore = auth.NewRefreshTokenRepository()
+	if dbConfig.Backend == database.BackendPostgres {
+		pool, err := database.Connect(context.Background(), dbConfig.URL)
+		if err != nil {
+			log.Fatalf("Failed to connect to database: %v", err)
+		}
+		defer pool.Close()
+		userRepo = user.NewPostgresRepository(pool)
+		refreshTokenRepo = auth.NewPostgresRefreshTokenRepository(pool)
+	}
+	log.Printf("Using %s storage", dbConfig.Backend)
 
 	service := auth.NewService(pasetoMaker, googleOauthConfig, userRepo, refreshTokenRepo)
 	handler := auth.NewGrpcHandler(service)
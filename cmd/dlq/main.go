@@ -0,0 +1,259 @@
+// Command dlq inspects the dead-letter topic and replays its messages.
+//
+//	dlq list [-n 20]                             show the newest dead-lettered messages
+//	dlq replay -partition P -offset N [-topic T] republish one message
+//	dlq replay -all [-topic T]                   republish every message
+//
+// A replayed message goes back to the topic it failed on, or to -topic, which
+// must be trip_events or driver_locations. It keeps its key, payload and
+// original headers. Replaying does not remove anything from the dead-letter
+// topic.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/lukabrx/uber-clone/internal/dlq"
+	"github.com/lukabrx/uber-clone/internal/eventbus"
+	"github.com/lukabrx/uber-clone/internal/eventbus/kafkabus"
+	"github.com/lukabrx/uber-clone/internal/events"
+	"github.com/lukabrx/uber-clone/internal/types"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// headerReplayedFrom records the dead-letter partition and offset a replayed
+// message was copied from.
+const headerReplayedFrom = "dlq-replayed-from"
+
+// readTimeout bounds how long readAll waits to reach the end of the topic.
+const readTimeout = 30 * time.Second
+
+var bootstrapServers = kafkabus.BootstrapServersFromEnv()
+
+func main() {
+	if len(os.Args) < 2 {
+		log.Fatal("Usage: dlq list|replay [flags]")
+	}
+
+	switch os.Args[1] {
+	case "list":
+		flags := flag.NewFlagSet("list", flag.ExitOnError)
+		n := flags.Int("n", 20, "number of messages to show, newest last")
+		flags.Parse(os.Args[2:])
+
+		msgs, err := readAll()
+		if err != nil {
+			log.Fatalf("Failed to read %s: %v", types.DeadLetterTopic, err)
+		}
+		if len(msgs) > *n {
+			msgs = msgs[len(msgs)-*n:]
+		}
+		for _, msg := range msgs {
+			printMessage(msg)
+		}
+		if len(msgs) == 0 {
+			log.Println("No dead-lettered messages")
+		}
+
+	case "replay":
+		flags := flag.NewFlagSet("replay", flag.ExitOnError)
+		partition := flags.Int("partition", 0, "partition of the message to replay")
+		offset := flags.Int64("offset", -1, "offset of the message to replay")
+		all := flags.Bool("all", false, "replay every dead-lettered message")
+		topic := flags.String("topic", "", "topic to replay to; defaults to the topic the message failed on")
+		flags.Parse(os.Args[2:])
+
+		if *all == (*offset >= 0) {
+			log.Fatal("Pass either -offset or -all")
+		}
+		if *topic != "" && *topic != types.TripEventsTopic && *topic != types.DriverLocationTopic {
+			log.Fatalf("Cannot replay to %q; use %s or %s", *topic, types.TripEventsTopic, types.DriverLocationTopic)
+		}
+
+		msgs, err := readAll()
+		if err != nil {
+			log.Fatalf("Failed to read %s: %v", types.DeadLetterTopic, err)
+		}
+		if !*all {
+			msgs = selectMessage(msgs, int32(*partition), *offset)
+			if msgs == nil {
+				log.Fatalf("No message at partition %d offset %d", *partition, *offset)
+			}
+		}
+		if err := replay(msgs, *topic); err != nil {
+			log.Fatalf("Replay failed: %v", err)
+		}
+
+	default:
+		log.Fatalf("Unknown command %q; use list or replay", os.Args[1])
+	}
+}
+
+// readAll reads the dead-letter topic from the start up to the high watermark
+// each partition had when it was called, without joining a consumer group or
+// committing offsets.
+func readAll() ([]eventbus.Message, error) {
+	consumer, err := kafka.NewConsumer(&kafka.ConfigMap{
+		"bootstrap.servers":  bootstrapServers,
+		"group.id":           "dlq_admin",
+		"enable.auto.commit": false,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer consumer.Close()
+
+	metadata, err := consumer.GetMetadata(&types.DeadLetterTopic, false, 5000)
+	if err != nil {
+		return nil, err
+	}
+	topic, ok := metadata.Topics[types.DeadLetterTopic]
+	if !ok || topic.Error.Code() == kafka.ErrUnknownTopicOrPart {
+		return nil, nil
+	}
+
+	var assignments []kafka.TopicPartition
+	ends := make(map[int32]kafka.Offset)
+	for _, p := range topic.Partitions {
+		low, high, err := consumer.QueryWatermarkOffsets(types.DeadLetterTopic, p.ID, 5000)
+		if err != nil {
+			return nil, err
+		}
+		if low < high {
+			assignments = append(assignments, kafka.TopicPartition{Topic: &types.DeadLetterTopic, Partition: p.ID, Offset: kafka.Offset(low)})
+			ends[p.ID] = kafka.Offset(high)
+		}
+	}
+	if len(assignments) == 0 {
+		return nil, nil
+	}
+	if err := consumer.Assign(assignments); err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(readTimeout)
+	var msgs []eventbus.Message
+	for len(ends) > 0 {
+		msg, err := consumer.ReadMessage(time.Second)
+		var kafkaErr kafka.Error
+		if errors.As(err, &kafkaErr) && kafkaErr.IsTimeout() {
+			// Offsets can have gaps, e.g. for transaction markers, so the last
+			// message may come before the high watermark. The consumer's
+			// position tells whether it has read up to it.
+			if err := dropFinished(consumer, ends); err != nil {
+				return nil, err
+			}
+			if len(ends) > 0 && time.Now().After(deadline) {
+				return nil, fmt.Errorf("%d partition(s) not read to the end within %s", len(ends), readTimeout)
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, kafkabus.FromKafka(msg))
+		p := msg.TopicPartition
+		if p.Offset+1 >= ends[p.Partition] {
+			delete(ends, p.Partition)
+		}
+	}
+	return msgs, nil
+}
+
+// dropFinished removes the partitions the consumer has read up to their end
+// from ends.
+func dropFinished(consumer *kafka.Consumer, ends map[int32]kafka.Offset) error {
+	var partitions []kafka.TopicPartition
+	for p := range ends {
+		partitions = append(partitions, kafka.TopicPartition{Topic: &types.DeadLetterTopic, Partition: p})
+	}
+	positions, err := consumer.Position(partitions)
+	if err != nil {
+		return err
+	}
+	for _, p := range positions {
+		if p.Offset >= ends[p.Partition] {
+			delete(ends, p.Partition)
+		}
+	}
+	return nil
+}
+
+func selectMessage(msgs []eventbus.Message, partition int32, offset int64) []eventbus.Message {
+	for _, msg := range msgs {
+		if msg.Partition == partition && msg.Offset == offset {
+			return []eventbus.Message{msg}
+		}
+	}
+	return nil
+}
+
+func printMessage(msg eventbus.Message) {
+	fmt.Printf("partition %d offset %d\n", msg.Partition, msg.Offset)
+	fmt.Printf("  from     %s [%s] @ %s by %s\n",
+		msg.Header(dlq.HeaderOriginalTopic), msg.Header(dlq.HeaderOriginalPartition),
+		msg.Header(dlq.HeaderOriginalOffset), msg.Header(dlq.HeaderConsumerGroup))
+	fmt.Printf("  failed   %s after %s attempt(s)\n", msg.Header(dlq.HeaderFailedAt), msg.Header(dlq.HeaderAttempts))
+	fmt.Printf("  error    %s\n", msg.Header(dlq.HeaderError))
+	fmt.Printf("  key      %s\n", msg.Key)
+	fmt.Printf("  payload  %s\n", payload(msg))
+}
+
+// payload renders the events.v1 envelopes on trip_events and driver_locations
+// as JSON; anything else is printed as it is.
+func payload(msg eventbus.Message) string {
+	switch msg.Header(dlq.HeaderOriginalTopic) {
+	case types.TripEventsTopic, types.DriverLocationTopic:
+		if env, err := events.Unmarshal(msg.Value); err == nil {
+			return protojson.Format(env)
+		}
+	}
+	return string(msg.Value)
+}
+
+func replay(msgs []eventbus.Message, topic string) error {
+	publisher, err := kafkabus.NewPublisher(bootstrapServers)
+	if err != nil {
+		return err
+	}
+	defer publisher.Close()
+
+	ctx := context.Background()
+	for _, msg := range msgs {
+		target := topic
+		if target == "" {
+			target = msg.Header(dlq.HeaderOriginalTopic)
+		}
+		if target != types.TripEventsTopic && target != types.DriverLocationTopic {
+			log.Printf("Skipping partition %d offset %d: cannot replay to %q", msg.Partition, msg.Offset, target)
+			continue
+		}
+
+		var headers []eventbus.Header
+		for _, h := range msg.Headers {
+			if !strings.HasPrefix(h.Key, "dlq-") {
+				headers = append(headers, h)
+			}
+		}
+		headers = append(headers, eventbus.Header{
+			Key:   headerReplayedFrom,
+			Value: []byte(strconv.Itoa(int(msg.Partition)) + "/" + strconv.FormatInt(msg.Offset, 10)),
+		})
+
+		err := publisher.Publish(ctx, eventbus.Message{Topic: target, Key: msg.Key, Value: msg.Value, Headers: headers})
+		if err != nil {
+			return fmt.Errorf("partition %d offset %d: %w", msg.Partition, msg.Offset, err)
+		}
+		log.Printf("Replayed partition %d offset %d to %s", msg.Partition, msg.Offset, target)
+	}
+	return nil
+}
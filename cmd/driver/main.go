@@ -1,39 +1,86 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net"
+	"os"
+	"time"
 
+	"github.com/joho/godotenv"
 	pb "github.com/lukabrx/uber-clone/api/proto/driver/v1"
+	"github.com/lukabrx/uber-clone/internal/database"
+	"github.com/lukabrx/uber-clone/internal/dlq"
 	"github.com/lukabrx/uber-clone/internal/driver"
+	"github.com/lukabrx/uber-clone/internal/eventbus/kafkabus"
+	"github.com/lukabrx/uber-clone/internal/outbox"
 	"google.golang.org/grpc"
 )
 
 func main() {
+	if err := godotenv.Load("../../.env"); err != nil {
+		log.Println("No .env file found or error loading .env file:", err)
+	}
+
+	// How long a driver has to accept a trip offer before it goes to the next driver.
+	offerWindow := 15 * time.Second
+	if v := os.Getenv("DRIVER_OFFER_WINDOW"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			log.Fatalf("invalid DRIVER_OFFER_WINDOW %q: %v", v, err)
+		}
+		offerWindow = d
+	}
+
 	lis, err := net.Listen("tcp", ":50051")
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
-	kafkaProducer, err := driver.NewKafkaProducer("localhost:29092")
+	kafkaServers := kafkabus.BootstrapServersFromEnv()
+	publisher, err := kafkabus.NewPublisher(kafkaServers)
 	if err != nil {
 		log.Fatalf("Failed to create Kafka producer: %v", err)
 	}
-	defer kafkaProducer.Close()
+	defer publisher.Close()
+
+	dbConfig, err := database.ConfigFromEnv()
+	if err != nil {
+		log.Fatalf("Invalid storage config: %v", err)
+	}
+	var events outbox.Store = outbox.NewMemoryStore()
+	var repo driver.DriverRepository = driver.NewMemoryRepository(events)
+	var processed driver.ProcessedEvents = driver.NewMemoryProcessedEvents(driver.DefaultProcessedEventsRetention)
+	if dbConfig.Backend == database.BackendPostgres {
+		pool, err := database.Connect(context.Background(), dbConfig.URL)
+		if err != nil {
+			log.Fatalf("Failed to connect to database: %v", err)
+		}
+		defer pool.Close()
+		events = outbox.NewPostgresStore(pool)
+		repo = driver.NewPostgresRepository(pool)
+		processed = driver.NewPostgresProcessedEvents(pool)
+	}
+	log.Printf("Using %s storage", dbConfig.Backend)
+
+	relay := outbox.NewRelay(events, publisher, outbox.DefaultRelayConfig)
+	go relay.Run(context.Background())
 
 	// Wiring: Repository -> Service -> Handler
-	repo := driver.NewMemoryRepository()
-	service := driver.NewService(repo, kafkaProducer)
+	offers := driver.NewOfferManager(offerWindow)
+	service := driver.NewService(repo, events, offers)
 	handler := driver.NewGrpcHandler(service)
 
 	s := grpc.NewServer()
 	pb.RegisterDriverServiceServer(s, handler)
 
-	kafkaConsumer, err := driver.NewKafkaConsumer("localhost:29092", "driver_service_group", service)
+	subscriber, err := kafkabus.NewSubscriber(kafkaServers, "driver_service_group", kafkabus.Earliest)
 	if err != nil {
 		log.Fatalf("Failed to create Kafka consumer: %v", err)
 	}
-	kafkaConsumer.SubscribeAndListen()
+	deadLetters := dlq.NewProcessor(publisher, "driver_service_group", dlq.DefaultRetryPolicy)
+	eventConsumer := driver.NewEventConsumer(subscriber, service, processed, deadLetters)
+	eventConsumer.SubscribeAndListen(context.Background())
 
 	log.Println("Driver gRPC server listening at :50051")
 	if err := s.Serve(lis); err != nil {
@@ -12,6 +12,7 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/cors"
+	"github.com/google/uuid"
 	"github.com/joho/godotenv"
 	pb_auth "github.com/lukabrx/uber-clone/api/proto/auth/v1"
 	pb_driver "github.com/lukabrx/uber-clone/api/proto/driver/v1"
@@ -19,6 +20,8 @@ import (
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 
+	"github.com/lukabrx/uber-clone/internal/dlq"
+	"github.com/lukabrx/uber-clone/internal/eventbus/kafkabus"
 	"github.com/lukabrx/uber-clone/internal/gateway"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -69,7 +72,7 @@ func main() {
 	driverClient := pb_driver.NewDriverServiceClient(driverConn)
 	authClient := pb_auth.NewAuthServiceClient(authConn)
 
-	hub := gateway.NewHub(driverClient)
+	hub := gateway.NewHub(driverClient, tripClient)
 	googleOauthConfig := &oauth2.Config{
 		ClientID:     googleClientID,
 		ClientSecret: googleClientSecret,
@@ -80,13 +83,25 @@ func main() {
 
 	httpHandler := gateway.NewHttpHandler(driverClient, tripClient, authClient, hub, googleOauthConfig)
 
-	kafkaConsumer, err := gateway.NewKafkaConsumer("localhost:29092", "gateway_group", hub)
+	kafkaServers := kafkabus.BootstrapServersFromEnv()
+	publisher, err := kafkabus.NewPublisher(kafkaServers)
+	if err != nil {
+		log.Fatalf("Failed to create dead-letter producer: %v", err)
+	}
+	defer publisher.Close()
+
+	// Each instance pushes every update to the sockets it holds, so its group
+	// is its own; it only needs events from now on.
+	group := "gateway_" + uuid.NewString()
+	subscriber, err := kafkabus.NewSubscriber(kafkaServers, group, kafkabus.Latest)
 	if err != nil {
 		log.Fatalf("Failed to create Kafka consumer for gateway: %v", err)
 	}
+	deadLetters := dlq.NewProcessor(publisher, group, dlq.DefaultRetryPolicy)
+	eventConsumer := gateway.NewEventConsumer(subscriber, hub, deadLetters)
 
 	ctx, cancel := context.WithCancel(context.Background())
-	kafkaConsumer.SubscribeAndListen(ctx)
+	eventConsumer.SubscribeAndListen(ctx)
 
 	r := chi.NewRouter()
 
@@ -102,16 +117,28 @@ func main() {
 	r.Get("/auth/google/login", httpHandler.HandleGoogleLogin)
 	r.Get("/auth/google/callback", httpHandler.HandleGoogleCallback)
 	r.Post("/auth/refresh", httpHandler.HandleRefreshToken)
-	r.Get("/ws/drivers/available", httpHandler.StreamAvailableDrivers)
+	// These authenticate themselves, accepting the token as a query parameter.
+	r.Get("/ws/drivers/{id}/offers", httpHandler.StreamTripOffers)
+	r.Get("/ws/trips/{id}", httpHandler.StreamTrip)
 
 	r.Group(func(r chi.Router) {
 		r.Use(httpHandler.AuthMiddleware)
 
 		r.Post("/drivers", httpHandler.RegisterDriver)
+		r.Post("/drivers/{id}/location", httpHandler.UpdateDriverLocation)
 		r.Get("/drivers/available", httpHandler.FindAvailableDrivers)
+		r.Get("/ws/drivers/available", httpHandler.StreamAvailableDrivers)
+		r.Get("/surge", httpHandler.GetSurge)
+		r.Post("/quotes", httpHandler.CreateFareQuote)
 		r.Post("/trips", httpHandler.CreateTrip)
 		r.Patch("/trips/{id}/complete", httpHandler.CompleteTrip)
+		r.Patch("/trips/{id}/status", httpHandler.UpdateTripStatus)
+		r.Post("/trips/{id}/cancel", httpHandler.CancelTrip)
+		r.Get("/trips/{id}", httpHandler.GetTrip)
 		r.Get("/me", httpHandler.HandleGetMe)
+		r.Get("/me/trips", httpHandler.ListMyTrips)
+		r.Post("/offers/{id}/accept", httpHandler.AcceptTripOffer)
+		r.Post("/offers/{id}/decline", httpHandler.DeclineTripOffer)
 	})
 
 	log.Println("Gateway server starting on :8080")
@@ -0,0 +1,86 @@
+// Command migrate applies the SQL migrations in /migrations to DATABASE_URL.
+//
+//	migrate up      apply every pending migration
+//	migrate down    revert the most recent migration
+//	migrate status  list migrations and when they were applied
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+	"github.com/lukabrx/uber-clone/internal/database"
+	"github.com/lukabrx/uber-clone/migrations"
+)
+
+func main() {
+	if err := godotenv.Load("../../.env"); err != nil {
+		log.Println("No .env file found or error loading .env file:", err)
+	}
+
+	command := "up"
+	if len(os.Args) > 1 {
+		command = os.Args[1]
+	}
+
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		log.Fatal("DATABASE_URL environment variable not set")
+	}
+
+	ctx := context.Background()
+	pool, err := database.Connect(ctx, databaseURL)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer pool.Close()
+
+	migrator, err := database.NewMigrator(pool, migrations.FS)
+	if err != nil {
+		log.Fatalf("Failed to load migrations: %v", err)
+	}
+
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			log.Printf("Applied %s_%s", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		if len(applied) == 0 {
+			log.Println("Database is up to date")
+		}
+
+	case "down":
+		reverted, ok, err := migrator.Down(ctx)
+		if err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		if !ok {
+			log.Println("No migrations to revert")
+			return
+		}
+		log.Printf("Reverted %s_%s", reverted.Version, reverted.Name)
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			log.Fatalf("Failed to read migration status: %v", err)
+		}
+		for _, s := range statuses {
+			applied := "pending"
+			if !s.AppliedAt.IsZero() {
+				applied = s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%s_%-30s %s\n", s.Version, s.Name, applied)
+		}
+
+	default:
+		log.Fatalf("Unknown command %q; use up, down or status", command)
+	}
+}
@@ -0,0 +1,72 @@
+// Command schemacheck compares the event schemas compiled into this build,
+// together with the files they import, against the snapshots committed next to
+// them and fails on breaking changes.
+//
+//	schemacheck          check every schema against its snapshot
+//	schemacheck -update  rewrite the snapshots after an intended change
+//
+// Run it from the repository root.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+
+	eventsv1 "github.com/lukabrx/uber-clone/api/proto/events/v1"
+	"github.com/lukabrx/uber-clone/internal/schema"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// schemas maps each checked file to its snapshot.
+var schemas = []struct {
+	file     protoreflect.FileDescriptor
+	snapshot string
+}{
+	{eventsv1.File_api_proto_events_v1_events_proto, "api/proto/events/v1/events.lock.json"},
+}
+
+func main() {
+	update := flag.Bool("update", false, "rewrite the snapshots from the current schemas")
+	flag.Parse()
+
+	failed := false
+	for _, s := range schemas {
+		current := schema.Snapshot(s.file)
+
+		if *update {
+			if err := schema.WriteFile(s.snapshot, current); err != nil {
+				log.Fatalf("Failed to write %s: %v", s.snapshot, err)
+			}
+			log.Printf("Updated %s", s.snapshot)
+			continue
+		}
+
+		old, err := schema.ReadFile(s.snapshot)
+		if errors.Is(err, os.ErrNotExist) {
+			log.Fatalf("%s is missing; run with -update to create it", s.snapshot)
+		}
+		if err != nil {
+			log.Fatalf("Failed to read %s: %v", s.snapshot, err)
+		}
+
+		problems := schema.Compare(old, current)
+		for _, p := range problems {
+			fmt.Printf("%s: %s\n", s.file.Path(), p)
+		}
+		if len(problems) > 0 {
+			failed = true
+			continue
+		}
+		log.Printf("%s is compatible with %s", s.file.Path(), s.snapshot)
+		if !reflect.DeepEqual(old, current) {
+			log.Printf("%s has additions; run with -update to record them", s.file.Path())
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
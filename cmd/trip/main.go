@@ -1,35 +1,135 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net"
+	"os"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
 	pb_driver "github.com/lukabrx/uber-clone/api/proto/driver/v1"
 	pb_trip "github.com/lukabrx/uber-clone/api/proto/trip/v1"
+	"github.com/lukabrx/uber-clone/internal/auth"
+	"github.com/lukabrx/uber-clone/internal/database"
+	"github.com/lukabrx/uber-clone/internal/dlq"
+	"github.com/lukabrx/uber-clone/internal/driver"
+	"github.com/lukabrx/uber-clone/internal/eventbus/kafkabus"
+	"github.com/lukabrx/uber-clone/internal/outbox"
+	pricecalculator "github.com/lukabrx/uber-clone/internal/price_calculator"
 	"github.com/lukabrx/uber-clone/internal/trip"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
 func main() {
+	if err := godotenv.Load("../../.env"); err != nil {
+		log.Println("No .env file found or error loading .env file:", err)
+	}
+
+	routerConfig, err := pricecalculator.RouterConfigFromEnv()
+	if err != nil {
+		log.Fatalf("Invalid router config: %v", err)
+	}
+	router, err := pricecalculator.NewRouter(routerConfig)
+	if err != nil {
+		log.Fatalf("Failed to create router: %v", err)
+	}
+	log.Printf("Routing trips with the %s provider", routerConfig.Provider)
+
+	rateCardsPath := os.Getenv("RATE_CARDS_PATH")
+	if rateCardsPath == "" {
+		rateCardsPath = "../../configs/rate_cards.json"
+	}
+	rateCards, err := pricecalculator.NewRateCardStore(rateCardsPath)
+	if err != nil {
+		log.Fatalf("Failed to load rate cards: %v", err)
+	}
+	go rateCards.Watch(context.Background(), 10*time.Second)
+
+	quoteKey := os.Getenv("QUOTE_SYMMETRIC_KEY")
+	if quoteKey == "" {
+		log.Fatal("QUOTE_SYMMETRIC_KEY environment variable not set")
+	}
+	quoteMaker, err := auth.NewPasetoMaker(quoteKey)
+	if err != nil {
+		log.Fatalf("failed to create quote paseto maker: %v", err)
+	}
+	quoteValidity := trip.DefaultQuoteValidity
+	if v := os.Getenv("QUOTE_VALIDITY"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			log.Fatalf("invalid QUOTE_VALIDITY %q: %v", v, err)
+		}
+		quoteValidity = d
+	}
+
+	surge := pricecalculator.NewSurgeEngine(pricecalculator.DefaultSurgeConfig)
+	go surge.Run(context.Background())
+	kafkaServers := kafkabus.BootstrapServersFromEnv()
+	surgeSubscriber, err := kafkabus.NewSubscriber(kafkaServers, "trip_surge_group", kafkabus.Latest)
+	if err != nil {
+		log.Fatalf("Failed to create surge Kafka consumer: %v", err)
+	}
 	conn, err := grpc.NewClient("localhost:50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
 		log.Fatalf("did not connect to driver service: %v", err)
 	}
 	defer conn.Close()
 
-	kafkaProducer, err := trip.NewKafkaProducer("localhost:29092")
+	publisher, err := kafkabus.NewPublisher(kafkaServers)
 	if err != nil {
 		log.Fatalf("Failed to create Kafka producer for trip service: %v", err)
 	}
-	defer kafkaProducer.Close()
+	defer publisher.Close()
 
 	driverClient := pb_driver.NewDriverServiceClient(conn)
 
-	repo := trip.NewMemoryRepository()
-	service := trip.NewService(repo, driverClient, kafkaProducer)
+	dbConfig, err := database.ConfigFromEnv()
+	if err != nil {
+		log.Fatalf("Invalid storage config: %v", err)
+	}
+	var events outbox.Store = outbox.NewMemoryStore()
+	var repo trip.TripRepository = trip.NewMemoryRepository(events)
+	if dbConfig.Backend == database.BackendPostgres {
+		pool, err := database.Connect(context.Background(), dbConfig.URL)
+		if err != nil {
+			log.Fatalf("Failed to connect to database: %v", err)
+		}
+		defer pool.Close()
+		events = outbox.NewPostgresStore(pool)
+		repo = trip.NewPostgresRepository(pool)
+	}
+	log.Printf("Using %s storage", dbConfig.Backend)
+
+	relay := outbox.NewRelay(events, publisher, outbox.DefaultRelayConfig)
+	go relay.Run(context.Background())
+
+	// Surge counts live in memory and start empty, so the record of events
+	// they have seen can too.
+	surgeProcessed := driver.NewMemoryProcessedEvents(driver.DefaultProcessedEventsRetention)
+	surgeDeadLetters := dlq.NewProcessor(publisher, "trip_surge_group", dlq.DefaultRetryPolicy)
+	pricecalculator.NewEventConsumer(surgeSubscriber, surge, surgeProcessed, surgeDeadLetters).SubscribeAndListen(context.Background())
+
+	dispatcher := trip.NewDispatcher(driverClient, trip.NewDriverServiceOffers(driverClient), trip.DefaultDispatchConfig)
+	service := trip.NewService(repo, driverClient, dispatcher, pricecalculator.NewCalculator(router, rateCards, surge), trip.NewQuotes(quoteMaker, quoteValidity))
 	handler := trip.NewGrpcHandler(service)
 
+	tripSubscriber, err := kafkabus.NewSubscriber(kafkaServers, "trip_service_group", kafkabus.Earliest)
+	if err != nil {
+		log.Fatalf("Failed to create trip Kafka consumer: %v", err)
+	}
+	trip.NewEventConsumer(tripSubscriber, service).SubscribeAndListen(context.Background())
+
+	// Each instance follows every event for its own watchers, so its group is
+	// its own; it only needs events from now on.
+	watchSubscriber, err := kafkabus.NewSubscriber(kafkaServers, "trip_watch_"+uuid.NewString(), kafkabus.Latest)
+	if err != nil {
+		log.Fatalf("Failed to create trip watch Kafka consumer: %v", err)
+	}
+	trip.NewWatchFeed(watchSubscriber, service).SubscribeAndListen(context.Background())
+
 	lis, err := net.Listen("tcp", ":50052")
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
@@ -37,7 +137,7 @@ func main() {
 	s := grpc.NewServer()
 	pb_trip.RegisterTripServiceServer(s, handler)
 
-	tripSimulator := trip.NewTripSimulator(service, kafkaProducer)
+	tripSimulator := trip.NewTripSimulator(service)
 	tripSimulator.Start()
 
 	log.Println("Trip gRPC server listening at :50052")
@@ -74,3 +74,17 @@ func (maker *PasetoMaker) VerifyToken(token string) (*Payload, error) {
 
 	return payload, nil
 }
+
+// Seal encrypts an arbitrary payload into a token. Callers are responsible for
+// putting an expiry in the payload and checking it after Open.
+func (maker *PasetoMaker) Seal(payload any) (string, error) {
+	return maker.paseto.Encrypt(maker.symmetricKey, payload, nil)
+}
+
+// Open decrypts a token created by Seal into payload.
+func (maker *PasetoMaker) Open(token string, payload any) error {
+	if err := maker.paseto.Decrypt(token, maker.symmetricKey, payload, nil); err != nil {
+		return ErrInvalidToken
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresRefreshTokenRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresRefreshTokenRepository(pool *pgxpool.Pool) *PostgresRefreshTokenRepository {
+	return &PostgresRefreshTokenRepository{pool: pool}
+}
+
+func (r *PostgresRefreshTokenRepository) Store(ctx context.Context, token RefreshToken) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO refresh_tokens (token, user_id, expires_at) VALUES ($1, $2, $3)
+		ON CONFLICT (token) DO UPDATE SET user_id = EXCLUDED.user_id, expires_at = EXCLUDED.expires_at`,
+		token.Token, token.UserID, token.ExpiresAt,
+	)
+	return err
+}
+
+func (r *PostgresRefreshTokenRepository) Get(ctx context.Context, token string) (RefreshToken, error) {
+	var t RefreshToken
+	err := r.pool.QueryRow(ctx,
+		`SELECT token, user_id, expires_at FROM refresh_tokens WHERE token = $1`, token,
+	).Scan(&t.Token, &t.UserID, &t.ExpiresAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return RefreshToken{}, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return RefreshToken{}, err
+	}
+	if time.Now().After(t.ExpiresAt) {
+		return RefreshToken{}, ErrInvalidRefreshToken
+	}
+	return t, nil
+}
+
+func (r *PostgresRefreshTokenRepository) Delete(ctx context.Context, token string) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM refresh_tokens WHERE token = $1`, token)
+	return err
+}
@@ -0,0 +1,22 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lukabrx/uber-clone/internal/auth"
+	"github.com/lukabrx/uber-clone/internal/repotest"
+)
+
+func TestPostgresRefreshTokenRepository(t *testing.T) {
+	repotest.RunRefreshTokenStore(t, func(t *testing.T) auth.RefreshTokenStore {
+		pool := repotest.Postgres(t)
+		// Refresh tokens reference their user.
+		_, err := pool.Exec(context.Background(),
+			`INSERT INTO users (id, email, name) VALUES ('user-1', 'user-1@example.com', 'User One')`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return auth.NewPostgresRefreshTokenRepository(pool)
+	})
+}
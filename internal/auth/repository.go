@@ -1,17 +1,29 @@
 package auth
 
 import (
+	"context"
 	"errors"
 	"sync"
 	"time"
 )
 
+var ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+
 type RefreshToken struct {
 	Token     string
 	UserID    string
 	ExpiresAt time.Time
 }
 
+// RefreshTokenStore keeps issued refresh tokens so they can be rotated and
+// revoked.
+type RefreshTokenStore interface {
+	Store(ctx context.Context, token RefreshToken) error
+	// Get returns ErrInvalidRefreshToken for unknown and expired tokens.
+	Get(ctx context.Context, token string) (RefreshToken, error)
+	Delete(ctx context.Context, token string) error
+}
+
 type RefreshTokenRepository struct {
 	tokens map[string]RefreshToken
 	mu     sync.RWMutex
@@ -23,25 +35,26 @@ func NewRefreshTokenRepository() *RefreshTokenRepository {
 	}
 }
 
-func (r *RefreshTokenRepository) Store(token RefreshToken) error {
+func (r *RefreshTokenRepository) Store(ctx context.Context, token RefreshToken) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	r.tokens[token.Token] = token
 	return nil
 }
 
-func (r *RefreshTokenRepository) Get(token string) (RefreshToken, error) {
+func (r *RefreshTokenRepository) Get(ctx context.Context, token string) (RefreshToken, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	t, ok := r.tokens[token]
 	if !ok || time.Now().After(t.ExpiresAt) {
-		return RefreshToken{}, errors.New("invalid or expired refresh token")
+		return RefreshToken{}, ErrInvalidRefreshToken
 	}
 	return t, nil
 }
 
-func (r *RefreshTokenRepository) Delete(token string) {
+func (r *RefreshTokenRepository) Delete(ctx context.Context, token string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	delete(r.tokens, token)
+	return nil
 }
@@ -0,0 +1,14 @@
+package auth_test
+
+import (
+	"testing"
+
+	"github.com/lukabrx/uber-clone/internal/auth"
+	"github.com/lukabrx/uber-clone/internal/repotest"
+)
+
+func TestRefreshTokenRepository(t *testing.T) {
+	repotest.RunRefreshTokenStore(t, func(t *testing.T) auth.RefreshTokenStore {
+		return auth.NewRefreshTokenRepository()
+	})
+}
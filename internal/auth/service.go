@@ -15,13 +15,13 @@ type Service struct {
 	pasetoMaker          *PasetoMaker
 	googleOauth          *oauth2.Config
 	googleUserInfoURL    string
-	userRepo             *user.MemoryRepository
-	refreshTokenRepo     *RefreshTokenRepository
+	userRepo             user.UserRepository
+	refreshTokenRepo     RefreshTokenStore
 	accessTokenDuration  time.Duration
 	refreshTokenDuration time.Duration
 }
 
-func NewService(pasetoMaker *PasetoMaker, googleOauth *oauth2.Config, userRepo *user.MemoryRepository, refreshTokenRepo *RefreshTokenRepository) *Service {
+func NewService(pasetoMaker *PasetoMaker, googleOauth *oauth2.Config, userRepo user.UserRepository, refreshTokenRepo RefreshTokenStore) *Service {
 	return &Service{
 		pasetoMaker:          pasetoMaker,
 		googleOauth:          googleOauth,
@@ -61,7 +61,7 @@ func (s *Service) AuthenticateWithGoogle(ctx context.Context, code string) (stri
 		return "", "", nil, errors.New("failed to unmarshal user info")
 	}
 
-	persistedUser, err := s.userRepo.CreateOrUpdateUser(user.User{Email: userInfo.Email, Name: userInfo.Name})
+	persistedUser, err := s.userRepo.CreateOrUpdateUser(ctx, user.User{Email: userInfo.Email, Name: userInfo.Name})
 	if err != nil {
 		return "", "", nil, errors.New("failed to save user")
 	}
@@ -76,7 +76,7 @@ func (s *Service) AuthenticateWithGoogle(ctx context.Context, code string) (stri
 		return "", "", nil, errors.New("failed to create refresh token")
 	}
 
-	err = s.refreshTokenRepo.Store(RefreshToken{
+	err = s.refreshTokenRepo.Store(ctx, RefreshToken{
 		Token:     refreshToken,
 		UserID:    persistedUser.ID,
 		ExpiresAt: time.Now().Add(s.refreshTokenDuration),
@@ -98,11 +98,13 @@ func (s *Service) RefreshToken(ctx context.Context, oldRefreshToken string) (str
 		return "", "", ErrInvalidToken
 	}
 
-	_, err = s.refreshTokenRepo.Get(oldRefreshToken)
+	_, err = s.refreshTokenRepo.Get(ctx, oldRefreshToken)
 	if err != nil {
 		return "", "", err
 	}
-	s.refreshTokenRepo.Delete(oldRefreshToken)
+	if err := s.refreshTokenRepo.Delete(ctx, oldRefreshToken); err != nil {
+		return "", "", errors.New("failed to revoke refresh token")
+	}
 
 	newAccessToken, err := s.pasetoMaker.CreateToken(payload.UserID, s.accessTokenDuration)
 	if err != nil {
@@ -114,7 +116,7 @@ func (s *Service) RefreshToken(ctx context.Context, oldRefreshToken string) (str
 		return "", "", errors.New("failed to create new refresh token")
 	}
 
-	err = s.refreshTokenRepo.Store(RefreshToken{
+	err = s.refreshTokenRepo.Store(ctx, RefreshToken{
 		Token:     newRefreshToken,
 		UserID:    payload.UserID,
 		ExpiresAt: time.Now().Add(s.refreshTokenDuration),
@@ -127,7 +129,7 @@ func (s *Service) RefreshToken(ctx context.Context, oldRefreshToken string) (str
 }
 
 func (s *Service) GetUser(ctx context.Context, userID string) (*user.User, error) {
-	foundUser, err := s.userRepo.GetUserByID(userID)
+	foundUser, err := s.userRepo.GetUserByID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
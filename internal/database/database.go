@@ -0,0 +1,75 @@
+// Package database holds the shared Postgres plumbing: choosing a storage
+// backend, connecting, and applying migrations.
+package database
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	BackendMemory   = "memory"
+	BackendPostgres = "postgres"
+)
+
+// Config selects where services keep their data.
+type Config struct {
+	// Backend is BackendMemory (the default) or BackendPostgres.
+	Backend string
+	// URL is the Postgres connection string, required for BackendPostgres.
+	URL string
+}
+
+// ConfigFromEnv reads STORAGE_BACKEND and DATABASE_URL.
+func ConfigFromEnv() (Config, error) {
+	cfg := Config{Backend: os.Getenv("STORAGE_BACKEND"), URL: os.Getenv("DATABASE_URL")}
+	if cfg.Backend == "" {
+		cfg.Backend = BackendMemory
+	}
+	switch cfg.Backend {
+	case BackendMemory:
+	case BackendPostgres:
+		if cfg.URL == "" {
+			return Config{}, fmt.Errorf("DATABASE_URL is required when STORAGE_BACKEND is %s", BackendPostgres)
+		}
+	default:
+		return Config{}, fmt.Errorf("unknown STORAGE_BACKEND %q", cfg.Backend)
+	}
+	return cfg, nil
+}
+
+// Connect opens a connection pool and checks that the database is reachable.
+func Connect(ctx context.Context, url string) (*pgxpool.Pool, error) {
+	pool, err := pgxpool.New(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	pingCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := pool.Ping(pingCtx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("connect to postgres: %w", err)
+	}
+	return pool, nil
+}
+
+// NullTime maps the zero time to NULL.
+func NullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// TimeOrZero maps NULL back to the zero time.
+func TimeOrZero(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
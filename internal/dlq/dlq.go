@@ -0,0 +1,143 @@
+// Package dlq retries messages whose handlers fail and parks the ones that keep
+// failing on a dead-letter topic, together with the error and where they came
+// from, so they can be inspected and replayed instead of being dropped.
+package dlq
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/lukabrx/uber-clone/internal/eventbus"
+	"github.com/lukabrx/uber-clone/internal/types"
+)
+
+// Headers added to dead-lettered messages, next to the original headers.
+const (
+	HeaderOriginalTopic     = "dlq-original-topic"
+	HeaderOriginalPartition = "dlq-original-partition"
+	HeaderOriginalOffset    = "dlq-original-offset"
+	HeaderConsumerGroup     = "dlq-consumer-group"
+	HeaderError             = "dlq-error"
+	HeaderAttempts          = "dlq-attempts"
+	HeaderFailedAt          = "dlq-failed-at"
+)
+
+type permanentError struct{ err error }
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent marks an error that retrying cannot fix, such as a payload that
+// does not decode. The message goes to the dead-letter topic straight away.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanentError{err}
+}
+
+// IsPermanent reports whether err, or an error it wraps, was marked Permanent.
+func IsPermanent(err error) bool {
+	var p permanentError
+	return errors.As(err, &p)
+}
+
+// RetryPolicy says how often a failing message is retried before it is
+// dead-lettered.
+type RetryPolicy struct {
+	// MaxAttempts counts the first try.
+	MaxAttempts int
+	// Attempts are spaced by InitialBackoff, doubling up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    5,
+	InitialBackoff: 200 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+}
+
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.InitialBackoff
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if d >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	return d
+}
+
+// Processor runs an eventbus.Handler under a RetryPolicy and dead-letters
+// messages it gives up on.
+type Processor struct {
+	publisher eventbus.Publisher
+	group     string
+	policy    RetryPolicy
+}
+
+// NewProcessor creates a processor for the consumer group group, which is
+// recorded on dead-lettered messages.
+func NewProcessor(publisher eventbus.Publisher, group string, policy RetryPolicy) *Processor {
+	return &Processor{publisher: publisher, group: group, policy: policy}
+}
+
+// Handler wraps handle so that it is retried and, failing that, dead-lettered.
+func (p *Processor) Handler(handle eventbus.Handler) eventbus.Handler {
+	return func(ctx context.Context, msg eventbus.Message) error {
+		return p.Process(ctx, msg, handle)
+	}
+}
+
+// Process handles msg, retrying failures with backoff. Once the message has
+// been handled or dead-lettered it returns nil and the offset can be
+// committed. It returns an error only if the message could not be
+// dead-lettered either, in which case it must be read again.
+func (p *Processor) Process(ctx context.Context, msg eventbus.Message, handle eventbus.Handler) error {
+	var err error
+	attempt := 1
+	for ; ; attempt++ {
+		err = handle(ctx, msg)
+		if err == nil {
+			return nil
+		}
+		if IsPermanent(err) || attempt >= p.policy.MaxAttempts {
+			break
+		}
+
+		delay := p.policy.backoff(attempt)
+		log.Printf("Attempt %d for %s message at %d/%d failed, retrying in %s: %v", attempt, msg.Topic, msg.Partition, msg.Offset, delay, err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+
+	log.Printf("Dead-lettering %s message at %d/%d after %d attempt(s): %v", msg.Topic, msg.Partition, msg.Offset, attempt, err)
+	return p.deadLetter(ctx, msg, err, attempt)
+}
+
+func (p *Processor) deadLetter(ctx context.Context, msg eventbus.Message, cause error, attempts int) error {
+	headers := append([]eventbus.Header(nil), msg.Headers...)
+	headers = append(headers,
+		eventbus.Header{Key: HeaderOriginalTopic, Value: []byte(msg.Topic)},
+		eventbus.Header{Key: HeaderOriginalPartition, Value: []byte(strconv.Itoa(int(msg.Partition)))},
+		eventbus.Header{Key: HeaderOriginalOffset, Value: []byte(strconv.FormatInt(msg.Offset, 10))},
+		eventbus.Header{Key: HeaderConsumerGroup, Value: []byte(p.group)},
+		eventbus.Header{Key: HeaderError, Value: []byte(cause.Error())},
+		eventbus.Header{Key: HeaderAttempts, Value: []byte(strconv.Itoa(attempts))},
+		eventbus.Header{Key: HeaderFailedAt, Value: []byte(time.Now().UTC().Format(time.RFC3339))},
+	)
+
+	return p.publisher.Publish(ctx, eventbus.Message{
+		Topic:   types.DeadLetterTopic,
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: headers,
+	})
+}
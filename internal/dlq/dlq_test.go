@@ -0,0 +1,174 @@
+package dlq
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lukabrx/uber-clone/internal/eventbus"
+	"github.com/lukabrx/uber-clone/internal/types"
+)
+
+// recorder is a publisher that keeps what it publishes, or fails with err.
+type recorder struct {
+	err error
+
+	mu       sync.Mutex
+	messages []eventbus.Message
+}
+
+func (r *recorder) Publish(_ context.Context, msg eventbus.Message) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.messages = append(r.messages, msg)
+	return nil
+}
+
+// failing is a handler that fails the first n calls with err and records when
+// it was called.
+type failing struct {
+	n     int
+	err   error
+	calls []time.Time
+}
+
+func (f *failing) handle(context.Context, eventbus.Message) error {
+	f.calls = append(f.calls, time.Now())
+	if len(f.calls) <= f.n {
+		return f.err
+	}
+	return nil
+}
+
+var testPolicy = RetryPolicy{MaxAttempts: 4, InitialBackoff: 20 * time.Millisecond, MaxBackoff: 50 * time.Millisecond}
+
+var testMessage = eventbus.Message{
+	Topic:     types.TripEventsTopic,
+	Partition: 2,
+	Offset:    41,
+	Key:       "trip-1",
+	Value:     []byte("payload"),
+	Headers:   []eventbus.Header{{Key: "traceparent", Value: []byte("00-abc-def-01")}},
+}
+
+func TestProcessRetriesWithBackoff(t *testing.T) {
+	box := &recorder{}
+	handler := &failing{n: 3, err: errors.New("database unavailable")}
+
+	if err := NewProcessor(box, "driver_service_group", testPolicy).Process(context.Background(), testMessage, handler.handle); err != nil {
+		t.Fatal(err)
+	}
+	if len(handler.calls) != 4 {
+		t.Fatalf("handler called %d times, want 4", len(handler.calls))
+	}
+	// Backoff doubles from 20ms and is capped at 50ms.
+	for i, want := range []time.Duration{20 * time.Millisecond, 40 * time.Millisecond, 50 * time.Millisecond} {
+		if wait := handler.calls[i+1].Sub(handler.calls[i]); wait < want || wait > want+time.Second {
+			t.Errorf("retry %d after %s, want %s", i+1, wait, want)
+		}
+	}
+	if len(box.messages) != 0 {
+		t.Errorf("dead-lettered %d messages, want none once the handler succeeded", len(box.messages))
+	}
+}
+
+func TestProcessDeadLetters(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		wantAttempts int
+	}{
+		{"after the last attempt", errors.New("database unavailable"), 4},
+		{"permanent errors at once", Permanent(errors.New("could not decode trip event")), 1},
+		{"wrapped permanent errors at once", errors.Join(errors.New("handling"), Permanent(errors.New("bad payload"))), 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			box := &recorder{}
+			handler := &failing{n: 100, err: tt.err}
+
+			err := NewProcessor(box, "driver_service_group", testPolicy).Process(context.Background(), testMessage, handler.handle)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := len(handler.calls); got != tt.wantAttempts {
+				t.Errorf("handler called %d times, want %d", got, tt.wantAttempts)
+			}
+			if len(box.messages) != 1 {
+				t.Fatalf("dead-lettered %d messages, want 1", len(box.messages))
+			}
+
+			got := box.messages[0]
+			if got.Topic != types.DeadLetterTopic || got.Key != testMessage.Key || string(got.Value) != string(testMessage.Value) {
+				t.Errorf("dead-lettered %s %q %q, want the original key and payload on %s", got.Topic, got.Key, got.Value, types.DeadLetterTopic)
+			}
+			wantHeaders := map[string]string{
+				"traceparent":           "00-abc-def-01",
+				HeaderOriginalTopic:     types.TripEventsTopic,
+				HeaderOriginalPartition: "2",
+				HeaderOriginalOffset:    "41",
+				HeaderConsumerGroup:     "driver_service_group",
+				HeaderError:             tt.err.Error(),
+				HeaderAttempts:          strconv.Itoa(tt.wantAttempts),
+			}
+			for key, want := range wantHeaders {
+				if value := got.Header(key); value != want {
+					t.Errorf("header %s = %q, want %q", key, value, want)
+				}
+			}
+			if _, err := time.Parse(time.RFC3339, got.Header(HeaderFailedAt)); err != nil {
+				t.Errorf("header %s: %v", HeaderFailedAt, err)
+			}
+			// The original headers come first and are not modified.
+			if got.Headers[0].Key != "traceparent" || len(testMessage.Headers) != 1 {
+				t.Errorf("headers = %v, want the original ones first and untouched", got.Headers)
+			}
+		})
+	}
+}
+
+func TestProcessStopsWhenCancelled(t *testing.T) {
+	box := &recorder{}
+	handler := &failing{n: 100, err: errors.New("database unavailable")}
+	ctx, cancel := context.WithCancel(context.Background())
+	policy := RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Minute, MaxBackoff: time.Minute}
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	err := NewProcessor(box, "driver_service_group", policy).Process(ctx, testMessage, handler.handle)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want %v so the message is read again", err, context.Canceled)
+	}
+	if len(handler.calls) != 1 || len(box.messages) != 0 {
+		t.Errorf("handler called %d times and %d dead-lettered, want 1 call and none", len(handler.calls), len(box.messages))
+	}
+}
+
+func TestProcessFailsWhenDeadLetteringFails(t *testing.T) {
+	box := &recorder{err: errors.New("broker unavailable")}
+	handler := &failing{n: 100, err: Permanent(errors.New("bad payload"))}
+
+	err := NewProcessor(box, "driver_service_group", testPolicy).Process(context.Background(), testMessage, handler.handle)
+	if err == nil {
+		t.Fatal("Process succeeded although the message could not be dead-lettered")
+	}
+}
+
+func TestPermanent(t *testing.T) {
+	if Permanent(nil) != nil {
+		t.Error("Permanent(nil) != nil")
+	}
+	cause := errors.New("bad payload")
+	err := Permanent(cause)
+	if !IsPermanent(err) || !errors.Is(err, cause) || err.Error() != cause.Error() {
+		t.Errorf("Permanent(%v) = %v, want a permanent error wrapping it", cause, err)
+	}
+	if IsPermanent(cause) {
+		t.Error("IsPermanent of a plain error")
+	}
+}
@@ -0,0 +1,36 @@
+package driver
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrDriverNotFound    = errors.New("driver not found")
+	ErrDriverUnavailable = errors.New("driver is not available")
+	ErrDriverReserved    = errors.New("driver is reserved for another trip")
+	ErrInvalidLocation   = errors.New("invalid location ping")
+	ErrInvalidVehicle    = errors.New("vehicle_type must be economy, comfort or xl")
+	ErrStaleLocation     = errors.New("location ping is older than the driver's last known location")
+	ErrUserHasDriver     = errors.New("user has already registered a driver")
+)
+
+// grpcError converts service errors into gRPC status errors.
+func grpcError(err error) error {
+	switch {
+	case errors.Is(err, ErrDriverNotFound), errors.Is(err, ErrOfferNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrInvalidLocation), errors.Is(err, ErrInvalidVehicle):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, ErrOfferNotForDriver):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, ErrDriverUnavailable), errors.Is(err, ErrDriverReserved), errors.Is(err, ErrStaleLocation):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, ErrUserHasDriver):
+		return status.Error(codes.AlreadyExists, err.Error())
+	default:
+		return err
+	}
+}
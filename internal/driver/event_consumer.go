@@ -0,0 +1,95 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/lukabrx/uber-clone/internal/dlq"
+	"github.com/lukabrx/uber-clone/internal/eventbus"
+	"github.com/lukabrx/uber-clone/internal/events"
+	"github.com/lukabrx/uber-clone/internal/types"
+)
+
+// EventConsumer applies trip events to driver availability. Trip events are
+// keyed by trip ID, so each trip's events arrive in order and are handled one
+// at a time. A message is acknowledged only once it has been handled, so a
+// crash replays it rather than losing it; processed tracks events already
+// applied so replays are skipped. Events that keep failing are retried by
+// deadLetters and then moved to the dead-letter topic.
+type EventConsumer struct {
+	subscriber  eventbus.Subscriber
+	service     *Service
+	processed   ProcessedEvents
+	deadLetters *dlq.Processor
+}
+
+func NewEventConsumer(subscriber eventbus.Subscriber, service *Service, processed ProcessedEvents, deadLetters *dlq.Processor) *EventConsumer {
+	return &EventConsumer{subscriber: subscriber, service: service, processed: processed, deadLetters: deadLetters}
+}
+
+func (c *EventConsumer) SubscribeAndListen(ctx context.Context) {
+	log.Println("Subscribed to topic and listening for trip events...")
+	// Gorutine allow asynchronous processing of messages without blocking the main program.
+	go func() {
+		err := c.subscriber.Subscribe(ctx, []string{types.TripEventsTopic}, c.deadLetters.Handler(c.handle))
+		if err != nil {
+			log.Fatalf("Failed to subscribe to topic: %v", err)
+		}
+		log.Println("Stopping driver event consumer.")
+	}()
+}
+
+// handle applies one trip event. Events already processed or out of date are
+// logged and skipped; malformed events and events the driver's state rules out
+// fail permanently.
+func (c *EventConsumer) handle(ctx context.Context, msg eventbus.Message) error {
+	event, err := events.DecodeTripEvent(msg.Value)
+	if err != nil {
+		return dlq.Permanent(fmt.Errorf("could not decode trip event: %w", err))
+	}
+
+	err = c.processed.Check(ctx, event)
+	if errors.Is(err, ErrDuplicateEvent) || errors.Is(err, ErrStaleEvent) {
+		log.Printf("Skipping %s event %s (version %d) for trip %s: %v", event.EventType, event.EventID, event.Version, event.TripID, err)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := c.apply(ctx, event); err != nil {
+		return err
+	}
+	return c.processed.Mark(ctx, event)
+}
+
+func (c *EventConsumer) apply(ctx context.Context, event types.TripEvent) error {
+	var err error
+	switch event.EventType {
+	case types.TripDriverAssignedEvent:
+		log.Printf("Processing driver assignment for driver %s", event.DriverID)
+		// Normally the dispatcher has reserved the driver already; this is a no-op then.
+		_, err = c.service.ReserveDriver(ctx, event.DriverID, event.TripID)
+
+	case types.TripCompletedEvent, types.TripCancelledEvent, types.TripNoShowEvent:
+		if event.DriverID == "" {
+			return nil
+		}
+		log.Printf("Processing %s for driver %s", event.EventType, event.DriverID)
+		err = c.service.ReleaseDriver(ctx, event.DriverID, event.TripID)
+
+	case types.TripCreatedEvent, types.TripDriverArrivingEvent, types.TripStartedEvent:
+		// These do not change driver availability.
+
+	default:
+		log.Printf("Unknown event type received: %s", event.EventType)
+	}
+
+	// The driver's state rules the event out; retrying would not change that.
+	if errors.Is(err, ErrDriverNotFound) || errors.Is(err, ErrDriverReserved) || errors.Is(err, ErrDriverUnavailable) {
+		return dlq.Permanent(fmt.Errorf("%s for driver %s: %w", event.EventType, event.DriverID, err))
+	}
+	return err
+}
@@ -0,0 +1,29 @@
+package driver
+
+import (
+	"context"
+
+	"github.com/lukabrx/uber-clone/internal/events"
+	"github.com/lukabrx/uber-clone/internal/models"
+	"github.com/lukabrx/uber-clone/internal/outbox"
+	"github.com/lukabrx/uber-clone/internal/types"
+)
+
+// driverMessage is the outbox entry stored whenever a driver registers,
+// changes availability or reports a new location.
+func driverMessage(ctx context.Context, driver models.Driver) (outbox.Message, error) {
+	value, err := events.Marshal(events.NewDriverUpdated(ctx, driver))
+	if err != nil {
+		return outbox.Message{}, err
+	}
+	return outbox.Message{Topic: types.DriverLocationTopic, Key: driver.ID, Value: value}, nil
+}
+
+// tripOfferMessage is the outbox entry for an offer being made or closing.
+func tripOfferMessage(ctx context.Context, event types.TripOfferEvent) (outbox.Message, error) {
+	value, err := events.Marshal(events.NewTripOffer(ctx, event))
+	if err != nil {
+		return outbox.Message{}, err
+	}
+	return outbox.Message{Topic: types.DriverOffersTopic, Key: event.Offer.DriverID, Value: value}, nil
+}
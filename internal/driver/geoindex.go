@@ -0,0 +1,269 @@
+package driver
+
+import (
+	"math"
+	"slices"
+
+	"github.com/lukabrx/uber-clone/internal/geo"
+)
+
+// DefaultCellSizeDeg is roughly 1.1 km of latitude.
+const DefaultCellSizeDeg = 0.01
+
+type point struct {
+	lat, lon float64
+}
+
+// Neighbor is an indexed ID together with its distance from a query point.
+type Neighbor struct {
+	ID         string
+	DistanceKm float64
+}
+
+// GeoIndex buckets points into a fixed lat/lon grid so that nearby queries only
+// look at the cells around the query point instead of every point. It is not
+// safe for concurrent use; the repository guards it with its own lock.
+type GeoIndex struct {
+	cellSize float64
+	// cols is the number of cells around a parallel. Columns wrap at the
+	// antimeridian, so cells on either side of it are neighbours. The cell
+	// size is expected to divide 360.
+	cols   int
+	cells  map[geo.Cell]map[string]point
+	points map[string]point
+}
+
+func NewGeoIndex(cellSizeDeg float64) *GeoIndex {
+	return &GeoIndex{
+		cellSize: cellSizeDeg,
+		cols:     int(math.Round(360 / cellSizeDeg)),
+		cells:    make(map[geo.Cell]map[string]point),
+		points:   make(map[string]point),
+	}
+}
+
+func (g *GeoIndex) Len() int {
+	return len(g.points)
+}
+
+// Upsert adds id at the given position, moving it if it is already indexed.
+func (g *GeoIndex) Upsert(id string, lat, lon float64) {
+	g.Remove(id)
+
+	p := point{lat: lat, lon: lon}
+	cell := g.cellOf(lat, lon)
+	if g.cells[cell] == nil {
+		g.cells[cell] = make(map[string]point)
+	}
+	g.cells[cell][id] = p
+	g.points[id] = p
+}
+
+func (g *GeoIndex) Remove(id string) {
+	p, ok := g.points[id]
+	if !ok {
+		return
+	}
+	cell := g.cellOf(p.lat, p.lon)
+	delete(g.cells[cell], id)
+	if len(g.cells[cell]) == 0 {
+		delete(g.cells, cell)
+	}
+	delete(g.points, id)
+}
+
+// Nearby returns indexed IDs closest first. A positive radiusKm drops anything
+// further away and a positive limit caps the number of results; with neither
+// set every indexed ID is returned. keep, when set, filters IDs before the limit
+// is applied.
+func (g *GeoIndex) Nearby(lat, lon, radiusKm float64, limit int, keep func(id string) bool) []Neighbor {
+	q := query{lat: lat, lon: lon, radiusKm: radiusKm, keep: keep}
+	switch {
+	case limit > 0:
+		return g.nearest(q, limit)
+	case radiusKm > 0:
+		return g.within(q)
+	default:
+		return g.filtered(q, 0)
+	}
+}
+
+type query struct {
+	lat, lon float64
+	radiusKm float64
+	keep     func(id string) bool
+}
+
+func (q query) distance(id string, p point) (float64, bool) {
+	if q.keep != nil && !q.keep(id) {
+		return 0, false
+	}
+	d := geo.DistanceKm(q.lat, q.lon, p.lat, p.lon)
+	if q.radiusKm > 0 && d > q.radiusKm {
+		return 0, false
+	}
+	return d, true
+}
+
+// within scans the block of cells covering the radius' bounding box.
+func (g *GeoIndex) within(q query) []Neighbor {
+	angle := q.radiusKm / geo.EarthRadiusKm
+	dLat := angle * 180 / math.Pi
+	// The circle is widest east-west at the latitude where the meridians
+	// touching it meet it. If it reaches a pole it spans every longitude.
+	cosLat := math.Cos(q.lat * math.Pi / 180)
+	if math.Abs(q.lat)+dLat >= 90 || math.Sin(angle) >= cosLat {
+		return g.filtered(q, 0)
+	}
+	dLon := math.Asin(math.Sin(angle)/cosLat) * 180 / math.Pi
+	minCell := geo.CellOf(q.lat-dLat, q.lon-dLon, g.cellSize)
+	maxCell := geo.CellOf(q.lat+dLat, q.lon+dLon, g.cellSize)
+
+	// For huge radii it is cheaper to check every point than every cell.
+	cols := maxCell.Col - minCell.Col + 1
+	if cols >= g.cols || (maxCell.Row-minCell.Row+1)*cols > len(g.cells) {
+		return g.filtered(q, 0)
+	}
+
+	var found []Neighbor
+	for row := minCell.Row; row <= maxCell.Row; row++ {
+		for col := minCell.Col; col <= maxCell.Col; col++ {
+			found = g.collect(found, g.wrap(geo.Cell{Row: row, Col: col}), q)
+		}
+	}
+	sortByDistance(found)
+	return found
+}
+
+// nearest searches rings of cells outwards from the query cell. Once it has
+// limit results and the next ring cannot hold anything closer, it stops.
+func (g *GeoIndex) nearest(q query, limit int) []Neighbor {
+	center := geo.CellOf(q.lat, q.lon, g.cellSize)
+
+	var found []Neighbor
+	seen := 0
+	for ring := 0; seen < len(g.points); ring++ {
+		// Everything in this ring or beyond is at least this far away.
+		minDist := g.distanceOutside(q, center, ring)
+		if q.radiusKm > 0 && minDist > q.radiusKm {
+			break
+		}
+		if len(found) >= limit {
+			sortByDistance(found)
+			if found[limit-1].DistanceKm <= minDist {
+				break
+			}
+		}
+		// When points are sparse the rings outgrow the occupied cells; finish
+		// with a plain scan instead of walking empty cells. The same goes for
+		// rings that would wrap all the way around the globe.
+		if 8*ring > len(g.cells) || 2*ring+1 >= g.cols {
+			return g.filtered(q, limit)
+		}
+
+		for _, cell := range ringCells(center, ring) {
+			cell = g.wrap(cell)
+			seen += len(g.cells[cell])
+			found = g.collect(found, cell, q)
+		}
+	}
+
+	sortByDistance(found)
+	if len(found) > limit {
+		found = found[:limit]
+	}
+	return found
+}
+
+// distanceOutside is a lower bound on the distance from the query point to
+// anything outside the first rings around center. Such points lie beyond one of
+// the parallels or meridians bounding those rings, and nothing is closer than
+// the nearest of those lines.
+func (g *GeoIndex) distanceOutside(q query, center geo.Cell, rings int) float64 {
+	if rings == 0 {
+		return 0
+	}
+	const rad = math.Pi / 180
+	south := float64(center.Row-rings+1)*g.cellSize - 90
+	north := float64(center.Row+rings)*g.cellSize - 90
+	west := float64(center.Col-rings+1)*g.cellSize - 180
+	east := float64(center.Col+rings)*g.cellSize - 180
+
+	// Along a meridian to the nearest parallel.
+	d := math.Min(q.lat-south, north-q.lat) * rad
+	// Across to the great circle through each meridian.
+	cosLat := math.Cos(q.lat * rad)
+	for _, lon := range []float64{west, east} {
+		d = math.Min(d, math.Asin(math.Abs(math.Sin((q.lon-lon)*rad))*cosLat))
+	}
+	return d * geo.EarthRadiusKm
+}
+
+// cellOf returns the cell a point is indexed under.
+func (g *GeoIndex) cellOf(lat, lon float64) geo.Cell {
+	return g.wrap(geo.CellOf(lat, lon, g.cellSize))
+}
+
+// wrap moves a column past either side of the antimeridian back onto the grid.
+func (g *GeoIndex) wrap(cell geo.Cell) geo.Cell {
+	cell.Col = (cell.Col%g.cols + g.cols) % g.cols
+	return cell
+}
+
+// filtered checks every point, for when the grid cannot narrow the search.
+func (g *GeoIndex) filtered(q query, limit int) []Neighbor {
+	found := make([]Neighbor, 0, len(g.points))
+	for id, p := range g.points {
+		if d, ok := q.distance(id, p); ok {
+			found = append(found, Neighbor{ID: id, DistanceKm: d})
+		}
+	}
+	sortByDistance(found)
+	if limit > 0 && len(found) > limit {
+		found = found[:limit]
+	}
+	return found
+}
+
+func (g *GeoIndex) collect(found []Neighbor, cell geo.Cell, q query) []Neighbor {
+	for id, p := range g.cells[cell] {
+		if d, ok := q.distance(id, p); ok {
+			found = append(found, Neighbor{ID: id, DistanceKm: d})
+		}
+	}
+	return found
+}
+
+// ringCells returns the cells at exactly ring steps from center.
+func ringCells(center geo.Cell, ring int) []geo.Cell {
+	if ring == 0 {
+		return []geo.Cell{center}
+	}
+	cells := make([]geo.Cell, 0, 8*ring)
+	for d := -ring; d <= ring; d++ {
+		cells = append(cells,
+			geo.Cell{Row: center.Row - ring, Col: center.Col + d},
+			geo.Cell{Row: center.Row + ring, Col: center.Col + d},
+		)
+	}
+	for d := -ring + 1; d <= ring-1; d++ {
+		cells = append(cells,
+			geo.Cell{Row: center.Row + d, Col: center.Col - ring},
+			geo.Cell{Row: center.Row + d, Col: center.Col + ring},
+		)
+	}
+	return cells
+}
+
+func sortByDistance(found []Neighbor) {
+	slices.SortFunc(found, func(a, b Neighbor) int {
+		switch {
+		case a.DistanceKm < b.DistanceKm:
+			return -1
+		case a.DistanceKm > b.DistanceKm:
+			return 1
+		default:
+			return 0
+		}
+	})
+}
@@ -0,0 +1,211 @@
+package driver
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/lukabrx/uber-clone/internal/geo"
+	"github.com/lukabrx/uber-clone/internal/models"
+)
+
+// Points are scattered over a box of about 55 by 40 km around Belgrade.
+const (
+	cityLat, cityLon = 44.8, 20.45
+	citySpanDeg      = 0.5
+)
+
+func randomPoints(rng *rand.Rand, n int) map[string]point {
+	return pointsAround(rng, n, cityLat, cityLon, citySpanDeg)
+}
+
+// pointsAround scatters n points over a box of spanDeg degrees centred on
+// lat, lon, wrapping longitudes that cross the antimeridian.
+func pointsAround(rng *rand.Rand, n int, lat, lon, spanDeg float64) map[string]point {
+	points := make(map[string]point, n)
+	for i := range n {
+		points[fmt.Sprintf("driver-%d", i)] = point{
+			lat: lat + (rng.Float64()-0.5)*spanDeg,
+			lon: wrapLon(lon + (rng.Float64()-0.5)*spanDeg),
+		}
+	}
+	return points
+}
+
+func wrapLon(lon float64) float64 {
+	return math.Mod(lon+540, 360) - 180
+}
+
+// bruteForce is what Nearby must return: every point checked and sorted.
+func bruteForce(points map[string]point, lat, lon, radiusKm float64, limit int, keep func(string) bool) []Neighbor {
+	var found []Neighbor
+	for id, p := range points {
+		if keep != nil && !keep(id) {
+			continue
+		}
+		d := geo.DistanceKm(lat, lon, p.lat, p.lon)
+		if radiusKm > 0 && d > radiusKm {
+			continue
+		}
+		found = append(found, Neighbor{ID: id, DistanceKm: d})
+	}
+	sortByDistance(found)
+	if limit > 0 && len(found) > limit {
+		found = found[:limit]
+	}
+	return found
+}
+
+func TestGeoIndexMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	points := randomPoints(rng, 2000)
+	index := NewGeoIndex(DefaultCellSizeDeg)
+	for id, p := range points {
+		index.Upsert(id, p.lat, p.lon)
+	}
+	// Move some points and remove others, so the index has to keep up.
+	for i := range 200 {
+		id := fmt.Sprintf("driver-%d", i)
+		if i%2 == 0 {
+			p := point{lat: points[id].lat + 0.05, lon: points[id].lon - 0.05}
+			points[id] = p
+			index.Upsert(id, p.lat, p.lon)
+		} else {
+			delete(points, id)
+			index.Remove(id)
+		}
+	}
+	if index.Len() != len(points) {
+		t.Fatalf("Len = %d, want %d", index.Len(), len(points))
+	}
+
+	evenOnly := func(id string) bool { return id[len(id)-1]%2 == 0 }
+	tests := []struct {
+		name     string
+		radiusKm float64
+		limit    int
+		keep     func(string) bool
+	}{
+		{name: "radius", radiusKm: 3},
+		{name: "nearest", limit: 20},
+		{name: "radius and nearest", radiusKm: 2, limit: 20},
+		{name: "radius larger than the city", radiusKm: 100},
+		{name: "more nearest than points", limit: 5000},
+		{name: "filtered nearest", limit: 20, keep: evenOnly},
+		{name: "everything"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for range 20 {
+				lat := cityLat + (rng.Float64()-0.5)*citySpanDeg*1.2
+				lon := cityLon + (rng.Float64()-0.5)*citySpanDeg*1.2
+				got := index.Nearby(lat, lon, tt.radiusKm, tt.limit, tt.keep)
+				want := bruteForce(points, lat, lon, tt.radiusKm, tt.limit, tt.keep)
+				if !slices.Equal(got, want) {
+					t.Fatalf("Nearby(%f, %f) returned %d drivers, want %d:\ngot  %v\nwant %v", lat, lon, len(got), len(want), got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGeoIndexEdgesOfTheGrid(t *testing.T) {
+	tests := []struct {
+		name     string
+		lat, lon float64
+		spanDeg  float64
+		radiusKm float64
+		limit    int
+	}{
+		// Longitude cells are a fraction of their height up here, so rings
+		// reach far fewer kilometres east and west than north and south.
+		{name: "high latitude nearest", lat: 78, lon: 15, spanDeg: 2, limit: 20},
+		{name: "high latitude radius", lat: 78, lon: 15, spanDeg: 2, radiusKm: 15},
+		{name: "near the pole", lat: 89.7, lon: 0, spanDeg: 0.5, limit: 20},
+		{name: "near the pole radius", lat: 89.7, lon: 0, spanDeg: 0.5, radiusKm: 30},
+		{name: "across the antimeridian nearest", lat: -16.5, lon: 180, spanDeg: 0.5, limit: 20},
+		{name: "across the antimeridian radius", lat: -16.5, lon: 180, spanDeg: 0.5, radiusKm: 5},
+		{name: "antimeridian at high latitude", lat: 65, lon: 180, spanDeg: 1, limit: 20},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rng := rand.New(rand.NewPCG(3, 4))
+			points := pointsAround(rng, 2000, tt.lat, tt.lon, tt.spanDeg)
+			index := NewGeoIndex(DefaultCellSizeDeg)
+			for id, p := range points {
+				index.Upsert(id, p.lat, p.lon)
+			}
+			for range 20 {
+				lat := math.Min(tt.lat+(rng.Float64()-0.5)*tt.spanDeg, 90)
+				lon := wrapLon(tt.lon + (rng.Float64()-0.5)*tt.spanDeg)
+				got := index.Nearby(lat, lon, tt.radiusKm, tt.limit, nil)
+				want := bruteForce(points, lat, lon, tt.radiusKm, tt.limit, nil)
+				if !slices.Equal(got, want) {
+					t.Fatalf("Nearby(%f, %f) returned %d drivers, want %d:\ngot  %v\nwant %v", lat, lon, len(got), len(want), got, want)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkFindNearby compares the grid index with sorting every driver by
+// distance, which is how nearby drivers were found before the index.
+func BenchmarkFindNearby(b *testing.B) {
+	const radiusKm, limit = 10, 20
+	for _, n := range []int{1_000, 10_000, 100_000} {
+		rng := rand.New(rand.NewPCG(1, 2))
+		points := randomPoints(rng, n)
+
+		index := NewGeoIndex(DefaultCellSizeDeg)
+		drivers := make([]models.Driver, 0, n)
+		for id, p := range points {
+			index.Upsert(id, p.lat, p.lon)
+			drivers = append(drivers, models.Driver{ID: id, Lat: p.lat, Lon: p.lon})
+		}
+
+		b.Run(fmt.Sprintf("grid/%d", n), func(b *testing.B) {
+			for b.Loop() {
+				index.Nearby(cityLat, cityLon, radiusKm, limit, nil)
+			}
+		})
+
+		b.Run(fmt.Sprintf("sort/%d", n), func(b *testing.B) {
+			for b.Loop() {
+				sortAll(drivers, cityLat, cityLon, radiusKm, limit)
+			}
+		})
+	}
+}
+
+// sortAll copies every driver and sorts them, working distances out in the
+// comparator.
+func sortAll(all []models.Driver, lat, lon, radiusKm float64, limit int) []Neighbor {
+	drivers := slices.Clone(all)
+	distance := func(d models.Driver) float64 {
+		return geo.DistanceKm(lat, lon, d.Lat, d.Lon)
+	}
+	slices.SortFunc(drivers, func(a, b models.Driver) int {
+		da, db := distance(a), distance(b)
+		switch {
+		case da < db:
+			return -1
+		case da > db:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	var found []Neighbor
+	for _, d := range drivers {
+		if len(found) == limit {
+			break
+		}
+		if dist := distance(d); dist <= radiusKm {
+			found = append(found, Neighbor{ID: d.ID, DistanceKm: dist})
+		}
+	}
+	return found
+}
@@ -2,9 +2,17 @@ package driver
 
 import (
 	"context"
+	"errors"
+	"io"
+	"log"
+	"time"
 
 	pb "github.com/lukabrx/uber-clone/api/proto/driver/v1"
+	pb_money "github.com/lukabrx/uber-clone/api/proto/money/v1"
+	"github.com/lukabrx/uber-clone/internal/geo"
 	"github.com/lukabrx/uber-clone/internal/models"
+	"github.com/lukabrx/uber-clone/internal/money"
+	"google.golang.org/grpc"
 )
 
 type GrpcHandler struct {
@@ -17,26 +25,187 @@ func NewGrpcHandler(service *Service) *GrpcHandler {
 }
 
 func (h *GrpcHandler) RegisterDriver(ctx context.Context, req *pb.RegisterDriverRequest) (*pb.RegisterDriverResponse, error) {
-	driver, err := h.service.RegisterDriver(models.Driver{Name: req.Name, Lat: req.Lat, Lon: req.Lon})
+	driver, err := h.service.RegisterDriver(ctx, models.Driver{
+		Name:        req.Name,
+		Lat:         req.Lat,
+		Lon:         req.Lon,
+		VehicleType: models.VehicleType(req.VehicleType),
+		UserID:      req.UserId,
+	})
 	if err != nil {
-		return nil, err
+		return nil, grpcError(err)
 	}
-	return &pb.RegisterDriverResponse{Driver: &pb.Driver{Id: driver.ID, Name: driver.Name, Lat: driver.Lat, Lon: driver.Lon}}, nil
+	return &pb.RegisterDriverResponse{Driver: toPbDriver(*driver)}, nil
+}
+
+func (h *GrpcHandler) GetDriverByUser(ctx context.Context, req *pb.GetDriverByUserRequest) (*pb.GetDriverByUserResponse, error) {
+	driver, err := h.service.GetDriverByUser(ctx, req.UserId)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.GetDriverByUserResponse{Driver: toPbDriver(driver)}, nil
 }
 
 func (h *GrpcHandler) FindAvailableDrivers(ctx context.Context, req *pb.FindAvailableDriversRequest) (*pb.FindAvailableDriversResponse, error) {
-	drivers := h.service.FindClosestAvailableDrivers(req.Lat, req.Lon)
+	q := DriverQuery{Lat: req.Lat, Lon: req.Lon, RadiusKm: req.RadiusKm, Limit: int(req.Limit)}
+	for _, vt := range req.VehicleTypes {
+		q.VehicleTypes = append(q.VehicleTypes, models.VehicleType(vt))
+	}
+
+	nearby, err := h.service.FindAvailableDrivers(ctx, q)
+	if err != nil {
+		return nil, grpcError(err)
+	}
 	var pbDrivers []*pb.Driver
-	for _, d := range drivers {
-		pbDrivers = append(pbDrivers, &pb.Driver{Id: d.ID, Name: d.Name, Lat: d.Lat, Lon: d.Lon})
+	for _, n := range nearby {
+		d := toPbDriver(n.Driver)
+		// Search results are shown to riders; drivers' accounts stay private.
+		d.UserId = ""
+		d.DistanceKm = n.DistanceKm
+		d.EtaSeconds = int64(geo.EstimateETA(n.DistanceKm).Seconds())
+		pbDrivers = append(pbDrivers, d)
 	}
 	return &pb.FindAvailableDriversResponse{Drivers: pbDrivers}, nil
 }
 
 func (h *GrpcHandler) UpdateDriverStatus(ctx context.Context, req *pb.UpdateDriverStatusRequest) (*pb.UpdateDriverStatusResponse, error) {
-	err := h.service.UpdateDriverStatus(req.Id, req.IsAvailable)
+	err := h.service.UpdateDriverStatus(ctx, req.Id, req.IsAvailable)
 	if err != nil {
-		return nil, err
+		return nil, grpcError(err)
 	}
 	return &pb.UpdateDriverStatusResponse{}, nil
 }
+
+func (h *GrpcHandler) ReserveDriver(ctx context.Context, req *pb.ReserveDriverRequest) (*pb.ReserveDriverResponse, error) {
+	driver, err := h.service.ReserveDriver(ctx, req.DriverId, req.TripId)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.ReserveDriverResponse{Driver: toPbDriver(driver)}, nil
+}
+
+func (h *GrpcHandler) ReleaseDriver(ctx context.Context, req *pb.ReleaseDriverRequest) (*pb.ReleaseDriverResponse, error) {
+	if err := h.service.ReleaseDriver(ctx, req.DriverId, req.TripId); err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.ReleaseDriverResponse{}, nil
+}
+
+func (h *GrpcHandler) OfferTrip(ctx context.Context, req *pb.OfferTripRequest) (*pb.OfferTripResponse, error) {
+	offer, outcome, err := h.service.OfferTrip(ctx, models.TripOffer{
+		DriverID:   req.DriverId,
+		TripID:     req.TripId,
+		PickupLat:  req.PickupLat,
+		PickupLon:  req.PickupLon,
+		DropoffLat: req.DropoffLat,
+		DropoffLon: req.DropoffLon,
+		Price:      fromPbMoney(req.GetPrice()),
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.OfferTripResponse{Offer: toPbTripOffer(offer), Outcome: string(outcome)}, nil
+}
+
+func (h *GrpcHandler) AcceptTripOffer(ctx context.Context, req *pb.AcceptTripOfferRequest) (*pb.AcceptTripOfferResponse, error) {
+	offer, err := h.service.AcceptTripOffer(req.OfferId, req.DriverId)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.AcceptTripOfferResponse{Offer: toPbTripOffer(offer)}, nil
+}
+
+func (h *GrpcHandler) DeclineTripOffer(ctx context.Context, req *pb.DeclineTripOfferRequest) (*pb.DeclineTripOfferResponse, error) {
+	if err := h.service.DeclineTripOffer(req.OfferId, req.DriverId, req.Reason); err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.DeclineTripOfferResponse{}, nil
+}
+
+func (h *GrpcHandler) GetOfferStats(ctx context.Context, req *pb.GetOfferStatsRequest) (*pb.GetOfferStatsResponse, error) {
+	stats, err := h.service.GetOfferStats(ctx, req.DriverId)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.GetOfferStatsResponse{
+		Offered:        stats.Offered,
+		Accepted:       stats.Accepted,
+		Declined:       stats.Declined,
+		Expired:        stats.Expired,
+		AcceptanceRate: stats.AcceptanceRate(),
+	}, nil
+}
+
+func (h *GrpcHandler) UpdateLocation(ctx context.Context, req *pb.UpdateLocationRequest) (*pb.UpdateLocationResponse, error) {
+	driver, err := h.service.UpdateLocation(ctx, toLocationPing(req))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.UpdateLocationResponse{Driver: toPbDriver(driver)}, nil
+}
+
+func (h *GrpcHandler) StreamLocation(stream grpc.ClientStreamingServer[pb.UpdateLocationRequest, pb.StreamLocationResponse]) error {
+	var res pb.StreamLocationResponse
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return stream.SendAndClose(&res)
+		}
+		if err != nil {
+			return err
+		}
+
+		// A bad ping should not end the stream; count it and keep going.
+		if _, err := h.service.UpdateLocation(stream.Context(), toLocationPing(req)); err != nil {
+			log.Printf("Rejected location ping for driver %s: %v", req.DriverId, err)
+			res.Rejected++
+			continue
+		}
+		res.Accepted++
+	}
+}
+
+func toLocationPing(req *pb.UpdateLocationRequest) models.LocationPing {
+	ping := models.LocationPing{
+		DriverID: req.DriverId,
+		Lat:      req.Lat,
+		Lon:      req.Lon,
+		Heading:  req.Heading,
+		SpeedKmh: req.SpeedKmh,
+	}
+	if req.Timestamp > 0 {
+		ping.Timestamp = time.UnixMilli(req.Timestamp)
+	}
+	return ping
+}
+
+func toPbDriver(driver models.Driver) *pb.Driver {
+	return &pb.Driver{
+		Id:          driver.ID,
+		Name:        driver.Name,
+		Lat:         driver.Lat,
+		Lon:         driver.Lon,
+		Heading:     driver.Heading,
+		SpeedKmh:    driver.SpeedKmh,
+		VehicleType: string(driver.VehicleType),
+		UserId:      driver.UserID,
+	}
+}
+
+func toPbTripOffer(offer models.TripOffer) *pb.TripOffer {
+	return &pb.TripOffer{
+		Id:         offer.ID,
+		DriverId:   offer.DriverID,
+		TripId:     offer.TripID,
+		PickupLat:  offer.PickupLat,
+		PickupLon:  offer.PickupLon,
+		DropoffLat: offer.DropoffLat,
+		DropoffLon: offer.DropoffLon,
+		Price:      &pb_money.Money{Amount: offer.Price.Amount, Currency: offer.Price.Currency},
+		ExpiresAt:  offer.ExpiresAt.Unix(),
+	}
+}
+
+func fromPbMoney(m *pb_money.Money) money.Money {
+	return money.New(m.GetAmount(), m.GetCurrency())
+}
@@ -0,0 +1,128 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lukabrx/uber-clone/internal/models"
+)
+
+type OfferOutcome string
+
+const (
+	OfferAccepted OfferOutcome = "accepted"
+	OfferDeclined OfferOutcome = "declined"
+	OfferExpired  OfferOutcome = "expired"
+	// OfferWithdrawn means the trip service stopped waiting, e.g. because the
+	// rider cancelled. It does not count against the driver.
+	OfferWithdrawn OfferOutcome = "withdrawn"
+)
+
+var (
+	ErrOfferNotFound     = errors.New("offer not found or no longer open")
+	ErrOfferNotForDriver = errors.New("offer was made to a different driver")
+)
+
+// OfferStats counts how a driver responded to the offers made to them.
+type OfferStats struct {
+	DriverID string
+	Offered  int64
+	Accepted int64
+	Declined int64
+	Expired  int64
+}
+
+func (s OfferStats) AcceptanceRate() float64 {
+	if s.Offered == 0 {
+		return 0
+	}
+	return float64(s.Accepted) / float64(s.Offered)
+}
+
+type pendingOffer struct {
+	offer  models.TripOffer
+	result chan OfferOutcome
+}
+
+// OfferManager tracks the offers that are waiting for a driver's answer.
+type OfferManager struct {
+	window  time.Duration
+	mu      sync.Mutex
+	pending map[string]*pendingOffer
+}
+
+func NewOfferManager(window time.Duration) *OfferManager {
+	return &OfferManager{
+		window:  window,
+		pending: make(map[string]*pendingOffer),
+	}
+}
+
+func (m *OfferManager) open(offer models.TripOffer) *pendingOffer {
+	offer.ID = uuid.New().String()
+	offer.ExpiresAt = time.Now().Add(m.window)
+
+	p := &pendingOffer{offer: offer, result: make(chan OfferOutcome, 1)}
+
+	m.mu.Lock()
+	m.pending[offer.ID] = p
+	m.mu.Unlock()
+
+	return p
+}
+
+// wait blocks until the driver answers, the window closes or ctx is done.
+func (m *OfferManager) wait(ctx context.Context, p *pendingOffer) OfferOutcome {
+	timer := time.NewTimer(time.Until(p.offer.ExpiresAt))
+	defer timer.Stop()
+
+	var outcome OfferOutcome
+	select {
+	case outcome = <-p.result:
+		return outcome
+	case <-timer.C:
+		outcome = OfferExpired
+	case <-ctx.Done():
+		outcome = OfferWithdrawn
+	}
+
+	// The driver may have answered just as the offer closed.
+	if m.close(p.offer.ID) == nil {
+		return <-p.result
+	}
+	return outcome
+}
+
+// resolve records the driver's answer to an open offer.
+func (m *OfferManager) resolve(offerID, driverID string, outcome OfferOutcome) (models.TripOffer, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	p, ok := m.pending[offerID]
+	if !ok {
+		return models.TripOffer{}, ErrOfferNotFound
+	}
+	if p.offer.DriverID != driverID {
+		return models.TripOffer{}, ErrOfferNotForDriver
+	}
+
+	delete(m.pending, offerID)
+	p.result <- outcome
+	return p.offer, nil
+}
+
+// close removes an offer and returns it, or nil if it was already resolved.
+func (m *OfferManager) close(offerID string) *pendingOffer {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	p, ok := m.pending[offerID]
+	if !ok {
+		return nil
+	}
+	delete(m.pending, offerID)
+	return p
+}
@@ -0,0 +1,307 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/lukabrx/uber-clone/internal/geo"
+	"github.com/lukabrx/uber-clone/internal/models"
+	"github.com/lukabrx/uber-clone/internal/outbox"
+)
+
+const driverColumns = `id, name, vehicle_type, is_available, lat, lon, heading, speed_kmh, location_updated_at, trip_id, user_id`
+
+// uniqueViolation is the Postgres error code for a duplicate key.
+const uniqueViolation = "23505"
+
+type PostgresRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresRepository(pool *pgxpool.Pool) *PostgresRepository {
+	return &PostgresRepository{pool: pool}
+}
+
+func scanDriver(row pgx.Row) (models.Driver, error) {
+	var d models.Driver
+	err := row.Scan(&d.ID, &d.Name, &d.VehicleType, &d.IsAvailable, &d.Lat, &d.Lon,
+		&d.Heading, &d.SpeedKmh, &d.LocationUpdatedAt, &d.TripID, &d.UserID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.Driver{}, ErrDriverNotFound
+	}
+	return d, err
+}
+
+func (r *PostgresRepository) RegisterDriver(ctx context.Context, driver models.Driver) (models.Driver, error) {
+	driver.ID = uuid.New().String()
+	driver.IsAvailable = true
+	if driver.VehicleType == "" {
+		driver.VehicleType = models.VehicleEconomy
+	}
+	driver.LocationUpdatedAt = time.Now()
+
+	msg, err := driverMessage(ctx, driver)
+	if err != nil {
+		return models.Driver{}, err
+	}
+	err = pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO drivers (`+driverColumns+`)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+			driver.ID, driver.Name, driver.VehicleType, driver.IsAvailable, driver.Lat, driver.Lon,
+			driver.Heading, driver.SpeedKmh, driver.LocationUpdatedAt, driver.TripID, driver.UserID,
+		)
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return ErrUserHasDriver
+		}
+		if err != nil {
+			return err
+		}
+		return outbox.EnqueueTx(ctx, tx, msg)
+	})
+	if err != nil {
+		return models.Driver{}, err
+	}
+	return driver, nil
+}
+
+func (r *PostgresRepository) GetDriverByID(ctx context.Context, id string) (*models.Driver, error) {
+	d, err := scanDriver(r.pool.QueryRow(ctx,
+		`SELECT `+driverColumns+` FROM drivers WHERE id = $1`, id))
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *PostgresRepository) GetDriverByUserID(ctx context.Context, userID string) (models.Driver, error) {
+	if userID == "" {
+		return models.Driver{}, ErrDriverNotFound
+	}
+	return scanDriver(r.pool.QueryRow(ctx,
+		`SELECT `+driverColumns+` FROM drivers WHERE user_id = $1`, userID))
+}
+
+func (r *PostgresRepository) IsDriverAvailable(ctx context.Context, id string) (bool, error) {
+	d, err := r.GetDriverByID(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	return d.IsAvailable, nil
+}
+
+// UpdateDriverStatus refuses reserved drivers, matching MemoryRepository.
+func (r *PostgresRepository) UpdateDriverStatus(ctx context.Context, id string, isAvailable bool) error {
+	_, err := r.update(ctx, id, func(d *models.Driver) error {
+		if d.TripID != "" {
+			return ErrDriverReserved
+		}
+		d.IsAvailable = isAvailable
+		return nil
+	})
+	return err
+}
+
+// UpdateDriverLocation stores a location ping unless the stored location is
+// newer, matching MemoryRepository.
+func (r *PostgresRepository) UpdateDriverLocation(ctx context.Context, ping models.LocationPing) (models.Driver, error) {
+	return r.update(ctx, ping.DriverID, func(d *models.Driver) error {
+		if ping.Timestamp.Before(d.LocationUpdatedAt) {
+			return ErrStaleLocation
+		}
+		d.Lat = ping.Lat
+		d.Lon = ping.Lon
+		d.Heading = ping.Heading
+		d.SpeedKmh = ping.SpeedKmh
+		d.LocationUpdatedAt = ping.Timestamp
+		return nil
+	})
+}
+
+// ReserveDriver claims an available driver for a trip. The row is locked while
+// it is checked, so of two concurrent reservations only one succeeds.
+func (r *PostgresRepository) ReserveDriver(ctx context.Context, driverID, tripID string) (models.Driver, error) {
+	return r.update(ctx, driverID, func(d *models.Driver) error {
+		if d.TripID == tripID {
+			return errUnchanged
+		}
+		if d.TripID != "" {
+			return ErrDriverReserved
+		}
+		if !d.IsAvailable {
+			return ErrDriverUnavailable
+		}
+		d.IsAvailable = false
+		d.TripID = tripID
+		return nil
+	})
+}
+
+// ReleaseDriver frees a driver that is reserved for the given trip, matching
+// MemoryRepository.
+func (r *PostgresRepository) ReleaseDriver(ctx context.Context, driverID, tripID string) (models.Driver, error) {
+	return r.update(ctx, driverID, func(d *models.Driver) error {
+		if d.TripID != tripID {
+			if d.TripID == "" {
+				return errUnchanged
+			}
+			return ErrDriverReserved
+		}
+		d.IsAvailable = true
+		d.TripID = ""
+		return nil
+	})
+}
+
+// errUnchanged tells update that apply left the driver as it was, so there is
+// nothing to write.
+var errUnchanged = errors.New("driver unchanged")
+
+// update locks a driver row, lets apply change it and writes it back together
+// with its event.
+func (r *PostgresRepository) update(ctx context.Context, id string, apply func(*models.Driver) error) (models.Driver, error) {
+	var driver models.Driver
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		d, err := scanDriver(tx.QueryRow(ctx, `SELECT `+driverColumns+` FROM drivers WHERE id = $1 FOR UPDATE`, id))
+		if err != nil {
+			return err
+		}
+		driver = d
+		if err := apply(&d); err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, `
+			UPDATE drivers
+			SET is_available = $2, lat = $3, lon = $4, heading = $5, speed_kmh = $6,
+			    location_updated_at = $7, trip_id = $8
+			WHERE id = $1`,
+			d.ID, d.IsAvailable, d.Lat, d.Lon, d.Heading, d.SpeedKmh, d.LocationUpdatedAt, d.TripID,
+		)
+		if err != nil {
+			return err
+		}
+		msg, err := driverMessage(ctx, d)
+		if err != nil {
+			return err
+		}
+		driver = d
+		return outbox.EnqueueTx(ctx, tx, msg)
+	})
+	if err != nil && !errors.Is(err, errUnchanged) {
+		return models.Driver{}, err
+	}
+	return driver, nil
+}
+
+// FindNearbyAvailableDrivers follows the radius and limit rules of
+// GeoIndex.Nearby. A radius narrows the search to its bounding box in SQL;
+// exact distances are computed here.
+func (r *PostgresRepository) FindNearbyAvailableDrivers(ctx context.Context, lat, lon, radiusKm float64, limit int, vehicleTypes []models.VehicleType) ([]NearbyDriver, error) {
+	where := []string{"is_available"}
+	args := []any{lat, lon}
+	if radiusKm > 0 {
+		dLat := radiusKm / geo.KmPerDegreeLat
+		dLon := radiusKm / geo.KmPerDegreeLon(lat)
+		args = append(args, lat-dLat, lat+dLat, lon-dLon, lon+dLon)
+		where = append(where, "lat BETWEEN $3 AND $4", "lon BETWEEN $5 AND $6")
+	}
+	if len(vehicleTypes) > 0 {
+		types := make([]string, len(vehicleTypes))
+		for i, vt := range vehicleTypes {
+			types[i] = string(vt)
+		}
+		args = append(args, types)
+		where = append(where, fmt.Sprintf("vehicle_type = ANY($%d)", len(args)))
+	}
+
+	// Order by the flat-earth distance, which is close enough to pick
+	// candidates; a few extra rows cover the difference from the exact one.
+	query := `SELECT ` + driverColumns + ` FROM drivers WHERE ` + strings.Join(where, " AND ") +
+		` ORDER BY power(lat - $1, 2) + power((lon - $2) * cos(radians($1)), 2)`
+	if limit > 0 {
+		args = append(args, 2*limit+10)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var nearby []NearbyDriver
+	for rows.Next() {
+		d, err := scanDriver(rows)
+		if err != nil {
+			return nil, err
+		}
+		distance := geo.DistanceKm(lat, lon, d.Lat, d.Lon)
+		if radiusKm > 0 && distance > radiusKm {
+			continue
+		}
+		nearby = append(nearby, NearbyDriver{Driver: d, DistanceKm: distance})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(nearby, func(a, b NearbyDriver) int {
+		if a.DistanceKm != b.DistanceKm {
+			if a.DistanceKm < b.DistanceKm {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Driver.ID, b.Driver.ID)
+	})
+	if limit > 0 && len(nearby) > limit {
+		nearby = nearby[:limit]
+	}
+	return nearby, nil
+}
+
+func (r *PostgresRepository) RecordOfferOutcome(ctx context.Context, driverID string, outcome OfferOutcome) error {
+	column := map[OfferOutcome]string{
+		OfferAccepted: "accepted",
+		OfferDeclined: "declined",
+		OfferExpired:  "expired",
+	}[outcome]
+	if column == "" {
+		return nil
+	}
+
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO driver_offer_stats (driver_id, offered, `+column+`) VALUES ($1, 1, 1)
+		ON CONFLICT (driver_id) DO UPDATE
+		SET offered = driver_offer_stats.offered + 1, `+column+` = driver_offer_stats.`+column+` + 1`,
+		driverID,
+	)
+	return err
+}
+
+func (r *PostgresRepository) GetOfferStats(ctx context.Context, driverID string) (OfferStats, error) {
+	stats := OfferStats{DriverID: driverID}
+	var exists bool
+	err := r.pool.QueryRow(ctx, `
+		SELECT true, COALESCE(s.offered, 0), COALESCE(s.accepted, 0), COALESCE(s.declined, 0), COALESCE(s.expired, 0)
+		FROM drivers d LEFT JOIN driver_offer_stats s ON s.driver_id = d.id
+		WHERE d.id = $1`,
+		driverID,
+	).Scan(&exists, &stats.Offered, &stats.Accepted, &stats.Declined, &stats.Expired)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return OfferStats{}, ErrDriverNotFound
+	}
+	if err != nil {
+		return OfferStats{}, err
+	}
+	return stats, nil
+}
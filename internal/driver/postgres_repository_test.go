@@ -0,0 +1,14 @@
+package driver_test
+
+import (
+	"testing"
+
+	"github.com/lukabrx/uber-clone/internal/driver"
+	"github.com/lukabrx/uber-clone/internal/repotest"
+)
+
+func TestPostgresRepository(t *testing.T) {
+	repotest.RunDriverRepository(t, func(t *testing.T) driver.DriverRepository {
+		return driver.NewPostgresRepository(repotest.Postgres(t))
+	})
+}
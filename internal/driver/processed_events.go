@@ -0,0 +1,139 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/lukabrx/uber-clone/internal/types"
+)
+
+var (
+	ErrDuplicateEvent = errors.New("event was already processed")
+	ErrStaleEvent     = errors.New("event is older than one already processed for the trip")
+)
+
+// ProcessedEvents remembers which trip events the consumer has handled, so
+// redelivered events and events overtaken by a newer one for the same trip are
+// skipped. Events without a version (published before versions existed) are
+// only checked by ID.
+type ProcessedEvents interface {
+	// Check returns ErrDuplicateEvent or ErrStaleEvent if event must be skipped.
+	Check(ctx context.Context, event types.TripEvent) error
+	// Mark records that event has been handled.
+	Mark(ctx context.Context, event types.TripEvent) error
+}
+
+// DefaultProcessedEventsRetention is how long MemoryProcessedEvents remembers a
+// trip after its last event, well past Kafka's redelivery window.
+const DefaultProcessedEventsRetention = 24 * time.Hour
+
+type MemoryProcessedEvents struct {
+	retention time.Duration
+	mu        sync.Mutex
+	trips     map[string]*processedTrip
+	lastPrune time.Time
+}
+
+type processedTrip struct {
+	version   int64
+	eventIDs  map[string]bool
+	updatedAt time.Time
+}
+
+func NewMemoryProcessedEvents(retention time.Duration) *MemoryProcessedEvents {
+	return &MemoryProcessedEvents{
+		retention: retention,
+		trips:     make(map[string]*processedTrip),
+		lastPrune: time.Now(),
+	}
+}
+
+func (p *MemoryProcessedEvents) Check(ctx context.Context, event types.TripEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	trip, ok := p.trips[event.TripID]
+	if !ok {
+		return nil
+	}
+	if event.EventID != "" && trip.eventIDs[event.EventID] {
+		return ErrDuplicateEvent
+	}
+	if event.Version > 0 && event.Version <= trip.version {
+		return ErrStaleEvent
+	}
+	return nil
+}
+
+func (p *MemoryProcessedEvents) Mark(ctx context.Context, event types.TripEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	trip, ok := p.trips[event.TripID]
+	if !ok {
+		trip = &processedTrip{eventIDs: make(map[string]bool)}
+		p.trips[event.TripID] = trip
+	}
+	if event.EventID != "" {
+		trip.eventIDs[event.EventID] = true
+	}
+	trip.version = max(trip.version, event.Version)
+	trip.updatedAt = now
+
+	if now.Sub(p.lastPrune) > p.retention/24 {
+		for id, t := range p.trips {
+			if now.Sub(t.updatedAt) > p.retention {
+				delete(p.trips, id)
+			}
+		}
+		p.lastPrune = now
+	}
+	return nil
+}
+
+// PostgresProcessedEvents keeps processed events in processed_trip_events, so
+// they survive restarts.
+type PostgresProcessedEvents struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresProcessedEvents(pool *pgxpool.Pool) *PostgresProcessedEvents {
+	return &PostgresProcessedEvents{pool: pool}
+}
+
+func (p *PostgresProcessedEvents) Check(ctx context.Context, event types.TripEvent) error {
+	var duplicate bool
+	var version int64
+	err := p.pool.QueryRow(ctx, `
+		SELECT
+			EXISTS (SELECT 1 FROM processed_trip_events WHERE event_id = $1),
+			COALESCE((SELECT max(version) FROM processed_trip_events WHERE trip_id = $2), 0)`,
+		event.EventID, event.TripID,
+	).Scan(&duplicate, &version)
+	if err != nil {
+		return err
+	}
+	if duplicate {
+		return ErrDuplicateEvent
+	}
+	if event.Version > 0 && event.Version <= version {
+		return ErrStaleEvent
+	}
+	return nil
+}
+
+func (p *PostgresProcessedEvents) Mark(ctx context.Context, event types.TripEvent) error {
+	if event.EventID == "" {
+		return nil
+	}
+	_, err := p.pool.Exec(ctx, `
+		INSERT INTO processed_trip_events (event_id, trip_id, version) VALUES ($1, $2, $3)
+		ON CONFLICT (event_id) DO NOTHING`,
+		event.EventID, event.TripID, event.Version,
+	)
+	return err
+}
@@ -0,0 +1,42 @@
+package driver_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lukabrx/uber-clone/internal/driver"
+	"github.com/lukabrx/uber-clone/internal/repotest"
+	"github.com/lukabrx/uber-clone/internal/types"
+)
+
+func TestMemoryProcessedEvents(t *testing.T) {
+	repotest.RunProcessedEvents(t, func(t *testing.T) driver.ProcessedEvents {
+		return driver.NewMemoryProcessedEvents(driver.DefaultProcessedEventsRetention)
+	})
+}
+
+func TestPostgresProcessedEvents(t *testing.T) {
+	repotest.RunProcessedEvents(t, func(t *testing.T) driver.ProcessedEvents {
+		return driver.NewPostgresProcessedEvents(repotest.Postgres(t))
+	})
+}
+
+func TestMemoryProcessedEventsForgetsOldTrips(t *testing.T) {
+	ctx := context.Background()
+	const retention = 24 * time.Millisecond
+	store := driver.NewMemoryProcessedEvents(retention)
+	old := types.TripEvent{EventID: "e1", Version: 1, TripID: "trip-1"}
+	if err := store.Mark(ctx, old); err != nil {
+		t.Fatal(err)
+	}
+
+	// Marking another trip once the retention has passed prunes the first.
+	time.Sleep(2 * retention)
+	if err := store.Mark(ctx, types.TripEvent{EventID: "e2", Version: 1, TripID: "trip-2"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Check(ctx, old); err != nil {
+		t.Errorf("Check of a forgotten trip's event = %v, want nil", err)
+	}
+}
@@ -1,45 +1,234 @@
 package driver
 
 import (
-	"errors"
+	"context"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/lukabrx/uber-clone/internal/models"
+	"github.com/lukabrx/uber-clone/internal/outbox"
 )
 
+// DriverRepository stores drivers, their locations and offer statistics. Every
+// write that changes a driver also stores the driver's new state in the outbox
+// for DriverLocationTopic, in the same write.
+type DriverRepository interface {
+	RegisterDriver(ctx context.Context, driver models.Driver) (models.Driver, error)
+	GetDriverByID(ctx context.Context, id string) (*models.Driver, error)
+	GetDriverByUserID(ctx context.Context, userID string) (models.Driver, error)
+	IsDriverAvailable(ctx context.Context, id string) (bool, error)
+	UpdateDriverStatus(ctx context.Context, id string, isAvailable bool) error
+	UpdateDriverLocation(ctx context.Context, ping models.LocationPing) (models.Driver, error)
+	ReserveDriver(ctx context.Context, driverID, tripID string) (models.Driver, error)
+	ReleaseDriver(ctx context.Context, driverID, tripID string) (models.Driver, error)
+	FindNearbyAvailableDrivers(ctx context.Context, lat, lon, radiusKm float64, limit int, vehicleTypes []models.VehicleType) ([]NearbyDriver, error)
+	RecordOfferOutcome(ctx context.Context, driverID string, outcome OfferOutcome) error
+	GetOfferStats(ctx context.Context, driverID string) (OfferStats, error)
+}
+
 type MemoryRepository struct {
-	drivers map[string]*models.Driver
-	mu      sync.RWMutex
+	drivers    map[string]*models.Driver
+	offerStats map[string]*OfferStats
+	// available indexes the location of every available driver.
+	available *GeoIndex
+	events    outbox.Store // nil drops events
+	mu        sync.RWMutex
 }
 
-func NewMemoryRepository() *MemoryRepository {
+func NewMemoryRepository(events outbox.Store) *MemoryRepository {
 	return &MemoryRepository{
-		drivers: make(map[string]*models.Driver),
+		drivers:    make(map[string]*models.Driver),
+		offerStats: make(map[string]*OfferStats),
+		available:  NewGeoIndex(DefaultCellSizeDeg),
+		events:     events,
+	}
+}
+
+// save stores the driver's event in the outbox and, once that succeeds, the
+// driver itself. Callers must hold the write lock.
+func (r *MemoryRepository) save(ctx context.Context, driver models.Driver) error {
+	if r.events != nil {
+		msg, err := driverMessage(ctx, driver)
+		if err != nil {
+			return err
+		}
+		if err := r.events.Enqueue(ctx, msg); err != nil {
+			return err
+		}
+	}
+	stored, ok := r.drivers[driver.ID]
+	if !ok {
+		stored = &models.Driver{}
+		r.drivers[driver.ID] = stored
+	}
+	*stored = driver
+	r.reindex(stored)
+	return nil
+}
+
+// NearbyDriver is an available driver and how far it is from a query point.
+type NearbyDriver struct {
+	Driver     models.Driver
+	DistanceKm float64
+}
+
+// FindNearbyAvailableDrivers returns available drivers closest first, using the
+// same radius and limit rules as GeoIndex.Nearby. An empty vehicleTypes matches
+// every vehicle.
+func (r *MemoryRepository) FindNearbyAvailableDrivers(ctx context.Context, lat, lon, radiusKm float64, limit int, vehicleTypes []models.VehicleType) ([]NearbyDriver, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var keep func(id string) bool
+	if len(vehicleTypes) > 0 {
+		keep = func(id string) bool {
+			return slices.Contains(vehicleTypes, r.drivers[id].VehicleType)
+		}
+	}
+
+	neighbors := r.available.Nearby(lat, lon, radiusKm, limit, keep)
+	nearby := make([]NearbyDriver, 0, len(neighbors))
+	for _, n := range neighbors {
+		nearby = append(nearby, NearbyDriver{Driver: *r.drivers[n.ID], DistanceKm: n.DistanceKm})
+	}
+	return nearby, nil
+}
+
+// reindex keeps the geo index in step with a driver's availability and position.
+// Callers must hold the write lock.
+func (r *MemoryRepository) reindex(driver *models.Driver) {
+	if driver.IsAvailable {
+		r.available.Upsert(driver.ID, driver.Lat, driver.Lon)
+	} else {
+		r.available.Remove(driver.ID)
 	}
 }
 
-func (r *MemoryRepository) RegisterDriver(driver models.Driver) (models.Driver, error) {
+func (r *MemoryRepository) RegisterDriver(ctx context.Context, driver models.Driver) (models.Driver, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if driver.UserID != "" {
+		for _, d := range r.drivers {
+			if d.UserID == driver.UserID {
+				return models.Driver{}, ErrUserHasDriver
+			}
+		}
+	}
 	driver.ID = uuid.New().String()
 	driver.IsAvailable = true
-	r.drivers[driver.ID] = &driver
-
+	if driver.VehicleType == "" {
+		driver.VehicleType = models.VehicleEconomy
+	}
+	driver.LocationUpdatedAt = time.Now()
+	if err := r.save(ctx, driver); err != nil {
+		return models.Driver{}, err
+	}
 	return driver, nil
 }
 
-func (r *MemoryRepository) UpdateDriverStatus(id string, isAvailable bool) error {
+// UpdateDriverStatus takes a driver on or off duty. A driver reserved for a
+// trip keeps their status until ReleaseDriver frees them, so this can never
+// make them available for a second trip.
+func (r *MemoryRepository) UpdateDriverStatus(ctx context.Context, id string, isAvailable bool) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	driver, ok := r.drivers[id]
+	stored, ok := r.drivers[id]
 	if !ok {
-		return errors.New("driver not found")
+		return ErrDriverNotFound
 	}
+	if stored.TripID != "" {
+		return ErrDriverReserved
+	}
+	driver := *stored
 	driver.IsAvailable = isAvailable
-	return nil
+	return r.save(ctx, driver)
+}
+
+// UpdateDriverLocation stores a location ping. Pings older than the last one
+// stored are rejected, so out-of-order delivery cannot move a driver backwards.
+func (r *MemoryRepository) UpdateDriverLocation(ctx context.Context, ping models.LocationPing) (models.Driver, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.drivers[ping.DriverID]
+	if !ok {
+		return models.Driver{}, ErrDriverNotFound
+	}
+	if ping.Timestamp.Before(stored.LocationUpdatedAt) {
+		return models.Driver{}, ErrStaleLocation
+	}
+
+	driver := *stored
+	driver.Lat = ping.Lat
+	driver.Lon = ping.Lon
+	driver.Heading = ping.Heading
+	driver.SpeedKmh = ping.SpeedKmh
+	driver.LocationUpdatedAt = ping.Timestamp
+	if err := r.save(ctx, driver); err != nil {
+		return models.Driver{}, err
+	}
+	return driver, nil
+}
+
+// ReserveDriver claims an available driver for a trip. The check and the write
+// happen under one lock, so of two concurrent reservations only one succeeds.
+// Reserving a driver again for the same trip is a no-op.
+func (r *MemoryRepository) ReserveDriver(ctx context.Context, driverID, tripID string) (models.Driver, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.drivers[driverID]
+	if !ok {
+		return models.Driver{}, ErrDriverNotFound
+	}
+	if stored.TripID == tripID {
+		return *stored, nil
+	}
+	if stored.TripID != "" {
+		return models.Driver{}, ErrDriverReserved
+	}
+	if !stored.IsAvailable {
+		return models.Driver{}, ErrDriverUnavailable
+	}
+
+	driver := *stored
+	driver.IsAvailable = false
+	driver.TripID = tripID
+	if err := r.save(ctx, driver); err != nil {
+		return models.Driver{}, err
+	}
+	return driver, nil
+}
+
+// ReleaseDriver frees a driver that is reserved for the given trip. It fails if
+// the driver has since been reserved for a different trip, so late events for an
+// old trip cannot free a driver that is busy with a new one.
+func (r *MemoryRepository) ReleaseDriver(ctx context.Context, driverID, tripID string) (models.Driver, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.drivers[driverID]
+	if !ok {
+		return models.Driver{}, ErrDriverNotFound
+	}
+	if stored.TripID != tripID {
+		if stored.TripID == "" {
+			return *stored, nil
+		}
+		return models.Driver{}, ErrDriverReserved
+	}
+
+	driver := *stored
+	driver.IsAvailable = true
+	driver.TripID = ""
+	if err := r.save(ctx, driver); err != nil {
+		return models.Driver{}, err
+	}
+	return driver, nil
 }
 
 func (r *MemoryRepository) GetAvailableDrivers() []models.Driver {
@@ -65,24 +254,79 @@ func (r *MemoryRepository) GetAllDrivers() []models.Driver {
 	return all
 }
 
-func (r *MemoryRepository) IsDriverAvailable(id string) (bool, error) {
+func (r *MemoryRepository) IsDriverAvailable(ctx context.Context, id string) (bool, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	driver, ok := r.drivers[id]
 	if !ok {
-		return false, errors.New("driver not found")
+		return false, ErrDriverNotFound
 	}
 	return driver.IsAvailable, nil
 }
 
-func (r *MemoryRepository) GetDriverByID(id string) (*models.Driver, error) {
+func (r *MemoryRepository) GetDriverByID(ctx context.Context, id string) (*models.Driver, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	driver, ok := r.drivers[id]
 	if !ok {
-		return nil, errors.New("driver not found")
+		return nil, ErrDriverNotFound
 	}
-	return driver, nil
+	// Return a copy so callers never read the stored driver without the lock.
+	d := *driver
+	return &d, nil
+}
+
+func (r *MemoryRepository) GetDriverByUserID(ctx context.Context, userID string) (models.Driver, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if userID != "" {
+		for _, d := range r.drivers {
+			if d.UserID == userID {
+				return *d, nil
+			}
+		}
+	}
+	return models.Driver{}, ErrDriverNotFound
+}
+
+func (r *MemoryRepository) RecordOfferOutcome(ctx context.Context, driverID string, outcome OfferOutcome) error {
+	if outcome == OfferWithdrawn {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats, ok := r.offerStats[driverID]
+	if !ok {
+		stats = &OfferStats{DriverID: driverID}
+		r.offerStats[driverID] = stats
+	}
+	stats.Offered++
+	switch outcome {
+	case OfferAccepted:
+		stats.Accepted++
+	case OfferDeclined:
+		stats.Declined++
+	case OfferExpired:
+		stats.Expired++
+	}
+	return nil
+}
+
+func (r *MemoryRepository) GetOfferStats(ctx context.Context, driverID string) (OfferStats, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if _, ok := r.drivers[driverID]; !ok {
+		return OfferStats{}, ErrDriverNotFound
+	}
+	stats, ok := r.offerStats[driverID]
+	if !ok {
+		return OfferStats{DriverID: driverID}, nil
+	}
+	return *stats, nil
 }
@@ -0,0 +1,70 @@
+package driver_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/lukabrx/uber-clone/internal/driver"
+	"github.com/lukabrx/uber-clone/internal/models"
+	"github.com/lukabrx/uber-clone/internal/outbox"
+	"github.com/lukabrx/uber-clone/internal/repotest"
+)
+
+func TestMemoryRepository(t *testing.T) {
+	repotest.RunDriverRepository(t, func(t *testing.T) driver.DriverRepository {
+		return driver.NewMemoryRepository(outbox.NewMemoryStore())
+	})
+}
+
+// TestReserveDriverConcurrently races many trips for one driver, with status
+// updates mixed in, and checks the driver ends up booked exactly once.
+func TestReserveDriverConcurrently(t *testing.T) {
+	const trips = 50
+	ctx := context.Background()
+	repo := driver.NewMemoryRepository(nil)
+	d, err := repo.RegisterDriver(ctx, models.Driver{Name: "Marko"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		won []string
+	)
+	start := make(chan struct{})
+	for i := range trips {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			<-start
+			tripID := fmt.Sprintf("trip-%d", i)
+			if _, err := repo.ReserveDriver(ctx, d.ID, tripID); err == nil {
+				mu.Lock()
+				won = append(won, tripID)
+				mu.Unlock()
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			<-start
+			// Refused once the driver is reserved; must never free them.
+			repo.UpdateDriverStatus(ctx, d.ID, true)
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if len(won) != 1 {
+		t.Fatalf("%d reservations succeeded (%v), want exactly 1", len(won), won)
+	}
+	got, err := repo.GetDriverByID(ctx, d.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.TripID != won[0] || got.IsAvailable {
+		t.Fatalf("driver = %+v, want it reserved for %s", *got, won[0])
+	}
+}
@@ -1,76 +1,211 @@
 package driver
 
 import (
+	"context"
+	"fmt"
 	"log"
-	"math"
-	"sort"
+	"time"
 
 	"github.com/lukabrx/uber-clone/internal/models"
+	"github.com/lukabrx/uber-clone/internal/outbox"
+	"github.com/lukabrx/uber-clone/internal/types"
 )
 
 type Service struct {
-	repo     *MemoryRepository
-	producer *KafkaProducer
+	repo   DriverRepository
+	events outbox.Store
+	offers *OfferManager
 }
 
-func NewService(repo *MemoryRepository, producer *KafkaProducer) *Service {
-	return &Service{repo: repo, producer: producer}
+// NewService wires a driver service. Driver updates reach Kafka through the
+// outbox entries the repository writes; events enqueues trip offer events.
+func NewService(repo DriverRepository, events outbox.Store, offers *OfferManager) *Service {
+	return &Service{repo: repo, events: events, offers: offers}
 }
 
-func (s *Service) RegisterDriver(d models.Driver) (*models.Driver, error) {
-	driver, err := s.repo.RegisterDriver(d)
+func (s *Service) RegisterDriver(ctx context.Context, d models.Driver) (*models.Driver, error) {
+	switch d.VehicleType {
+	case "", models.VehicleEconomy, models.VehicleComfort, models.VehicleXL:
+	default:
+		return nil, ErrInvalidVehicle
+	}
+
+	driver, err := s.repo.RegisterDriver(ctx, d)
 	if err != nil {
 		return nil, err
 	}
 
-	log.Printf("New driver registered %s, publishing availability update", driver.ID)
-	s.producer.ProduceAvailableDriverUpdate(driver)
-
+	log.Printf("New driver registered %s", driver.ID)
 	return &driver, nil
 }
 
-func (s *Service) UpdateDriverStatus(id string, isAvailable bool) error {
-	err := s.repo.UpdateDriverStatus(id, isAvailable)
-	if err != nil {
+// GetDriverByUser returns the driver a user registered.
+func (s *Service) GetDriverByUser(ctx context.Context, userID string) (models.Driver, error) {
+	return s.repo.GetDriverByUserID(ctx, userID)
+}
+
+func (s *Service) UpdateDriverStatus(ctx context.Context, id string, isAvailable bool) error {
+	if err := s.repo.UpdateDriverStatus(ctx, id, isAvailable); err != nil {
 		return err
 	}
 
-	driver, err := s.repo.GetDriverByID(id)
+	log.Printf("Driver %s status updated to available: %v", id, isAvailable)
+	return nil
+}
+
+// UpdateLocation stores a driver's latest position and publishes it.
+func (s *Service) UpdateLocation(ctx context.Context, ping models.LocationPing) (models.Driver, error) {
+	if err := validatePing(ping); err != nil {
+		return models.Driver{}, err
+	}
+	if ping.Timestamp.IsZero() {
+		ping.Timestamp = time.Now()
+	}
+
+	driver, err := s.repo.UpdateDriverLocation(ctx, ping)
 	if err != nil {
-		return err
+		return models.Driver{}, err
+	}
+
+	return driver, nil
+}
+
+func validatePing(ping models.LocationPing) error {
+	switch {
+	case ping.Lat < -90 || ping.Lat > 90:
+		return fmt.Errorf("%w: lat %f out of range", ErrInvalidLocation, ping.Lat)
+	case ping.Lon < -180 || ping.Lon > 180:
+		return fmt.Errorf("%w: lon %f out of range", ErrInvalidLocation, ping.Lon)
+	case ping.Heading < 0 || ping.Heading >= 360:
+		return fmt.Errorf("%w: heading %f out of range", ErrInvalidLocation, ping.Heading)
+	case ping.SpeedKmh < 0:
+		return fmt.Errorf("%w: negative speed", ErrInvalidLocation)
+	case ping.Timestamp.After(time.Now().Add(time.Minute)):
+		return fmt.Errorf("%w: timestamp is in the future", ErrInvalidLocation)
+	}
+	return nil
+}
+
+func (s *Service) ReserveDriver(ctx context.Context, driverID, tripID string) (models.Driver, error) {
+	driver, err := s.repo.ReserveDriver(ctx, driverID, tripID)
+	if err != nil {
+		return models.Driver{}, err
 	}
 
-	log.Printf("Driver %s status updated to available: %v, publishing update", id, isAvailable)
-	s.producer.ProduceAvailableDriverUpdate(*driver)
+	log.Printf("Driver %s reserved for trip %s", driverID, tripID)
 
+	return driver, nil
+}
+
+func (s *Service) ReleaseDriver(ctx context.Context, driverID, tripID string) error {
+	if _, err := s.repo.ReleaseDriver(ctx, driverID, tripID); err != nil {
+		return err
+	}
+
+	log.Printf("Driver %s released from trip %s", driverID, tripID)
 	return nil
 }
 
-func (s *Service) FindClosestAvailableDrivers(lat, lon float64) []models.Driver {
-	drivers := s.repo.GetAvailableDrivers()
+// DriverQuery describes a search for available drivers around a point.
+type DriverQuery struct {
+	Lat, Lon     float64
+	RadiusKm     float64
+	Limit        int
+	VehicleTypes []models.VehicleType
+}
+
+const (
+	defaultSearchRadiusKm = 10
+	defaultSearchLimit    = 20
+	maxSearchLimit        = 100
+)
+
+// FindAvailableDrivers returns the available drivers closest to the query point,
+// with their distance and estimated time to reach it.
+func (s *Service) FindAvailableDrivers(ctx context.Context, q DriverQuery) ([]NearbyDriver, error) {
+	if q.RadiusKm <= 0 {
+		q.RadiusKm = defaultSearchRadiusKm
+	}
+	switch {
+	case q.Limit <= 0:
+		q.Limit = defaultSearchLimit
+	case q.Limit > maxSearchLimit:
+		q.Limit = maxSearchLimit
+	}
+
+	return s.repo.FindNearbyAvailableDrivers(ctx, q.Lat, q.Lon, q.RadiusKm, q.Limit, q.VehicleTypes)
+}
+
+func (s *Service) IsDriverAvailable(ctx context.Context, id string) (bool, error) {
+	return s.repo.IsDriverAvailable(ctx, id)
+}
+
+// OfferTrip pushes an offer to the driver and waits for them to accept or
+// decline it, or for the offer to expire.
+func (s *Service) OfferTrip(ctx context.Context, offer models.TripOffer) (models.TripOffer, OfferOutcome, error) {
+	// Only offer trips the driver has been reserved for, so two offers can never
+	// be open for the same driver at once.
+	driver, err := s.repo.GetDriverByID(ctx, offer.DriverID)
+	if err != nil {
+		return models.TripOffer{}, "", err
+	}
+	if driver.TripID != offer.TripID {
+		return models.TripOffer{}, "", ErrDriverUnavailable
+	}
+
+	pending := s.offers.open(offer)
+	log.Printf("Offering trip %s to driver %s until %s", offer.TripID, offer.DriverID, pending.offer.ExpiresAt)
+	s.publishOffer(ctx, types.TripOfferEvent{EventType: types.TripOfferCreatedEvent, Offer: pending.offer})
+
+	outcome := s.offers.wait(ctx, pending)
+	if err := s.repo.RecordOfferOutcome(ctx, offer.DriverID, outcome); err != nil {
+		log.Printf("Failed to record %s offer outcome for driver %s: %v", outcome, offer.DriverID, err)
+	}
+
+	if outcome == OfferExpired || outcome == OfferWithdrawn {
+		s.publishOffer(ctx, types.TripOfferEvent{
+			EventType: types.TripOfferClosedEvent,
+			Offer:     pending.offer,
+			Outcome:   string(outcome),
+		})
+	}
+
+	return pending.offer, outcome, nil
+}
+
+// publishOffer enqueues an offer event. Offers live in memory, so there is no
+// write to make atomic with it; a failure only costs the app a notification.
+func (s *Service) publishOffer(ctx context.Context, event types.TripOfferEvent) {
+	msg, err := tripOfferMessage(ctx, event)
+	if err == nil {
+		// The closing event is sent after the offer's request may have ended.
+		err = s.events.Enqueue(context.WithoutCancel(ctx), msg)
+	}
+	if err != nil {
+		log.Printf("Failed to enqueue %s event for offer %s: %v", event.EventType, event.Offer.ID, err)
+	}
+}
 
-	sort.Slice(drivers, func(i, j int) bool {
-		distI := calculateDistance(lat, lon, drivers[i].Lat, drivers[i].Lon)
-		distJ := calculateDistance(lat, lon, drivers[j].Lat, drivers[j].Lon)
-		return distI < distJ
-	})
+func (s *Service) AcceptTripOffer(offerID, driverID string) (models.TripOffer, error) {
+	offer, err := s.offers.resolve(offerID, driverID, OfferAccepted)
+	if err != nil {
+		return models.TripOffer{}, err
+	}
 
-	return drivers
+	log.Printf("Driver %s accepted offer for trip %s", driverID, offer.TripID)
+	return offer, nil
 }
 
-// calculateDistance calculates the distance between two points on Earth.
-func calculateDistance(lat1, lon1, lat2, lon2 float64) float64 {
-	const R = 6371 // Earth radius in kilometers
-	dLat := (lat2 - lat1) * (math.Pi / 180.0)
-	dLon := (lon2 - lon1) * (math.Pi / 180.0)
-	lat1_rad := lat1 * (math.Pi / 180.0)
-	lat2_rad := lat2 * (math.Pi / 180.0)
-	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
-		math.Sin(dLon/2)*math.Sin(dLon/2)*math.Cos(lat1_rad)*math.Cos(lat2_rad)
-	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
-	return R * c
+func (s *Service) DeclineTripOffer(offerID, driverID, reason string) error {
+	offer, err := s.offers.resolve(offerID, driverID, OfferDeclined)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Driver %s declined offer for trip %s: %q", driverID, offer.TripID, reason)
+	return nil
 }
 
-func (s *Service) IsDriverAvailable(id string) (bool, error) {
-	return s.repo.IsDriverAvailable(id)
+func (s *Service) GetOfferStats(ctx context.Context, driverID string) (OfferStats, error) {
+	return s.repo.GetOfferStats(ctx, driverID)
 }
@@ -0,0 +1,55 @@
+// Package eventbus is how services publish and consume events without
+// depending on a particular broker. Package kafkabus implements it on Kafka;
+// MemoryBus implements it in process for tests and local development.
+package eventbus
+
+import (
+	"context"
+	"time"
+)
+
+type Header struct {
+	Key   string
+	Value []byte
+}
+
+type Message struct {
+	Topic   string
+	Key     string
+	Value   []byte
+	Headers []Header
+	// Where a consumed message was read from. Unset when publishing.
+	Partition int32
+	Offset    int64
+}
+
+// Header returns the value of the named header, or "" if it is missing.
+func (m Message) Header(key string) string {
+	for _, h := range m.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+// Publisher delivers a message and returns once the bus has accepted it.
+// Messages with the same topic and key are delivered in the order published.
+type Publisher interface {
+	Publish(ctx context.Context, msg Message) error
+}
+
+// Handler processes one consumed message.
+type Handler func(ctx context.Context, msg Message) error
+
+// Subscriber delivers messages to a handler, one at a time.
+type Subscriber interface {
+	// Subscribe calls handle for every message on topics until ctx is
+	// cancelled. A message is acknowledged once handle returns nil; if it
+	// returns an error, the message is delivered again after RetryDelay.
+	Subscribe(ctx context.Context, topics []string, handle Handler) error
+}
+
+// RetryDelay is how long subscribers wait before delivering a message again
+// after its handler failed.
+const RetryDelay = time.Second
@@ -0,0 +1,163 @@
+// Package kafkabus implements eventbus on Kafka.
+package kafkabus
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/lukabrx/uber-clone/internal/eventbus"
+)
+
+// BootstrapServersFromEnv returns KAFKA_BOOTSTRAP_SERVERS, or the broker from
+// docker-compose.yml if it is not set.
+func BootstrapServersFromEnv() string {
+	if servers := os.Getenv("KAFKA_BOOTSTRAP_SERVERS"); servers != "" {
+		return servers
+	}
+	return "localhost:29092"
+}
+
+// Publisher produces messages and waits for each delivery report.
+type Publisher struct {
+	producer *kafka.Producer
+}
+
+func NewPublisher(bootstrapServers string) (*Publisher, error) {
+	producer, err := kafka.NewProducer(&kafka.ConfigMap{
+		"bootstrap.servers": bootstrapServers,
+		// Wait for all in-sync replicas, and let the broker drop duplicates
+		// from the producer's own retries.
+		"acks":               "all",
+		"enable.idempotence": true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Publisher{producer: producer}, nil
+}
+
+func (p *Publisher) Publish(ctx context.Context, msg eventbus.Message) error {
+	delivery := make(chan kafka.Event, 1)
+	if err := p.producer.Produce(ToKafka(msg), delivery); err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case e := <-delivery:
+		m, ok := e.(*kafka.Message)
+		if !ok {
+			return fmt.Errorf("unexpected delivery event %v", e)
+		}
+		return m.TopicPartition.Error
+	}
+}
+
+func (p *Publisher) Close() {
+	p.producer.Flush(15 * 1000)
+	p.producer.Close()
+}
+
+// StartOffset is where a consumer group with no committed offset starts.
+type StartOffset string
+
+const (
+	Earliest StartOffset = "earliest"
+	Latest   StartOffset = "latest"
+)
+
+// Subscriber consumes as a member of a consumer group. Offsets are committed
+// only once a message has been handled, so a crash replays it rather than
+// losing it.
+type Subscriber struct {
+	consumer *kafka.Consumer
+}
+
+func NewSubscriber(bootstrapServers, groupID string, start StartOffset) (*Subscriber, error) {
+	consumer, err := kafka.NewConsumer(&kafka.ConfigMap{
+		"bootstrap.servers":  bootstrapServers,
+		"group.id":           groupID,
+		"auto.offset.reset":  string(start),
+		"enable.auto.commit": false,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Subscriber{consumer: consumer}, nil
+}
+
+// Subscribe consumes until ctx is cancelled and then closes the consumer.
+func (s *Subscriber) Subscribe(ctx context.Context, topics []string, handle eventbus.Handler) error {
+	if err := s.consumer.SubscribeTopics(topics, nil); err != nil {
+		return err
+	}
+	defer s.consumer.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		msg, err := s.consumer.ReadMessage(100) // 100ms timeout for reading messages
+		if err != nil {
+			// Ignore timeout errors
+			if kafkaErr, ok := err.(kafka.Error); ok && kafkaErr.Code() == kafka.ErrTimedOut {
+				continue
+			}
+			log.Printf("Consumer error: %v", err)
+			continue
+		}
+
+		if err := handle(ctx, FromKafka(msg)); err != nil {
+			log.Printf("Failed to handle message at %v, reading it again: %v", msg.TopicPartition, err)
+			// Rewind so the same message is read again.
+			if err := s.consumer.Seek(msg.TopicPartition, 0); err != nil {
+				log.Printf("Failed to rewind to %v: %v", msg.TopicPartition, err)
+			}
+			select {
+			case <-ctx.Done():
+			case <-time.After(eventbus.RetryDelay):
+			}
+			continue
+		}
+
+		if _, err := s.consumer.CommitMessage(msg); err != nil {
+			log.Printf("Failed to commit offset %v: %v", msg.TopicPartition, err)
+		}
+	}
+}
+
+func ToKafka(msg eventbus.Message) *kafka.Message {
+	headers := make([]kafka.Header, len(msg.Headers))
+	for i, h := range msg.Headers {
+		headers[i] = kafka.Header{Key: h.Key, Value: h.Value}
+	}
+	return &kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &msg.Topic, Partition: kafka.PartitionAny},
+		Key:            []byte(msg.Key),
+		Value:          msg.Value,
+		Headers:        headers,
+	}
+}
+
+func FromKafka(msg *kafka.Message) eventbus.Message {
+	headers := make([]eventbus.Header, len(msg.Headers))
+	for i, h := range msg.Headers {
+		headers[i] = eventbus.Header{Key: h.Key, Value: h.Value}
+	}
+	return eventbus.Message{
+		Topic:     *msg.TopicPartition.Topic,
+		Key:       string(msg.Key),
+		Value:     msg.Value,
+		Headers:   headers,
+		Partition: msg.TopicPartition.Partition,
+		Offset:    int64(msg.TopicPartition.Offset),
+	}
+}
@@ -0,0 +1,95 @@
+package eventbus
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryBus is an in-process Publisher and Subscriber. It keeps every message
+// for the life of the bus, and every subscription reads its topics from the
+// first message, so a subscription started after a publish still sees it.
+// Subscriptions do not share work the way a Kafka consumer group does; each
+// one gets every message.
+type MemoryBus struct {
+	// RetryDelay overrides eventbus.RetryDelay when set.
+	RetryDelay time.Duration
+
+	mu      sync.Mutex
+	topics  map[string][]Message
+	changed chan struct{}
+}
+
+func NewMemoryBus() *MemoryBus {
+	return &MemoryBus{
+		topics:  make(map[string][]Message),
+		changed: make(chan struct{}),
+	}
+}
+
+func (b *MemoryBus) Publish(ctx context.Context, msg Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	msg.Offset = int64(len(b.topics[msg.Topic]))
+	msg.Partition = 0
+	msg.Headers = append([]Header(nil), msg.Headers...)
+	b.topics[msg.Topic] = append(b.topics[msg.Topic], msg)
+
+	// Wake every subscription waiting for a message.
+	close(b.changed)
+	b.changed = make(chan struct{})
+	return nil
+}
+
+func (b *MemoryBus) Subscribe(ctx context.Context, topics []string, handle Handler) error {
+	retryDelay := b.RetryDelay
+	if retryDelay == 0 {
+		retryDelay = RetryDelay
+	}
+
+	next := make(map[string]int, len(topics))
+	for turn := 0; ; turn++ {
+		msg, ok, changed := b.poll(topics, next, turn)
+		if !ok {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-changed:
+			}
+			continue
+		}
+
+		if err := handle(ctx, msg); err != nil {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(retryDelay):
+			}
+			continue
+		}
+		next[msg.Topic]++
+	}
+}
+
+// poll returns the next unhandled message on topics, starting the search at a
+// different topic each turn so a busy topic does not starve the others. If
+// there is none, it returns a channel that is closed when one is published.
+func (b *MemoryBus) poll(topics []string, next map[string]int, turn int) (Message, bool, <-chan struct{}) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i := range topics {
+		topic := topics[(turn+i)%len(topics)]
+		if msgs := b.topics[topic]; next[topic] < len(msgs) {
+			return msgs[next[topic]], true, nil
+		}
+	}
+	return Message{}, false, b.changed
+}
+
+// Messages returns what has been published on topic so far.
+func (b *MemoryBus) Messages(topic string) []Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]Message(nil), b.topics[topic]...)
+}
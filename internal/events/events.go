@@ -0,0 +1,250 @@
+// Package events converts between the domain types and the events.v1 messages
+// published on trip_events, driver_locations and driver_offers.
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	pb "github.com/lukabrx/uber-clone/api/proto/events/v1"
+	pb_money "github.com/lukabrx/uber-clone/api/proto/money/v1"
+	"github.com/lukabrx/uber-clone/internal/models"
+	"github.com/lukabrx/uber-clone/internal/money"
+	"github.com/lukabrx/uber-clone/internal/types"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Payload schema versions this build produces and understands.
+const (
+	TripEventVersion     = 1
+	DriverUpdatedVersion = 1
+	TripOfferVersion     = 1
+)
+
+// DriverUpdatedType is the envelope type of DriverUpdated events.
+const DriverUpdatedType = "DRIVER_UPDATED"
+
+var (
+	ErrUnexpectedPayload  = errors.New("event has an unexpected payload")
+	ErrUnsupportedVersion = errors.New("event schema version is newer than supported")
+)
+
+// NewTripEvent wraps event in an envelope. A new ID is generated if event has
+// none.
+func NewTripEvent(ctx context.Context, event types.TripEvent) *pb.Envelope {
+	id := event.EventID
+	if id == "" {
+		id = uuid.New().String()
+	}
+	return &pb.Envelope{
+		Id:            id,
+		Type:          string(event.EventType),
+		SchemaVersion: TripEventVersion,
+		OccurredAt:    timestamppb.Now(),
+		Trace:         traceFromContext(ctx),
+		Payload: &pb.Envelope_TripEvent{TripEvent: &pb.TripEvent{
+			TripId:         event.TripID,
+			Version:        event.Version,
+			DriverId:       event.DriverID,
+			Status:         event.Status,
+			PreviousStatus: event.PreviousStatus,
+			CancelledBy:    event.CancelledBy,
+			Reason:         event.Reason,
+			PickupLat:      event.PickupLat,
+			PickupLon:      event.PickupLon,
+		}},
+	}
+}
+
+func NewDriverUpdated(ctx context.Context, driver models.Driver) *pb.Envelope {
+	updated := &pb.DriverUpdated{
+		DriverId:    driver.ID,
+		Name:        driver.Name,
+		VehicleType: string(driver.VehicleType),
+		IsAvailable: driver.IsAvailable,
+		Lat:         driver.Lat,
+		Lon:         driver.Lon,
+		Heading:     driver.Heading,
+		SpeedKmh:    driver.SpeedKmh,
+		TripId:      driver.TripID,
+	}
+	if !driver.LocationUpdatedAt.IsZero() {
+		updated.LocationUpdatedAt = timestamppb.New(driver.LocationUpdatedAt)
+	}
+	return &pb.Envelope{
+		Id:            uuid.New().String(),
+		Type:          DriverUpdatedType,
+		SchemaVersion: DriverUpdatedVersion,
+		OccurredAt:    timestamppb.Now(),
+		Trace:         traceFromContext(ctx),
+		Payload:       &pb.Envelope_DriverUpdated{DriverUpdated: updated},
+	}
+}
+
+// NewTripOffer wraps an offer event; its type is the envelope's type.
+func NewTripOffer(ctx context.Context, event types.TripOfferEvent) *pb.Envelope {
+	offer := event.Offer
+	payload := &pb.TripOffer{
+		OfferId:    offer.ID,
+		DriverId:   offer.DriverID,
+		TripId:     offer.TripID,
+		PickupLat:  offer.PickupLat,
+		PickupLon:  offer.PickupLon,
+		DropoffLat: offer.DropoffLat,
+		DropoffLon: offer.DropoffLon,
+		Price:      &pb_money.Money{Amount: offer.Price.Amount, Currency: offer.Price.Currency},
+		Outcome:    event.Outcome,
+	}
+	if !offer.ExpiresAt.IsZero() {
+		payload.ExpiresAt = timestamppb.New(offer.ExpiresAt)
+	}
+	return &pb.Envelope{
+		Id:            uuid.New().String(),
+		Type:          string(event.EventType),
+		SchemaVersion: TripOfferVersion,
+		OccurredAt:    timestamppb.Now(),
+		Trace:         traceFromContext(ctx),
+		Payload:       &pb.Envelope_TripOffer{TripOffer: payload},
+	}
+}
+
+func Marshal(env *pb.Envelope) ([]byte, error) {
+	return proto.Marshal(env)
+}
+
+func Unmarshal(data []byte) (*pb.Envelope, error) {
+	var env pb.Envelope
+	if err := proto.Unmarshal(data, &env); err != nil {
+		return nil, err
+	}
+	return &env, nil
+}
+
+// TripEvent returns the trip event in env.
+func TripEvent(env *pb.Envelope) (types.TripEvent, error) {
+	event := env.GetTripEvent()
+	if event == nil {
+		return types.TripEvent{}, fmt.Errorf("%w: %s is not a trip event", ErrUnexpectedPayload, env.GetType())
+	}
+	if env.GetSchemaVersion() > TripEventVersion {
+		return types.TripEvent{}, fmt.Errorf("%w: trip event version %d", ErrUnsupportedVersion, env.GetSchemaVersion())
+	}
+	return types.TripEvent{
+		EventID:        env.GetId(),
+		Version:        event.GetVersion(),
+		EventType:      types.EventType(env.GetType()),
+		TripID:         event.GetTripId(),
+		DriverID:       event.GetDriverId(),
+		Status:         event.GetStatus(),
+		PreviousStatus: event.GetPreviousStatus(),
+		CancelledBy:    event.GetCancelledBy(),
+		Reason:         event.GetReason(),
+		PickupLat:      event.GetPickupLat(),
+		PickupLon:      event.GetPickupLon(),
+	}, nil
+}
+
+// Driver returns the driver state in env.
+func Driver(env *pb.Envelope) (models.Driver, error) {
+	updated := env.GetDriverUpdated()
+	if updated == nil {
+		return models.Driver{}, fmt.Errorf("%w: %s is not a driver update", ErrUnexpectedPayload, env.GetType())
+	}
+	if env.GetSchemaVersion() > DriverUpdatedVersion {
+		return models.Driver{}, fmt.Errorf("%w: driver update version %d", ErrUnsupportedVersion, env.GetSchemaVersion())
+	}
+	driver := models.Driver{
+		ID:          updated.GetDriverId(),
+		Name:        updated.GetName(),
+		VehicleType: models.VehicleType(updated.GetVehicleType()),
+		IsAvailable: updated.GetIsAvailable(),
+		Lat:         updated.GetLat(),
+		Lon:         updated.GetLon(),
+		Heading:     updated.GetHeading(),
+		SpeedKmh:    updated.GetSpeedKmh(),
+		TripID:      updated.GetTripId(),
+	}
+	if updated.LocationUpdatedAt != nil {
+		driver.LocationUpdatedAt = updated.GetLocationUpdatedAt().AsTime().In(time.Local)
+	}
+	return driver, nil
+}
+
+// TripOffer returns the offer event in env.
+func TripOffer(env *pb.Envelope) (types.TripOfferEvent, error) {
+	offer := env.GetTripOffer()
+	if offer == nil {
+		return types.TripOfferEvent{}, fmt.Errorf("%w: %s is not a trip offer", ErrUnexpectedPayload, env.GetType())
+	}
+	if env.GetSchemaVersion() > TripOfferVersion {
+		return types.TripOfferEvent{}, fmt.Errorf("%w: trip offer version %d", ErrUnsupportedVersion, env.GetSchemaVersion())
+	}
+	event := types.TripOfferEvent{
+		EventType: types.EventType(env.GetType()),
+		Offer: models.TripOffer{
+			ID:         offer.GetOfferId(),
+			DriverID:   offer.GetDriverId(),
+			TripID:     offer.GetTripId(),
+			PickupLat:  offer.GetPickupLat(),
+			PickupLon:  offer.GetPickupLon(),
+			DropoffLat: offer.GetDropoffLat(),
+			DropoffLon: offer.GetDropoffLon(),
+			Price:      money.New(offer.GetPrice().GetAmount(), offer.GetPrice().GetCurrency()),
+		},
+		Outcome: offer.GetOutcome(),
+	}
+	if offer.ExpiresAt != nil {
+		event.Offer.ExpiresAt = offer.GetExpiresAt().AsTime().In(time.Local)
+	}
+	return event, nil
+}
+
+// DecodeTripEvent unmarshals a trip_events message.
+func DecodeTripEvent(data []byte) (types.TripEvent, error) {
+	env, err := Unmarshal(data)
+	if err != nil {
+		return types.TripEvent{}, err
+	}
+	return TripEvent(env)
+}
+
+// DecodeDriver unmarshals a driver_locations message.
+func DecodeDriver(data []byte) (models.Driver, error) {
+	env, err := Unmarshal(data)
+	if err != nil {
+		return models.Driver{}, err
+	}
+	return Driver(env)
+}
+
+// DecodeTripOffer unmarshals a driver_offers message.
+func DecodeTripOffer(data []byte) (types.TripOfferEvent, error) {
+	env, err := Unmarshal(data)
+	if err != nil {
+		return types.TripOfferEvent{}, err
+	}
+	return TripOffer(env)
+}
+
+// traceFromContext picks up the trace context a caller sent with a gRPC
+// request, if any.
+func traceFromContext(ctx context.Context) *pb.TraceContext {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+	parent := md.Get("traceparent")
+	if len(parent) == 0 {
+		return nil
+	}
+	trace := &pb.TraceContext{Traceparent: parent[0]}
+	if state := md.Get("tracestate"); len(state) > 0 {
+		trace.Tracestate = state[0]
+	}
+	return trace
+}
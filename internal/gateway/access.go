@@ -0,0 +1,112 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	pb_auth "github.com/lukabrx/uber-clone/api/proto/auth/v1"
+	pb_driver "github.com/lukabrx/uber-clone/api/proto/driver/v1"
+	pb_trip "github.com/lukabrx/uber-clone/api/proto/trip/v1"
+	"github.com/lukabrx/uber-clone/internal/jsn"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	errNotDriver      = errors.New("you are not registered as a driver")
+	errNotParticipant = errors.New("you are not the trip's rider or driver")
+	errNotTripDriver  = errors.New("only the trip's driver can change its status")
+)
+
+// authenticateSocket verifies the access token of a WebSocket request and
+// returns the user it belongs to. Browsers cannot set headers on a WebSocket,
+// so the token may also be passed as the token query parameter. On failure
+// the error has been written and ok is false.
+func (h *HttpHandler) authenticateSocket(w http.ResponseWriter, r *http.Request) (userID string, ok bool) {
+	token := r.URL.Query().Get("token")
+	if authHeader := r.Header.Get("Authorization"); authHeader != "" {
+		parts := strings.Split(authHeader, " ")
+		if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+			jsn.ErrorJson(w, errors.New("authorization header format must be Bearer <token>"), http.StatusUnauthorized)
+			return "", false
+		}
+		token = parts[1]
+	}
+	if token == "" {
+		jsn.ErrorJson(w, errors.New("an access token is required"), http.StatusUnauthorized)
+		return "", false
+	}
+	res, err := h.authClient.VerifyToken(r.Context(), &pb_auth.VerifyTokenRequest{Token: token})
+	if err != nil {
+		jsn.ErrorJson(w, errors.New("invalid token: "+err.Error()), http.StatusUnauthorized)
+		return "", false
+	}
+	return res.UserId, true
+}
+
+// driverFor returns the driver the user registered, or errNotDriver.
+func (h *HttpHandler) driverFor(ctx context.Context, userID string) (*pb_driver.Driver, error) {
+	res, err := h.driverClient.GetDriverByUser(ctx, &pb_driver.GetDriverByUserRequest{UserId: userID})
+	if status.Code(err) == codes.NotFound {
+		return nil, errNotDriver
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res.Driver, nil
+}
+
+// participant is how a user takes part in a trip.
+type participant struct {
+	rider bool
+	// driverID is set when the user is the trip's driver.
+	driverID string
+}
+
+// participantIn returns how the user takes part in trip, or errNotParticipant.
+func (h *HttpHandler) participantIn(ctx context.Context, userID string, trip *pb_trip.Trip) (participant, error) {
+	p := participant{rider: userID == trip.RiderId}
+	if trip.DriverId != "" {
+		driver, err := h.driverFor(ctx, userID)
+		switch {
+		case errors.Is(err, errNotDriver):
+		case err != nil:
+			return participant{}, err
+		case driver.Id == trip.DriverId:
+			p.driverID = driver.Id
+		}
+	}
+	if !p.rider && p.driverID == "" {
+		return participant{}, errNotParticipant
+	}
+	return p, nil
+}
+
+// requireTripDriver checks that the user is the driver assigned to the trip.
+// Every status a trip moves through after dispatch, short of a cancellation,
+// is reported by its driver.
+func (h *HttpHandler) requireTripDriver(ctx context.Context, userID, tripID string) error {
+	res, err := h.tripClient.GetTrip(ctx, &pb_trip.GetTripRequest{TripId: tripID})
+	if err != nil {
+		return err
+	}
+	p, err := h.participantIn(ctx, userID, res.Trip)
+	if err != nil {
+		return err
+	}
+	if p.driverID == "" {
+		return errNotTripDriver
+	}
+	return nil
+}
+
+// writeAccessError writes an error from the checks in this file.
+func writeAccessError(w http.ResponseWriter, err error) {
+	if errors.Is(err, errNotDriver) || errors.Is(err, errNotParticipant) || errors.Is(err, errNotTripDriver) {
+		jsn.ErrorJson(w, err, http.StatusForbidden)
+		return
+	}
+	writeGrpcError(w, err)
+}
@@ -0,0 +1,137 @@
+package gateway
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// sendBuffer is how many messages a client may fall behind before it is
+	// evicted.
+	sendBuffer = 64
+	// writeWait bounds every write, so a stuck connection is noticed.
+	writeWait = 10 * time.Second
+	// A client that sends nothing, not even a pong, for pongWait is gone.
+	defaultPongWait = 60 * time.Second
+	// Clients only send pongs and close frames.
+	maxMessageSize = 512
+)
+
+// Client is one WebSocket connection. Messages are queued and written by the
+// client's own goroutine, so a slow client only delays itself; one that falls
+// sendBuffer messages behind is disconnected.
+type Client struct {
+	conn *websocket.Conn
+	send chan []byte
+	// pongWait is how long the client may stay silent; it is pinged a little
+	// more often than that.
+	pongWait time.Duration
+
+	closeOnce sync.Once
+	done      chan struct{}
+	// Set before done is closed.
+	closeCode int
+	closeText string
+	drain     bool
+
+	// topics is guarded by the hub's lock.
+	topics map[Topic]struct{}
+}
+
+func newClient(conn *websocket.Conn, pongWait time.Duration) *Client {
+	return &Client{
+		conn:     conn,
+		send:     make(chan []byte, sendBuffer),
+		pongWait: pongWait,
+		done:     make(chan struct{}),
+		topics:   make(map[Topic]struct{}),
+	}
+}
+
+// enqueue queues data without blocking and evicts the client if its queue is
+// full.
+func (c *Client) enqueue(data []byte) {
+	select {
+	case <-c.done:
+		return
+	default:
+	}
+	select {
+	case c.send <- data:
+	default:
+		log.Printf("Evicting slow client %s", c.conn.RemoteAddr())
+		c.close(websocket.ClosePolicyViolation, "too slow", false)
+		// The writer is likely stuck on a full socket; closing the connection
+		// unblocks it and ends the client's read loop.
+		c.conn.Close()
+	}
+}
+
+// close ends the connection with a close frame. With drain, messages already
+// queued are written first.
+func (c *Client) close(code int, text string, drain bool) {
+	c.closeOnce.Do(func() {
+		c.closeCode, c.closeText, c.drain = code, text, drain
+		close(c.done)
+	})
+}
+
+// writePump writes queued messages and keepalive pings until the client is
+// closed or a write fails.
+func (c *Client) writePump() {
+	ticker := time.NewTicker(c.pongWait * 9 / 10)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case data := <-c.send:
+			if err := c.write(data); err != nil {
+				c.close(websocket.CloseAbnormalClosure, "", false)
+				return
+			}
+
+		case <-ticker.C:
+			if err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				c.close(websocket.CloseAbnormalClosure, "", false)
+				return
+			}
+
+		case <-c.done:
+			for c.drain && len(c.send) > 0 {
+				if err := c.write(<-c.send); err != nil {
+					return
+				}
+			}
+			msg := websocket.FormatCloseMessage(c.closeCode, c.closeText)
+			c.conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(writeWait))
+			return
+		}
+	}
+}
+
+func (c *Client) write(data []byte) error {
+	c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+	return c.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+// readPump reads until the connection fails or goes quiet, keeping it alive on
+// pongs. Clients are not expected to send data; anything they do send is
+// discarded.
+func (c *Client) readPump() {
+	c.conn.SetReadLimit(maxMessageSize)
+	c.conn.SetReadDeadline(time.Now().Add(c.pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(c.pongWait))
+	})
+	for {
+		if _, _, err := c.conn.NextReader(); err != nil {
+			return
+		}
+	}
+}
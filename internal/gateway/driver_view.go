@@ -0,0 +1,232 @@
+package gateway
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"time"
+
+	pb_driver "github.com/lukabrx/uber-clone/api/proto/driver/v1"
+	"github.com/lukabrx/uber-clone/internal/geo"
+	"github.com/lukabrx/uber-clone/internal/models"
+)
+
+// The driver service's search defaults, applied to area queries the same way.
+const (
+	defaultAreaRadiusKm = 10
+	defaultAreaLimit    = 20
+	maxAreaLimit        = 100
+)
+
+// Area subscribers get a snapshot when they connect and then one delta per
+// driver change that affects what they see: the closest drivers in the area, up
+// to the query's limit. A driver who drives out of the area, or is pushed past
+// the limit by closer ones, is reported as unavailable to it.
+const (
+	typeSnapshot          = "snapshot"
+	typeDriverAdded       = "driver_added"
+	typeDriverMoved       = "driver_moved"
+	typeDriverUnavailable = "driver_unavailable"
+)
+
+type areaSnapshotMessage struct {
+	Type    string              `json:"type"`
+	Drivers []*pb_driver.Driver `json:"drivers"`
+}
+
+// driverDeltaMessage carries Driver for driver_added and driver_moved, and
+// only DriverID for driver_unavailable.
+type driverDeltaMessage struct {
+	Type     string            `json:"type"`
+	Driver   *pb_driver.Driver `json:"driver,omitempty"`
+	DriverID string            `json:"driver_id"`
+}
+
+// tombstoneTTL is how long the view remembers that a driver went unavailable.
+// A search of the driver service can only lag behind driver events by so much,
+// so after that it no longer needs protecting from stale snapshots.
+const tombstoneTTL = time.Minute
+
+// driverView is the gateway's copy of every available driver it has heard of,
+// built from driver events. Drivers that go unavailable are dropped, leaving a
+// tombstone for a while so a snapshot taken from the driver service can't
+// bring them back.
+type driverView struct {
+	drivers map[string]models.Driver
+	// gone holds when each recently unavailable driver went unavailable.
+	gone map[string]time.Time
+	// pruned is when expired tombstones were last removed.
+	pruned time.Time
+	now    func() time.Time
+}
+
+func newDriverView() *driverView {
+	return &driverView{
+		drivers: make(map[string]models.Driver),
+		gone:    make(map[string]time.Time),
+		now:     time.Now,
+	}
+}
+
+// update records driver and returns what the view held before.
+func (v *driverView) update(driver models.Driver) (models.Driver, bool) {
+	now := v.now()
+	v.prune(now)
+
+	prev, ok := v.drivers[driver.ID]
+	if driver.IsAvailable {
+		v.drivers[driver.ID] = driver
+		delete(v.gone, driver.ID)
+	} else {
+		delete(v.drivers, driver.ID)
+		v.gone[driver.ID] = now
+	}
+	return prev, ok
+}
+
+// prune drops expired tombstones, at most once per tombstoneTTL, so location
+// pings don't each pay for a scan.
+func (v *driverView) prune(now time.Time) {
+	if now.Sub(v.pruned) < tombstoneTTL {
+		return
+	}
+	for id, at := range v.gone {
+		if now.Sub(at) >= tombstoneTTL {
+			delete(v.gone, id)
+		}
+	}
+	v.pruned = now
+}
+
+// seed adds the drivers of a search result the view has not heard of yet and
+// reports whether there were any. Drivers it already knows are left alone, as
+// their events are more recent, and so are drivers that went unavailable too
+// recently for the search to know.
+func (v *driverView) seed(drivers []*pb_driver.Driver) bool {
+	now := v.now()
+	added := false
+	for _, d := range drivers {
+		if _, ok := v.drivers[d.Id]; ok {
+			continue
+		}
+		if at, ok := v.gone[d.Id]; ok && now.Sub(at) < tombstoneTTL {
+			continue
+		}
+		v.drivers[d.Id] = models.Driver{
+			ID:          d.Id,
+			Name:        d.Name,
+			VehicleType: models.VehicleType(d.VehicleType),
+			IsAvailable: true,
+			Lat:         d.Lat,
+			Lon:         d.Lon,
+			Heading:     d.Heading,
+			SpeedKmh:    d.SpeedKmh,
+		}
+		added = true
+	}
+	return added
+}
+
+// snapshot returns the drivers matching query, closest first, up to its limit.
+// Drivers at the same distance are ordered by ID, so the cut is the same every
+// time.
+func (v *driverView) snapshot(query *pb_driver.FindAvailableDriversRequest) []*pb_driver.Driver {
+	drivers := []*pb_driver.Driver{}
+	for _, d := range v.drivers {
+		if inArea(query, d) {
+			drivers = append(drivers, areaDriver(query, d))
+		}
+	}
+	slices.SortFunc(drivers, func(a, b *pb_driver.Driver) int {
+		return cmp.Or(cmp.Compare(a.DistanceKm, b.DistanceKm), cmp.Compare(a.Id, b.Id))
+	})
+
+	limit := int(query.Limit)
+	switch {
+	case limit <= 0:
+		limit = defaultAreaLimit
+	case limit > maxAreaLimit:
+		limit = maxAreaLimit
+	}
+	if len(drivers) > limit {
+		drivers = drivers[:limit]
+	}
+	return drivers
+}
+
+// area is a driver search clients are subscribed to.
+type area struct {
+	query *pb_driver.FindAvailableDriversRequest
+	// shown holds the drivers subscribers have been told about, which are
+	// those of the view's snapshot for query.
+	shown map[string]bool
+}
+
+// affected reports whether a driver going from prev to current can change
+// what a's subscribers see. known is false for drivers the view had not heard
+// of.
+func (a *area) affected(prev models.Driver, known bool, current models.Driver) bool {
+	return a.shown[current.ID] || (known && inArea(a.query, prev)) || inArea(a.query, current)
+}
+
+// deltas brings shown up to date with the view and returns the messages that
+// tell subscribers: drivers that left first, then those that joined. moved
+// names a driver whose state changed, who is reported as moved if they are
+// still shown.
+func (a *area) deltas(v *driverView, moved string) []driverDeltaMessage {
+	drivers := v.snapshot(a.query)
+	shown := make(map[string]bool, len(drivers))
+	for _, d := range drivers {
+		shown[d.Id] = true
+	}
+
+	var msgs []driverDeltaMessage
+	for _, id := range slices.Sorted(maps.Keys(a.shown)) {
+		if !shown[id] {
+			msgs = append(msgs, driverDeltaMessage{Type: typeDriverUnavailable, DriverID: id})
+		}
+	}
+	for _, d := range drivers {
+		switch {
+		case !a.shown[d.Id]:
+			msgs = append(msgs, driverDeltaMessage{Type: typeDriverAdded, Driver: d, DriverID: d.Id})
+		case d.Id == moved:
+			msgs = append(msgs, driverDeltaMessage{Type: typeDriverMoved, Driver: d, DriverID: d.Id})
+		}
+	}
+	a.shown = shown
+	return msgs
+}
+
+// inArea reports whether the driver service would return driver for query,
+// ignoring the limit.
+func inArea(query *pb_driver.FindAvailableDriversRequest, driver models.Driver) bool {
+	if !driver.IsAvailable {
+		return false
+	}
+	if len(query.VehicleTypes) > 0 && !slices.Contains(query.VehicleTypes, string(driver.VehicleType)) {
+		return false
+	}
+	radiusKm := query.RadiusKm
+	if radiusKm <= 0 {
+		radiusKm = defaultAreaRadiusKm
+	}
+	return geo.DistanceKm(query.Lat, query.Lon, driver.Lat, driver.Lon) <= radiusKm
+}
+
+// areaDriver is driver as a search for query would return it, with its
+// distance and ETA to the query point.
+func areaDriver(query *pb_driver.FindAvailableDriversRequest, driver models.Driver) *pb_driver.Driver {
+	distanceKm := geo.DistanceKm(query.Lat, query.Lon, driver.Lat, driver.Lon)
+	return &pb_driver.Driver{
+		Id:          driver.ID,
+		Name:        driver.Name,
+		Lat:         driver.Lat,
+		Lon:         driver.Lon,
+		Heading:     driver.Heading,
+		SpeedKmh:    driver.SpeedKmh,
+		VehicleType: string(driver.VehicleType),
+		DistanceKm:  distanceKm,
+		EtaSeconds:  int64(geo.EstimateETA(distanceKm).Seconds()),
+	}
+}
@@ -0,0 +1,41 @@
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	pb_driver "github.com/lukabrx/uber-clone/api/proto/driver/v1"
+	"github.com/lukabrx/uber-clone/internal/models"
+)
+
+func TestDriverViewForgetsUnavailableDrivers(t *testing.T) {
+	now := time.Now()
+	v := newDriverView()
+	v.now = func() time.Time { return now }
+	query := &pb_driver.FindAvailableDriversRequest{Lat: 44.81, Lon: 20.46}
+	stale := []*pb_driver.Driver{{Id: "driver-1", Lat: 44.81, Lon: 20.46}}
+
+	v.update(models.Driver{ID: "driver-1", IsAvailable: true, Lat: 44.81, Lon: 20.46})
+	v.update(models.Driver{ID: "driver-1", IsAvailable: false, Lat: 44.81, Lon: 20.46})
+	if len(v.drivers) != 0 {
+		t.Fatalf("view holds %d drivers, want the unavailable one dropped", len(v.drivers))
+	}
+
+	// A search that has not caught up yet must not bring the driver back.
+	v.seed(stale)
+	if got := v.snapshot(query); len(got) != 0 {
+		t.Fatalf("snapshot = %v, want no drivers while the tombstone is fresh", got)
+	}
+
+	// Once the tombstone has expired, the search is trusted again and the
+	// tombstone is pruned by the next update.
+	now = now.Add(tombstoneTTL)
+	v.seed(stale)
+	if got := v.snapshot(query); len(got) != 1 {
+		t.Fatalf("snapshot = %v, want the seeded driver", got)
+	}
+	v.update(models.Driver{ID: "driver-2", IsAvailable: true})
+	if len(v.gone) != 0 {
+		t.Fatalf("view holds %d tombstones, want the expired one pruned", len(v.gone))
+	}
+}
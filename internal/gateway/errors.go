@@ -0,0 +1,36 @@
+package gateway
+
+import (
+	"net/http"
+
+	"github.com/lukabrx/uber-clone/internal/jsn"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// grpcHTTPStatus maps the status code of a gRPC error to the closest HTTP status.
+func grpcHTTPStatus(err error) int {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.FailedPrecondition, codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// writeGrpcError writes err as a JSON error using only the gRPC status message.
+func writeGrpcError(w http.ResponseWriter, err error) {
+	jsn.WriteJson(w, grpcHTTPStatus(err), map[string]string{"error": status.Convert(err).Message()})
+}
@@ -0,0 +1,63 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/lukabrx/uber-clone/internal/dlq"
+	"github.com/lukabrx/uber-clone/internal/eventbus"
+	"github.com/lukabrx/uber-clone/internal/events"
+	"github.com/lukabrx/uber-clone/internal/types"
+)
+
+// EventConsumer pushes driver updates and offers to connected clients.
+// Messages are acknowledged once they have been handled or dead-lettered.
+type EventConsumer struct {
+	subscriber  eventbus.Subscriber
+	hub         *Hub
+	deadLetters *dlq.Processor
+}
+
+func NewEventConsumer(subscriber eventbus.Subscriber, hub *Hub, deadLetters *dlq.Processor) *EventConsumer {
+	return &EventConsumer{subscriber: subscriber, hub: hub, deadLetters: deadLetters}
+}
+
+func (c *EventConsumer) SubscribeAndListen(ctx context.Context) {
+	log.Println("Gateway consumer subscribed and listening for driver location updates and offers...")
+	go func() {
+		topics := []string{types.DriverLocationTopic, types.DriverOffersTopic}
+		if err := c.subscriber.Subscribe(ctx, topics, c.deadLetters.Handler(c.handle)); err != nil {
+			log.Fatalf("Failed to subscribe to topic: %v", err)
+		}
+		log.Println("Stopping gateway event consumer.")
+	}()
+}
+
+func (c *EventConsumer) handle(ctx context.Context, msg eventbus.Message) error {
+	if msg.Topic == types.DriverOffersTopic {
+		return c.handleTripOffer(msg.Value)
+	}
+
+	log.Printf("Received driver location update from topic %s", msg.Topic)
+
+	driver, err := events.DecodeDriver(msg.Value)
+	if err != nil {
+		return dlq.Permanent(fmt.Errorf("could not decode driver update: %w", err))
+	}
+
+	c.hub.DriverChanged(driver)
+	return nil
+}
+
+// handleTripOffer forwards an offer event to the driver it was made to.
+func (c *EventConsumer) handleTripOffer(value []byte) error {
+	event, err := events.DecodeTripOffer(value)
+	if err != nil {
+		return dlq.Permanent(fmt.Errorf("could not decode trip offer event: %w", err))
+	}
+
+	log.Printf("Forwarding %s for trip %s to driver %s", event.EventType, event.Offer.TripID, event.Offer.DriverID)
+	c.hub.SendToDriver(event.Offer.DriverID, event)
+	return nil
+}
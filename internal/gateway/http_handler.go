@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
@@ -58,6 +59,10 @@ func (h *HttpHandler) RegisterDriver(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	// The driver belongs to the authenticated user, whatever the body says.
+	if userID, ok := r.Context().Value(UserIDKey).(string); ok {
+		req.UserId = userID
+	}
 	res, err := h.driverClient.RegisterDriver(r.Context(), &req)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -67,29 +72,143 @@ func (h *HttpHandler) RegisterDriver(w http.ResponseWriter, r *http.Request) {
 	jsn.WriteJson(w, http.StatusCreated, res.Driver)
 }
 
+// UpdateDriverLocation records a ping from the authenticated user's own driver.
+func (h *HttpHandler) UpdateDriverLocation(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(UserIDKey).(string)
+	if !ok {
+		jsn.ErrorJson(w, errors.New("user ID not found in context"), http.StatusInternalServerError)
+		return
+	}
+
+	var req pb_driver.UpdateLocationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		jsn.ErrorJson(w, err, http.StatusBadRequest)
+		return
+	}
+	req.DriverId = chi.URLParam(r, "id")
+
+	driver, err := h.driverFor(r.Context(), userID)
+	if err != nil {
+		writeAccessError(w, err)
+		return
+	}
+	if driver.Id != req.DriverId {
+		jsn.ErrorJson(w, errors.New("you can only report your own driver's location"), http.StatusForbidden)
+		return
+	}
+
+	res, err := h.driverClient.UpdateLocation(r.Context(), &req)
+	if err != nil {
+		writeGrpcError(w, err)
+		return
+	}
+
+	jsn.WriteJson(w, http.StatusOK, res.Driver)
+}
+
 func (h *HttpHandler) FindAvailableDrivers(w http.ResponseWriter, r *http.Request) {
-	lat, _ := strconv.ParseFloat(r.URL.Query().Get("lat"), 64)
-	lon, _ := strconv.ParseFloat(r.URL.Query().Get("lon"), 64)
+	req, err := parseDriverQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
-	req := &pb_driver.FindAvailableDriversRequest{Lat: lat, Lon: lon}
 	res, err := h.driverClient.FindAvailableDrivers(r.Context(), req)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeGrpcError(w, err)
 		return
 	}
 
 	jsn.WriteJson(w, http.StatusOK, res.Drivers)
 }
 
+// parseDriverQuery reads lat, lon, radius_km, limit and vehicle_type from the
+// query string. vehicle_type may be repeated or comma separated.
+func parseDriverQuery(r *http.Request) (*pb_driver.FindAvailableDriversRequest, error) {
+	q := r.URL.Query()
+	lat, _ := strconv.ParseFloat(q.Get("lat"), 64)
+	lon, _ := strconv.ParseFloat(q.Get("lon"), 64)
+	req := &pb_driver.FindAvailableDriversRequest{Lat: lat, Lon: lon}
+
+	if v := q.Get("radius_km"); v != "" {
+		radius, err := strconv.ParseFloat(v, 64)
+		if err != nil || radius < 0 {
+			return nil, errors.New("radius_km must be a non-negative number")
+		}
+		req.RadiusKm = radius
+	}
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 0 {
+			return nil, errors.New("limit must be a non-negative integer")
+		}
+		req.Limit = int32(limit)
+	}
+	for _, v := range q["vehicle_type"] {
+		for _, vt := range strings.Split(v, ",") {
+			if vt = strings.TrimSpace(vt); vt != "" {
+				req.VehicleTypes = append(req.VehicleTypes, vt)
+			}
+		}
+	}
+	return req, nil
+}
+
+// GetSurge reports the surge multiplier riders would pay at ?lat=&lon=.
+func (h *HttpHandler) GetSurge(w http.ResponseWriter, r *http.Request) {
+	lat, err := strconv.ParseFloat(r.URL.Query().Get("lat"), 64)
+	if err != nil {
+		jsn.ErrorJson(w, errors.New("lat is required"), http.StatusBadRequest)
+		return
+	}
+	lon, err := strconv.ParseFloat(r.URL.Query().Get("lon"), 64)
+	if err != nil {
+		jsn.ErrorJson(w, errors.New("lon is required"), http.StatusBadRequest)
+		return
+	}
+
+	res, err := h.tripClient.GetSurge(r.Context(), &pb_trip.GetSurgeRequest{Lat: lat, Lon: lon})
+	if err != nil {
+		writeGrpcError(w, err)
+		return
+	}
+
+	jsn.WriteJson(w, http.StatusOK, res)
+}
+
+// CreateFareQuote prices a trip for the authenticated rider before booking it.
+func (h *HttpHandler) CreateFareQuote(w http.ResponseWriter, r *http.Request) {
+	var req pb_trip.GetFareQuoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// Quotes are bound to the authenticated rider so they can't be shared.
+	if userID, ok := r.Context().Value(UserIDKey).(string); ok {
+		req.RiderId = userID
+	}
+	res, err := h.tripClient.GetFareQuote(r.Context(), &req)
+	if err != nil {
+		writeGrpcError(w, err)
+		return
+	}
+
+	jsn.WriteJson(w, http.StatusCreated, res)
+}
+
 func (h *HttpHandler) CreateTrip(w http.ResponseWriter, r *http.Request) {
 	var req pb_trip.CreateTripRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	// The rider is always the authenticated user, whatever the body says.
+	if userID, ok := r.Context().Value(UserIDKey).(string); ok {
+		req.RiderId = userID
+	}
 	res, err := h.tripClient.CreateTrip(r.Context(), &req)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeGrpcError(w, err)
 		return
 	}
 
@@ -103,51 +222,386 @@ func (h *HttpHandler) CompleteTrip(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	userID, ok := r.Context().Value(UserIDKey).(string)
+	if !ok {
+		jsn.ErrorJson(w, errors.New("user ID not found in context"), http.StatusInternalServerError)
+		return
+	}
+	if err := h.requireTripDriver(r.Context(), userID, tripID); err != nil {
+		writeAccessError(w, err)
+		return
+	}
+
 	req := &pb_trip.CompleteTripRequest{TripId: tripID}
 	res, err := h.tripClient.CompleteTrip(r.Context(), req)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeGrpcError(w, err)
 		return
 	}
 
 	jsn.WriteJson(w, http.StatusOK, res.Trip)
 }
 
-func (h *HttpHandler) StreamAvailableDrivers(w http.ResponseWriter, r *http.Request) {
-	conn, err := upgrader.Upgrade(w, r, nil)
+func (h *HttpHandler) UpdateTripStatus(w http.ResponseWriter, r *http.Request) {
+	tripID := chi.URLParam(r, "id")
+	if tripID == "" {
+		jsn.ErrorJson(w, errors.New("trip_id is required in the URL path"), http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := r.Context().Value(UserIDKey).(string)
+	if !ok {
+		jsn.ErrorJson(w, errors.New("user ID not found in context"), http.StatusInternalServerError)
+		return
+	}
+
+	var body struct {
+		Status string `json:"status"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		jsn.ErrorJson(w, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := h.requireTripDriver(r.Context(), userID, tripID); err != nil {
+		writeAccessError(w, err)
+		return
+	}
+
+	req := &pb_trip.UpdateTripStatusRequest{TripId: tripID, Status: body.Status}
+	res, err := h.tripClient.UpdateTripStatus(r.Context(), req)
 	if err != nil {
-		log.Println("upgrade error:", err)
+		writeGrpcError(w, err)
 		return
 	}
-	defer conn.Close()
 
-	h.hub.AddClient(conn)
-	defer h.hub.RemoveClient(conn)
+	jsn.WriteJson(w, http.StatusOK, res.Trip)
+}
+
+// CancelTrip cancels a trip on behalf of its rider or its driver. Who cancelled
+// decides the fee, so it follows from the authenticated user, and
+// cancelled_by only picks a side for a user who is both.
+func (h *HttpHandler) CancelTrip(w http.ResponseWriter, r *http.Request) {
+	tripID := chi.URLParam(r, "id")
+	if tripID == "" {
+		jsn.ErrorJson(w, errors.New("trip_id is required in the URL path"), http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := r.Context().Value(UserIDKey).(string)
+	if !ok {
+		jsn.ErrorJson(w, errors.New("user ID not found in context"), http.StatusInternalServerError)
+		return
+	}
+
+	var body struct {
+		CancelledBy string `json:"cancelled_by"`
+		Reason      string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		jsn.ErrorJson(w, err, http.StatusBadRequest)
+		return
+	}
+	if body.CancelledBy != "" && body.CancelledBy != "rider" && body.CancelledBy != "driver" {
+		jsn.ErrorJson(w, errors.New("cancelled_by must be rider or driver"), http.StatusBadRequest)
+		return
+	}
+
+	trip, err := h.tripClient.GetTrip(r.Context(), &pb_trip.GetTripRequest{TripId: tripID})
+	if err != nil {
+		writeGrpcError(w, err)
+		return
+	}
+	p, err := h.participantIn(r.Context(), userID, trip.Trip)
+	if err != nil {
+		writeAccessError(w, err)
+		return
+	}
+
+	req := &pb_trip.CancelTripRequest{TripId: tripID, Reason: body.Reason}
+	switch {
+	case p.rider && body.CancelledBy != "driver":
+		req.CancelledBy = "rider"
+		req.CancellerId = userID
+	case p.driverID != "" && body.CancelledBy != "rider":
+		req.CancelledBy = "driver"
+		req.CancellerId = p.driverID
+	default:
+		jsn.ErrorJson(w, fmt.Errorf("you are not the trip's %s", body.CancelledBy), http.StatusForbidden)
+		return
+	}
+
+	res, err := h.tripClient.CancelTrip(r.Context(), req)
+	if err != nil {
+		writeGrpcError(w, err)
+		return
+	}
+
+	jsn.WriteJson(w, http.StatusOK, res.Trip)
+}
+
+func (h *HttpHandler) GetTrip(w http.ResponseWriter, r *http.Request) {
+	tripID := chi.URLParam(r, "id")
+	if tripID == "" {
+		jsn.ErrorJson(w, errors.New("trip_id is required in the URL path"), http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := r.Context().Value(UserIDKey).(string)
+	if !ok {
+		jsn.ErrorJson(w, errors.New("user ID not found in context"), http.StatusInternalServerError)
+		return
+	}
 
-	lat, _ := strconv.ParseFloat(r.URL.Query().Get("lat"), 64)
-	lon, _ := strconv.ParseFloat(r.URL.Query().Get("lon"), 64)
-	res, err := h.driverClient.FindAvailableDrivers(
-		r.Context(),
-		&pb_driver.FindAvailableDriversRequest{Lat: lat, Lon: lon},
-	)
+	res, err := h.tripClient.GetTrip(r.Context(), &pb_trip.GetTripRequest{TripId: tripID})
 	if err != nil {
-		log.Println("initial find available drivers error:", err)
-	} else {
-		if err := conn.WriteJSON(res.Drivers); err != nil {
-			log.Println("initial write json error:", err)
+		writeGrpcError(w, err)
+		return
+	}
+
+	// Users only see trips they rode or drove; report others as missing rather
+	// than forbidden.
+	if _, err := h.participantIn(r.Context(), userID, res.Trip); errors.Is(err, errNotParticipant) {
+		jsn.ErrorJson(w, errors.New("trip not found"), http.StatusNotFound)
+		return
+	} else if err != nil {
+		writeGrpcError(w, err)
+		return
+	}
+
+	jsn.WriteJson(w, http.StatusOK, res.Trip)
+}
+
+// ListMyTrips lists the trips the user rode, or with as=driver the trips they
+// drove. from and to are RFC3339 timestamps; fractions of a second are dropped.
+func (h *HttpHandler) ListMyTrips(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(UserIDKey).(string)
+	if !ok {
+		jsn.ErrorJson(w, errors.New("user ID not found in context"), http.StatusInternalServerError)
+		return
+	}
+
+	query := r.URL.Query()
+	req := &pb_trip.ListTripsRequest{
+		Status:    query.Get("status"),
+		PageToken: query.Get("page_token"),
+	}
+	switch query.Get("as") {
+	case "", "rider":
+		req.RiderId = userID
+	case "driver":
+		driver, err := h.driverFor(r.Context(), userID)
+		if err != nil {
+			writeAccessError(w, err)
 			return
 		}
+		req.DriverId = driver.Id
+	default:
+		jsn.ErrorJson(w, errors.New(`as must be "rider" or "driver"`), http.StatusBadRequest)
+		return
 	}
 
-	// Keep the connection open and listen for new messages
-	for {
-		msgType, msg, err := conn.NextReader()
-		log.Println("receive message type:", msgType)
-		log.Println("receive message:", msg)
+	if v := query.Get("page_size"); v != "" {
+		pageSize, err := strconv.Atoi(v)
 		if err != nil {
-			break
+			jsn.ErrorJson(w, errors.New("page_size must be an integer"), http.StatusBadRequest)
+			return
 		}
+		req.PageSize = int32(pageSize)
+	}
+	if v := query.Get("from"); v != "" {
+		from, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			jsn.ErrorJson(w, errors.New("from must be an RFC3339 timestamp"), http.StatusBadRequest)
+			return
+		}
+		req.From = from.Unix()
+	}
+	if v := query.Get("to"); v != "" {
+		to, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			jsn.ErrorJson(w, errors.New("to must be an RFC3339 timestamp"), http.StatusBadRequest)
+			return
+		}
+		req.To = to.Unix()
+	}
+
+	res, err := h.tripClient.ListTrips(r.Context(), req)
+	if err != nil {
+		writeGrpcError(w, err)
+		return
+	}
+
+	trips := res.Trips
+	if trips == nil {
+		trips = []*pb_trip.Trip{}
+	}
+	jsn.WriteJson(w, http.StatusOK, map[string]any{
+		"trips":           trips,
+		"next_page_token": res.NextPageToken,
+	})
+}
+
+// StreamAvailableDrivers sends a snapshot of the available drivers matching
+// the query, then driver_added, driver_moved and driver_unavailable messages as
+// they change.
+func (h *HttpHandler) StreamAvailableDrivers(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Println("upgrade error:", err)
+		return
+	}
+
+	req, err := parseDriverQuery(r)
+	if err != nil {
+		conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseUnsupportedData, err.Error()))
+		conn.Close()
+		return
+	}
+
+	client := h.hub.Register(conn)
+	defer h.hub.Unregister(client)
+	h.hub.SubscribeArea(r.Context(), client, req)
+	h.hub.Listen(client)
+}
+
+// StreamTripOffers pushes trip offers to a single driver's app. Only the
+// driver's own user may listen, as offers include the rider's pickup and
+// dropoff.
+func (h *HttpHandler) StreamTripOffers(w http.ResponseWriter, r *http.Request) {
+	driverID := chi.URLParam(r, "id")
+	if driverID == "" {
+		http.Error(w, "driver id is required in the URL path", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := h.authenticateSocket(w, r)
+	if !ok {
+		return
+	}
+	driver, err := h.driverFor(r.Context(), userID)
+	if err != nil {
+		writeAccessError(w, err)
+		return
+	}
+	if driver.Id != driverID {
+		jsn.ErrorJson(w, errors.New("you can only follow your own offers"), http.StatusForbidden)
+		return
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Println("upgrade error:", err)
+		return
 	}
+
+	client := h.hub.Register(conn)
+	defer h.hub.Unregister(client)
+	h.hub.SubscribeDriver(client, driverID)
+
+	// Keep the connection open until the driver app goes away.
+	h.hub.Listen(client)
+}
+
+// StreamTrip lets a trip's rider or driver follow it: status changes, the
+// driver's location and the ETA.
+func (h *HttpHandler) StreamTrip(w http.ResponseWriter, r *http.Request) {
+	tripID := chi.URLParam(r, "id")
+	if tripID == "" {
+		jsn.ErrorJson(w, errors.New("trip_id is required in the URL path"), http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := h.authenticateSocket(w, r)
+	if !ok {
+		return
+	}
+
+	res, err := h.tripClient.GetTrip(r.Context(), &pb_trip.GetTripRequest{TripId: tripID})
+	if err != nil {
+		writeGrpcError(w, err)
+		return
+	}
+	// Report trips the user is not part of as missing rather than forbidden.
+	if _, err := h.participantIn(r.Context(), userID, res.Trip); errors.Is(err, errNotParticipant) {
+		jsn.ErrorJson(w, errors.New("trip not found"), http.StatusNotFound)
+		return
+	} else if err != nil {
+		writeGrpcError(w, err)
+		return
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Println("upgrade error:", err)
+		return
+	}
+
+	client := h.hub.Register(conn)
+	defer h.hub.Unregister(client)
+	h.hub.SubscribeTrip(client, tripID)
+
+	h.hub.Listen(client)
+}
+
+// AcceptTripOffer accepts an offer made to the authenticated user's driver.
+func (h *HttpHandler) AcceptTripOffer(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(UserIDKey).(string)
+	if !ok {
+		jsn.ErrorJson(w, errors.New("user ID not found in context"), http.StatusInternalServerError)
+		return
+	}
+	driver, err := h.driverFor(r.Context(), userID)
+	if err != nil {
+		writeAccessError(w, err)
+		return
+	}
+
+	res, err := h.driverClient.AcceptTripOffer(r.Context(), &pb_driver.AcceptTripOfferRequest{
+		OfferId:  chi.URLParam(r, "id"),
+		DriverId: driver.Id,
+	})
+	if err != nil {
+		writeGrpcError(w, err)
+		return
+	}
+
+	jsn.WriteJson(w, http.StatusOK, res.Offer)
+}
+
+// DeclineTripOffer declines an offer made to the authenticated user's driver.
+func (h *HttpHandler) DeclineTripOffer(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(UserIDKey).(string)
+	if !ok {
+		jsn.ErrorJson(w, errors.New("user ID not found in context"), http.StatusInternalServerError)
+		return
+	}
+
+	var body struct {
+		Reason string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		jsn.ErrorJson(w, err, http.StatusBadRequest)
+		return
+	}
+
+	driver, err := h.driverFor(r.Context(), userID)
+	if err != nil {
+		writeAccessError(w, err)
+		return
+	}
+
+	_, err = h.driverClient.DeclineTripOffer(r.Context(), &pb_driver.DeclineTripOfferRequest{
+		OfferId:  chi.URLParam(r, "id"),
+		DriverId: driver.Id,
+		Reason:   body.Reason,
+	})
+	if err != nil {
+		writeGrpcError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
 }
 
 func (h *HttpHandler) HandleGoogleLogin(w http.ResponseWriter, r *http.Request) {
@@ -192,6 +646,17 @@ const UserIDKey contextKey = "userID"
 
 func (h *HttpHandler) AuthMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Browsers cannot set headers on a WebSocket handshake, so sockets may
+		// pass the token as a query parameter instead.
+		if websocket.IsWebSocketUpgrade(r) {
+			userID, ok := h.authenticateSocket(w, r)
+			if !ok {
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), UserIDKey, userID)))
+			return
+		}
+
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
 			jsn.ErrorJson(w, errors.New("authorization header is required"), http.StatusUnauthorized)
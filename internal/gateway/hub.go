@@ -1,50 +1,337 @@
 package gateway
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"log"
+	"math"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 	pb_driver "github.com/lukabrx/uber-clone/api/proto/driver/v1"
+	pb_trip "github.com/lukabrx/uber-clone/api/proto/trip/v1"
+	"github.com/lukabrx/uber-clone/internal/models"
 )
 
+type TopicKind string
+
+const (
+	// TopicArea carries the available drivers matching one driver search.
+	TopicArea TopicKind = "area"
+	// TopicTrip carries one trip's status changes and driver locations.
+	TopicTrip TopicKind = "trip"
+	// TopicDriver carries the trip offers made to one driver.
+	TopicDriver TopicKind = "driver"
+)
+
+// Topic is something a client can subscribe to.
+type Topic struct {
+	Kind TopicKind
+	ID   string
+}
+
+// AreaTopic identifies the area of a driver search. Searches that normalize
+// to the same query share a topic.
+func AreaTopic(query *pb_driver.FindAvailableDriversRequest) Topic {
+	q := normalizeArea(query)
+	return Topic{Kind: TopicArea, ID: fmt.Sprintf("%.3f,%.3f/%.1fkm/%d/%s",
+		q.Lat, q.Lon, q.RadiusKm, q.Limit, strings.Join(q.VehicleTypes, ","))}
+}
+
+// normalizeArea rounds a driver search to about 100 m, and its radius to
+// 100 m, and sorts its vehicle types, so that clients looking at the same
+// place share one area instead of each getting their own.
+func normalizeArea(query *pb_driver.FindAvailableDriversRequest) *pb_driver.FindAvailableDriversRequest {
+	vehicleTypes := slices.Clone(query.GetVehicleTypes())
+	slices.Sort(vehicleTypes)
+	return &pb_driver.FindAvailableDriversRequest{
+		Lat:          math.Round(query.GetLat()*1000) / 1000,
+		Lon:          math.Round(query.GetLon()*1000) / 1000,
+		RadiusKm:     math.Round(query.GetRadiusKm()*10) / 10,
+		Limit:        query.GetLimit(),
+		VehicleTypes: slices.Compact(vehicleTypes),
+	}
+}
+
+func TripTopic(tripID string) Topic { return Topic{Kind: TopicTrip, ID: tripID} }
+
+func DriverTopic(driverID string) Topic { return Topic{Kind: TopicDriver, ID: driverID} }
+
+// tripUpdateMessage is what trip subscribers receive for each update. Type is
+// "trip" or "driver_location", naming the field that is set.
+type tripUpdateMessage struct {
+	Type           string                  `json:"type"`
+	Trip           *pb_trip.Trip           `json:"trip,omitempty"`
+	DriverLocation *pb_trip.DriverLocation `json:"driver_location,omitempty"`
+	EtaSeconds     int64                   `json:"eta_seconds"`
+}
+
+// tripWatch is the one WatchTrip stream shared by a trip's subscribers. The
+// latest trip and location messages are kept for clients that join later.
+type tripWatch struct {
+	cancel   context.CancelFunc
+	trip     []byte
+	location []byte
+}
+
+// Hub routes updates to the clients subscribed to them. Publishing only
+// queues messages, so no client can hold up the others.
 type Hub struct {
-	clients      map[*websocket.Conn]bool
-	mu           sync.Mutex
+	mu     sync.Mutex
+	topics map[Topic]map[*Client]struct{}
+	// areas holds the driver search behind each area topic.
+	areas   map[Topic]*area
+	drivers *driverView
+	trips   map[string]*tripWatch
+
 	driverClient pb_driver.DriverServiceClient
+	tripClient   pb_trip.TripServiceClient
+	// pongWait is how long a client may go without answering a ping.
+	pongWait time.Duration
 }
 
-func NewHub(driverClient pb_driver.DriverServiceClient) *Hub {
+func NewHub(driverClient pb_driver.DriverServiceClient, tripClient pb_trip.TripServiceClient) *Hub {
 	return &Hub{
-		clients:      make(map[*websocket.Conn]bool),
+		topics:       make(map[Topic]map[*Client]struct{}),
+		areas:        make(map[Topic]*area),
+		drivers:      newDriverView(),
+		trips:        make(map[string]*tripWatch),
 		driverClient: driverClient,
+		tripClient:   tripClient,
+		pongWait:     defaultPongWait,
 	}
 }
 
-func (h *Hub) AddClient(conn *websocket.Conn) {
+// Register starts writing to conn. The caller subscribes the client, runs
+// Listen and then calls Unregister.
+func (h *Hub) Register(conn *websocket.Conn) *Client {
+	c := newClient(conn, h.pongWait)
+	go c.writePump()
+	return c
+}
+
+// Listen blocks until the client disconnects or stops answering pings.
+func (h *Hub) Listen(c *Client) {
+	c.readPump()
+}
+
+// Unregister removes the client from all its topics and closes it.
+func (h *Hub) Unregister(c *Client) {
 	h.mu.Lock()
-	h.clients[conn] = true
+	for topic := range c.topics {
+		h.unsubscribe(c, topic)
+	}
 	h.mu.Unlock()
-	log.Printf("Client added. Total clients: %d", len(h.clients))
+	c.close(websocket.CloseNormalClosure, "", false)
+	log.Printf("Client %s disconnected", c.conn.RemoteAddr())
 }
 
-func (h *Hub) RemoveClient(conn *websocket.Conn) {
+// SubscribeArea subscribes c to the available drivers matching query, once
+// normalized like AreaTopic does, and sends it a snapshot of them. The driver service is searched first to fill in
+// drivers the hub has not heard from since it started; if that fails, the
+// snapshot could be missing drivers, so c is closed instead.
+func (h *Hub) SubscribeArea(ctx context.Context, c *Client, query *pb_driver.FindAvailableDriversRequest) {
+	query = normalizeArea(query)
+	res, err := h.driverClient.FindAvailableDrivers(ctx, query)
+	if err != nil {
+		log.Printf("Failed to find available drivers for %v: %v", query, err)
+		c.close(websocket.CloseTryAgainLater, "driver search unavailable", false)
+		return
+	}
+
+	topic := AreaTopic(query)
 	h.mu.Lock()
-	delete(h.clients, conn)
-	h.mu.Unlock()
-	log.Printf("Client removed. Total clients: %d", len(h.clients))
+	defer h.mu.Unlock()
+	// Drivers new to the view can change what existing areas show.
+	if h.drivers.seed(res.GetDrivers()) {
+		for t, a := range h.areas {
+			h.publishDeltas(t, a.deltas(h.drivers, ""))
+		}
+	}
+	if _, ok := h.areas[topic]; !ok {
+		a := &area{query: query}
+		a.deltas(h.drivers, "")
+		h.areas[topic] = a
+	}
+	h.subscribe(c, topic)
+
+	// Taken under the lock, so the snapshot and the deltas that follow it line
+	// up.
+	data, err := json.Marshal(areaSnapshotMessage{Type: typeSnapshot, Drivers: h.drivers.snapshot(query)})
+	if err != nil {
+		log.Printf("Failed to encode driver snapshot for %v: %v", query, err)
+		return
+	}
+	c.enqueue(data)
+}
+
+// SubscribeDriver subscribes c to the offers made to a driver.
+func (h *Hub) SubscribeDriver(c *Client, driverID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.subscribe(c, DriverTopic(driverID))
+}
+
+// SubscribeTrip subscribes c to a trip's updates. The first subscriber starts
+// watching the trip; later ones get the latest state straight away.
+func (h *Hub) SubscribeTrip(c *Client, tripID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.subscribe(c, TripTopic(tripID))
+
+	watch, ok := h.trips[tripID]
+	if !ok {
+		ctx, cancel := context.WithCancel(context.Background())
+		watch = &tripWatch{cancel: cancel}
+		h.trips[tripID] = watch
+		go h.watchTrip(ctx, tripID, watch)
+		return
+	}
+	if watch.trip != nil {
+		c.enqueue(watch.trip)
+	}
+	if watch.location != nil {
+		c.enqueue(watch.location)
+	}
+}
+
+// subscribe must be called with h.mu held.
+func (h *Hub) subscribe(c *Client, topic Topic) {
+	if h.topics[topic] == nil {
+		h.topics[topic] = make(map[*Client]struct{})
+	}
+	h.topics[topic][c] = struct{}{}
+	c.topics[topic] = struct{}{}
+	log.Printf("Client %s subscribed to %s %s. Subscribers: %d", c.conn.RemoteAddr(), topic.Kind, topic.ID, len(h.topics[topic]))
 }
 
-func (h *Hub) Broadcast(drivers []*pb_driver.Driver) {
+// unsubscribe must be called with h.mu held. Topics nobody follows any more
+// are forgotten, and their trip watch stopped.
+func (h *Hub) unsubscribe(c *Client, topic Topic) {
+	delete(h.topics[topic], c)
+	delete(c.topics, topic)
+	if len(h.topics[topic]) > 0 {
+		return
+	}
+	delete(h.topics, topic)
+	switch topic.Kind {
+	case TopicArea:
+		delete(h.areas, topic)
+	case TopicTrip:
+		if watch, ok := h.trips[topic.ID]; ok {
+			watch.cancel()
+			delete(h.trips, topic.ID)
+		}
+	}
+}
+
+// Publish sends v to every client subscribed to topic.
+func (h *Hub) Publish(topic Topic, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("Failed to encode message for %s %s: %v", topic.Kind, topic.ID, err)
+		return
+	}
 	h.mu.Lock()
 	defer h.mu.Unlock()
+	h.publish(topic, data)
+}
+
+// publish must be called with h.mu held.
+func (h *Hub) publish(topic Topic, data []byte) {
+	for c := range h.topics[topic] {
+		c.enqueue(data)
+	}
+}
+
+// SendToDriver sends v to every socket the driver has open.
+func (h *Hub) SendToDriver(driverID string, v any) {
+	h.Publish(DriverTopic(driverID), v)
+}
 
-	for client := range h.clients {
-		if err := client.WriteJSON(drivers); err != nil {
-			log.Printf("Error broadcasting to client: %v", err)
-			// On error, assume the client has disconnected and remove them.
-			client.Close()
-			delete(h.clients, client)
+// DriverChanged records a driver's latest state and tells each area it
+// entered, moved within or left.
+func (h *Hub) DriverChanged(driver models.Driver) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	prev, known := h.drivers.update(driver)
+	for topic, a := range h.areas {
+		if a.affected(prev, known, driver) {
+			h.publishDeltas(topic, a.deltas(h.drivers, driver.ID))
 		}
 	}
 }
+
+// publishDeltas must be called with h.mu held.
+func (h *Hub) publishDeltas(topic Topic, msgs []driverDeltaMessage) {
+	for _, msg := range msgs {
+		data, err := json.Marshal(msg)
+		if err != nil {
+			log.Printf("Failed to encode %s for driver %s: %v", msg.Type, msg.DriverID, err)
+			continue
+		}
+		h.publish(topic, data)
+	}
+}
+
+// watchTrip relays a trip's updates to its subscribers until the trip ends or
+// the last subscriber leaves, which cancels ctx.
+func (h *Hub) watchTrip(ctx context.Context, tripID string, watch *tripWatch) {
+	topic := TripTopic(tripID)
+	stream, err := h.tripClient.WatchTrip(ctx, &pb_trip.WatchTripRequest{TripId: tripID})
+	for err == nil {
+		var update *pb_trip.TripUpdate
+		update, err = stream.Recv()
+		if err != nil {
+			break
+		}
+
+		msg := tripUpdateMessage{EtaSeconds: update.EtaSeconds}
+		switch u := update.Update.(type) {
+		case *pb_trip.TripUpdate_Trip:
+			msg.Type, msg.Trip = "trip", u.Trip
+		case *pb_trip.TripUpdate_DriverLocation:
+			msg.Type, msg.DriverLocation = "driver_location", u.DriverLocation
+		default:
+			continue
+		}
+		data, encodeErr := json.Marshal(msg)
+		if encodeErr != nil {
+			log.Printf("Failed to encode update for trip %s: %v", tripID, encodeErr)
+			continue
+		}
+
+		h.mu.Lock()
+		if msg.Trip != nil {
+			watch.trip = data
+		} else {
+			watch.location = data
+		}
+		h.publish(topic, data)
+		h.mu.Unlock()
+	}
+	if ctx.Err() != nil {
+		return
+	}
+
+	code, text := websocket.CloseNormalClosure, "trip ended"
+	if err != io.EOF {
+		log.Printf("Watching trip %s failed: %v", tripID, err)
+		code, text = websocket.CloseTryAgainLater, "trip updates interrupted"
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for c := range h.topics[topic] {
+		c.close(code, text, true)
+	}
+	if h.trips[tripID] == watch {
+		delete(h.trips, tripID)
+	}
+}
@@ -0,0 +1,298 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	pb_driver "github.com/lukabrx/uber-clone/api/proto/driver/v1"
+	"github.com/lukabrx/uber-clone/internal/models"
+	"google.golang.org/grpc"
+)
+
+// connect serves one WebSocket for hub and dials it. subscribe runs before
+// the client is listened to; connect returns once it has.
+func connect(t *testing.T, hub *Hub, subscribe func(*Client)) *websocket.Conn {
+	t.Helper()
+	var upgrader websocket.Upgrader
+	subscribed := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		c := hub.Register(conn)
+		defer hub.Unregister(c)
+		subscribe(c)
+		close(subscribed)
+		hub.Listen(c)
+	}))
+	t.Cleanup(srv.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	select {
+	case <-subscribed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("client was not subscribed in time")
+	}
+	return conn
+}
+
+// readText reads the next message from conn, failing after wait.
+func readText(t *testing.T, conn *websocket.Conn, wait time.Duration) (string, error) {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(wait))
+	_, data, err := conn.ReadMessage()
+	return string(data), err
+}
+
+func subscribers(hub *Hub) int {
+	hub.mu.Lock()
+	defer hub.mu.Unlock()
+	n := 0
+	for _, clients := range hub.topics {
+		n += len(clients)
+	}
+	return n
+}
+
+func TestPublishRoutesByTopic(t *testing.T) {
+	hub := NewHub(nil, nil)
+	marko := connect(t, hub, func(c *Client) { hub.SubscribeDriver(c, "marko") })
+	ana := connect(t, hub, func(c *Client) { hub.SubscribeDriver(c, "ana") })
+
+	hub.SendToDriver("marko", "offer for marko")
+	hub.SendToDriver("ana", "offer for ana")
+	hub.SendToDriver("nobody", "offer for nobody")
+
+	for conn, want := range map[*websocket.Conn]string{marko: `"offer for marko"`, ana: `"offer for ana"`} {
+		got, err := readText(t, conn, 5*time.Second)
+		if err != nil || got != want {
+			t.Fatalf("read %q, %v; want %q", got, err, want)
+		}
+		if got, err := readText(t, conn, 100*time.Millisecond); err == nil {
+			t.Fatalf("read %q, want nothing else", got)
+		}
+	}
+}
+
+func TestSlowClientEvicted(t *testing.T) {
+	hub := NewHub(nil, nil)
+	fast := connect(t, hub, func(c *Client) { hub.SubscribeDriver(c, "marko") })
+
+	// A client whose writer never runs falls behind as soon as its queue is
+	// full. It wraps the dialing end, which nothing else writes to.
+	slow := newClient(connect(t, hub, func(*Client) {}), hub.pongWait)
+	hub.SubscribeDriver(slow, "marko")
+
+	for range sendBuffer {
+		hub.SendToDriver("marko", "offer")
+	}
+	select {
+	case <-slow.done:
+		t.Fatal("client evicted with a full but not overflowing queue")
+	default:
+	}
+	for range sendBuffer {
+		if _, err := readText(t, fast, 5*time.Second); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	hub.SendToDriver("marko", "one too many")
+	select {
+	case <-slow.done:
+	default:
+		t.Fatal("client not evicted once its queue overflowed")
+	}
+	if slow.closeCode != websocket.ClosePolicyViolation {
+		t.Errorf("close code = %d, want %d", slow.closeCode, websocket.ClosePolicyViolation)
+	}
+	// Other subscribers keep getting messages.
+	if got, err := readText(t, fast, 5*time.Second); err != nil || got != `"one too many"` {
+		t.Fatalf("fast client read %q, %v", got, err)
+	}
+}
+
+func TestPingPong(t *testing.T) {
+	hub := NewHub(nil, nil)
+	hub.pongWait = 200 * time.Millisecond
+
+	// Reading answers pings, which keeps the client connected well past
+	// pongWait.
+	alive := connect(t, hub, func(c *Client) { hub.SubscribeDriver(c, "marko") })
+	received := make(chan error, 1)
+	go func() {
+		alive.SetReadDeadline(time.Now().Add(10 * time.Second))
+		for {
+			_, _, err := alive.ReadMessage()
+			received <- err
+			if err != nil {
+				return
+			}
+		}
+	}()
+	time.Sleep(5 * hub.pongWait)
+	hub.SendToDriver("marko", "still there")
+	if err := <-received; err != nil {
+		t.Fatalf("client answering pings was disconnected: %v", err)
+	}
+
+	// A client that stops answering is dropped.
+	silent := connect(t, hub, func(c *Client) { hub.SubscribeDriver(c, "ana") })
+	silent.SetPingHandler(func(string) error { return nil })
+	if got, err := readText(t, silent, 5*time.Second); err == nil {
+		t.Fatalf("read %q, want the silent client disconnected", got)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for subscribers(hub) != 1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d subscribers left, want only the live client", subscribers(hub))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// failingSearch is a driver service whose searches fail.
+type failingSearch struct {
+	pb_driver.DriverServiceClient
+}
+
+func (failingSearch) FindAvailableDrivers(context.Context, *pb_driver.FindAvailableDriversRequest, ...grpc.CallOption) (*pb_driver.FindAvailableDriversResponse, error) {
+	return nil, errors.New("driver service unavailable")
+}
+
+// fixedSearch is a driver service whose searches return the same drivers.
+type fixedSearch struct {
+	pb_driver.DriverServiceClient
+	drivers []*pb_driver.Driver
+}
+
+func (s fixedSearch) FindAvailableDrivers(context.Context, *pb_driver.FindAvailableDriversRequest, ...grpc.CallOption) (*pb_driver.FindAvailableDriversResponse, error) {
+	return &pb_driver.FindAvailableDriversResponse{Drivers: s.drivers}, nil
+}
+
+// readArea reads messages until the next snapshot or delta, returning its type
+// and the IDs of the drivers in it.
+func readArea(t *testing.T, conn *websocket.Conn) (string, []string) {
+	t.Helper()
+	text, err := readText(t, conn, 5*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var msg struct {
+		Type     string              `json:"type"`
+		DriverID string              `json:"driver_id"`
+		Drivers  []*pb_driver.Driver `json:"drivers"`
+	}
+	if err := json.Unmarshal([]byte(text), &msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg.Type != typeSnapshot {
+		return msg.Type, []string{msg.DriverID}
+	}
+	ids := []string{}
+	for _, d := range msg.Drivers {
+		ids = append(ids, d.Id)
+	}
+	return msg.Type, ids
+}
+
+func TestAreaDeltasRespectLimit(t *testing.T) {
+	// Drivers east of the pickup, each a little further away.
+	driverAt := func(id string, lon float64) models.Driver {
+		return models.Driver{ID: id, IsAvailable: true, VehicleType: models.VehicleEconomy, Lat: 44.81, Lon: lon}
+	}
+	hub := NewHub(fixedSearch{drivers: []*pb_driver.Driver{
+		{Id: "near", Lat: 44.81, Lon: 20.461, VehicleType: "economy"},
+		{Id: "middle", Lat: 44.81, Lon: 20.462, VehicleType: "economy"},
+		{Id: "far", Lat: 44.81, Lon: 20.463, VehicleType: "economy"},
+	}}, nil)
+	query := &pb_driver.FindAvailableDriversRequest{Lat: 44.81, Lon: 20.46, Limit: 2}
+	conn := connect(t, hub, func(c *Client) { hub.SubscribeArea(context.Background(), c, query) })
+
+	// Each step's messages must be the next ones read, so a step that should
+	// send nothing is caught by the step after it.
+	steps := []struct {
+		name   string
+		change models.Driver
+		want   [][]string
+	}{
+		// The driver past the limit can move without anyone being told.
+		{"far driver moves", driverAt("far", 20.464), nil},
+		{"near driver moves", driverAt("near", 20.4605), [][]string{{typeDriverMoved, "near"}}},
+		{"far driver overtakes", driverAt("far", 20.4601), [][]string{{typeDriverUnavailable, "middle"}, {typeDriverAdded, "far"}}},
+		{"shown driver leaves", models.Driver{ID: "near"}, [][]string{{typeDriverUnavailable, "near"}, {typeDriverAdded, "middle"}}},
+		{"new driver past the limit", driverAt("new", 20.47), nil},
+		{"shown driver falls behind", driverAt("far", 20.48), [][]string{{typeDriverUnavailable, "far"}, {typeDriverAdded, "new"}}},
+	}
+
+	if typ, ids := readArea(t, conn); typ != typeSnapshot || !slices.Equal(ids, []string{"near", "middle"}) {
+		t.Fatalf("first message %s %v, want a snapshot of near and middle", typ, ids)
+	}
+	for _, step := range steps {
+		hub.DriverChanged(step.change)
+		for _, want := range step.want {
+			if typ, ids := readArea(t, conn); typ != want[0] || ids[0] != want[1] {
+				t.Fatalf("%s: got %s %s, want %s %s", step.name, typ, ids[0], want[0], want[1])
+			}
+		}
+	}
+	if got, err := readText(t, conn, 100*time.Millisecond); err == nil {
+		t.Fatalf("got %s, want nothing more", got)
+	}
+}
+
+func TestSubscribeAreaClosesWhenSearchFails(t *testing.T) {
+	hub := NewHub(failingSearch{}, nil)
+	conn := connect(t, hub, func(c *Client) {
+		hub.SubscribeArea(context.Background(), c, &pb_driver.FindAvailableDriversRequest{Lat: 44.81, Lon: 20.46})
+	})
+
+	got, err := readText(t, conn, 5*time.Second)
+	if !websocket.IsCloseError(err, websocket.CloseTryAgainLater) {
+		t.Fatalf("read %q, %v; want the connection closed with try again later", got, err)
+	}
+	if n := subscribers(hub); n != 0 {
+		t.Fatalf("hub has %d subscribers, want the client not subscribed", n)
+	}
+}
+
+func TestAreaTopic(t *testing.T) {
+	base := &pb_driver.FindAvailableDriversRequest{Lat: 44.8125, Lon: 20.4612, RadiusKm: 5, Limit: 20, VehicleTypes: []string{"comfort", "economy"}}
+	same := []*pb_driver.FindAvailableDriversRequest{
+		{Lat: 44.8125, Lon: 20.4612, RadiusKm: 5, Limit: 20, VehicleTypes: []string{"economy", "comfort"}},
+		{Lat: 44.81251, Lon: 20.46119, RadiusKm: 5.01, Limit: 20, VehicleTypes: []string{"comfort", "economy"}},
+		{Lat: 44.8125, Lon: 20.4612, RadiusKm: 5, Limit: 20, VehicleTypes: []string{"economy", "comfort", "economy"}},
+	}
+	different := []*pb_driver.FindAvailableDriversRequest{
+		{Lat: 44.8135, Lon: 20.4612, RadiusKm: 5, Limit: 20, VehicleTypes: []string{"comfort", "economy"}},
+		{Lat: 44.8125, Lon: 20.4612, RadiusKm: 6, Limit: 20, VehicleTypes: []string{"comfort", "economy"}},
+		{Lat: 44.8125, Lon: 20.4612, RadiusKm: 5, Limit: 10, VehicleTypes: []string{"comfort", "economy"}},
+		{Lat: 44.8125, Lon: 20.4612, RadiusKm: 5, Limit: 20, VehicleTypes: []string{"economy"}},
+		{Lat: 44.8125, Lon: 20.4612, RadiusKm: 5, Limit: 20},
+	}
+
+	want := AreaTopic(base)
+	for _, q := range same {
+		if got := AreaTopic(q); got != want {
+			t.Errorf("AreaTopic(%v) = %v, want %v like %v", q, got, want, base)
+		}
+	}
+	for _, q := range different {
+		if got := AreaTopic(q); got == want {
+			t.Errorf("AreaTopic(%v) = %v, the same as for %v", q, got, base)
+		}
+	}
+}
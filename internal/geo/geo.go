@@ -0,0 +1,58 @@
+package geo
+
+import (
+	"math"
+	"time"
+)
+
+const EarthRadiusKm = 6371
+
+// KmPerDegreeLat is the length of one degree of latitude.
+const KmPerDegreeLat = 111.32
+
+// DistanceKm calculates the great-circle distance between two points on Earth.
+func DistanceKm(lat1, lon1, lat2, lon2 float64) float64 {
+	dLat := (lat2 - lat1) * (math.Pi / 180.0)
+	dLon := (lon2 - lon1) * (math.Pi / 180.0)
+	lat1Rad := lat1 * (math.Pi / 180.0)
+	lat2Rad := lat2 * (math.Pi / 180.0)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Sin(dLon/2)*math.Sin(dLon/2)*math.Cos(lat1Rad)*math.Cos(lat2Rad)
+	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+	return EarthRadiusKm * c
+}
+
+// Cell identifies a square of a fixed-size lat/lon grid.
+type Cell struct {
+	Row int
+	Col int
+}
+
+// CellOf returns the grid cell, with sides of sizeDeg degrees, containing the point.
+func CellOf(lat, lon, sizeDeg float64) Cell {
+	return Cell{
+		Row: int(math.Floor((lat + 90) / sizeDeg)),
+		Col: int(math.Floor((lon + 180) / sizeDeg)),
+	}
+}
+
+// KmPerDegreeLon is the length of one degree of longitude at the given latitude.
+// It is clamped near the poles so callers can safely divide by it.
+func KmPerDegreeLon(lat float64) float64 {
+	return KmPerDegreeLat * math.Max(math.Cos(lat*math.Pi/180), 0.01)
+}
+
+const (
+	// Straight-line distance understates the road distance by roughly this much.
+	roadDistanceFactor  = 1.3
+	averageCitySpeedKmh = 30
+	minimumETA          = time.Minute
+)
+
+// EstimateETA guesses how long a driver takes to cover a straight-line distance
+// in city traffic.
+func EstimateETA(distanceKm float64) time.Duration {
+	hours := distanceKm * roadDistanceFactor / averageCitySpeedKmh
+	eta := time.Duration(hours * float64(time.Hour)).Round(time.Second)
+	return max(eta, minimumETA)
+}
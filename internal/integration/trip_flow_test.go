@@ -0,0 +1,247 @@
+// Package integration_test runs the driver, trip and gateway services in one
+// process, joined by the in-memory event bus, and follows a trip from request
+// to drop-off.
+package integration_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	pb_driver "github.com/lukabrx/uber-clone/api/proto/driver/v1"
+	"github.com/lukabrx/uber-clone/internal/auth"
+	"github.com/lukabrx/uber-clone/internal/dlq"
+	"github.com/lukabrx/uber-clone/internal/driver"
+	"github.com/lukabrx/uber-clone/internal/eventbus"
+	"github.com/lukabrx/uber-clone/internal/events"
+	"github.com/lukabrx/uber-clone/internal/gateway"
+	"github.com/lukabrx/uber-clone/internal/models"
+	"github.com/lukabrx/uber-clone/internal/outbox"
+	pricecalculator "github.com/lukabrx/uber-clone/internal/price_calculator"
+	"github.com/lukabrx/uber-clone/internal/trip"
+	"github.com/lukabrx/uber-clone/internal/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// waitFor bounds every wait in the test.
+const waitFor = 10 * time.Second
+
+func TestTripFlow(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	bus := eventbus.NewMemoryBus()
+	bus.RetryDelay = 10 * time.Millisecond
+	relayConfig := outbox.DefaultRelayConfig
+	relayConfig.PollInterval = 10 * time.Millisecond
+
+	// Driver service, reached over gRPC like in production.
+	driverEvents := outbox.NewMemoryStore()
+	go outbox.NewRelay(driverEvents, bus, relayConfig).Run(ctx)
+	drivers := driver.NewService(driver.NewMemoryRepository(driverEvents), driverEvents, driver.NewOfferManager(5*time.Second))
+	driver.NewEventConsumer(bus, drivers,
+		driver.NewMemoryProcessedEvents(driver.DefaultProcessedEventsRetention),
+		dlq.NewProcessor(bus, "driver_service_group", dlq.DefaultRetryPolicy),
+	).SubscribeAndListen(ctx)
+	driverClient := pb_driver.NewDriverServiceClient(serve(t, func(s *grpc.Server) {
+		pb_driver.RegisterDriverServiceServer(s, driver.NewGrpcHandler(drivers))
+	}))
+
+	// Trip service.
+	tripEvents := outbox.NewMemoryStore()
+	go outbox.NewRelay(tripEvents, bus, relayConfig).Run(ctx)
+	rateCards, err := pricecalculator.NewRateCardStore("../../configs/rate_cards.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	quoteMaker, err := auth.NewPasetoMaker(strings.Repeat("k", 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dispatcher := trip.NewDispatcher(driverClient, trip.NewDriverServiceOffers(driverClient), trip.DispatchConfig{
+		SearchRadiusKm: 10,
+		MaxCandidates:  1,
+		OfferTimeout:   waitFor,
+	})
+	pricing := pricecalculator.NewCalculator(pricecalculator.DefaultHaversineRouter, rateCards,
+		pricecalculator.NewSurgeEngine(pricecalculator.DefaultSurgeConfig))
+	trips := trip.NewService(trip.NewMemoryRepository(tripEvents), driverClient, dispatcher, pricing,
+		trip.NewQuotes(quoteMaker, trip.DefaultQuoteValidity))
+
+	// Gateway.
+	hub := gateway.NewHub(driverClient, nil)
+	gateway.NewEventConsumer(bus, hub, dlq.NewProcessor(bus, "gateway_group", dlq.DefaultRetryPolicy)).SubscribeAndListen(ctx)
+
+	d, err := drivers.RegisterDriver(ctx, models.Driver{Name: "Marko", UserID: "user-1", Lat: 44.8125, Lon: 20.4612})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The driver's app follows its offers and the drivers around it; the
+	// snapshot confirms both subscriptions are in place.
+	app := socket(t, hub, func(c *gateway.Client) {
+		hub.SubscribeDriver(c, d.ID)
+		hub.SubscribeArea(ctx, c, &pb_driver.FindAvailableDriversRequest{Lat: 44.81, Lon: 20.46})
+	})
+	snapshot := read(t, app, func(m message) bool { return m.Type == "snapshot" })
+	if len(snapshot.Drivers) != 1 || snapshot.Drivers[0].Id != d.ID {
+		t.Fatalf("snapshot = %+v, want driver %s", snapshot.Drivers, d.ID)
+	}
+
+	created, err := trips.CreateTrip(ctx, models.Trip{
+		RiderID:  "rider-1",
+		StartLat: 44.81, StartLon: 20.46,
+		EndLat: 44.79, EndLon: 20.47,
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Reserving the driver for the offer takes them off the map.
+	var offer models.TripOffer
+	var busy bool
+	read(t, app, func(m message) bool {
+		switch {
+		case m.EventType == types.TripOfferCreatedEvent:
+			offer = m.Offer
+		case m.Type == "driver_unavailable" && m.DriverID == d.ID:
+			busy = true
+		}
+		return offer.ID != "" && busy
+	})
+	if offer.TripID != created.ID || offer.DriverID != d.ID {
+		t.Fatalf("offer = %+v, want trip %s for driver %s", offer, created.ID, d.ID)
+	}
+
+	if _, err := driverClient.AcceptTripOffer(ctx, &pb_driver.AcceptTripOfferRequest{OfferId: offer.ID, DriverId: d.ID}); err != nil {
+		t.Fatal(err)
+	}
+	eventually(t, func() bool {
+		tr, err := trips.GetTrip(ctx, created.ID)
+		return err == nil && tr.Status == models.TripStatusDriverAssigned && tr.DriverID == d.ID
+	})
+
+	for _, status := range []models.TripStatus{
+		models.TripStatusDriverArriving,
+		models.TripStatusInProgress,
+		models.TripStatusCompleted,
+	} {
+		if _, err := trips.UpdateTripStatus(ctx, created.ID, status); err != nil {
+			t.Fatalf("UpdateTripStatus(%s): %v", status, err)
+		}
+	}
+
+	// Dropping the rider off frees the driver for the next trip.
+	read(t, app, func(m message) bool { return m.Type == "driver_added" && m.DriverID == d.ID })
+
+	want := []types.EventType{
+		types.TripCreatedEvent,
+		types.TripDriverAssignedEvent,
+		types.TripDriverArrivingEvent,
+		types.TripStartedEvent,
+		types.TripCompletedEvent,
+	}
+	eventually(t, func() bool { return len(bus.Messages(types.TripEventsTopic)) == len(want) })
+	for i, msg := range bus.Messages(types.TripEventsTopic) {
+		event, err := events.DecodeTripEvent(msg.Value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if event.EventType != want[i] || event.TripID != created.ID {
+			t.Fatalf("trip event %d is %s for trip %s, want %s for trip %s", i, event.EventType, event.TripID, want[i], created.ID)
+		}
+	}
+}
+
+// serve starts a gRPC server on an in-memory listener and returns a
+// connection to it.
+func serve(t *testing.T, register func(*grpc.Server)) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	register(s)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// socket connects a WebSocket client to hub, which subscribe sets up the way
+// a gateway handler would.
+func socket(t *testing.T, hub *gateway.Hub, subscribe func(*gateway.Client)) *websocket.Conn {
+	t.Helper()
+	var upgrader websocket.Upgrader
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		c := hub.Register(conn)
+		subscribe(c)
+		hub.Listen(c)
+		hub.Unregister(c)
+	}))
+	t.Cleanup(srv.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// message is any of the messages the gateway pushes to a client.
+type message struct {
+	// Area updates.
+	Type     string              `json:"type"`
+	DriverID string              `json:"driver_id"`
+	Drivers  []*pb_driver.Driver `json:"drivers"`
+	// Offer events.
+	EventType types.EventType  `json:"event_type"`
+	Offer     models.TripOffer `json:"offer"`
+}
+
+// read reads messages from conn until done returns true for one, and returns
+// that one.
+func read(t *testing.T, conn *websocket.Conn, done func(message) bool) message {
+	t.Helper()
+	if err := conn.SetReadDeadline(time.Now().Add(waitFor)); err != nil {
+		t.Fatal(err)
+	}
+	for {
+		var m message
+		if err := conn.ReadJSON(&m); err != nil {
+			t.Fatalf("waiting for a message: %v", err)
+		}
+		if done(m) {
+			return m
+		}
+	}
+}
+
+func eventually(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(waitFor)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -1,32 +1,118 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/lukabrx/uber-clone/internal/money"
+)
+
+type VehicleType string
+
+const (
+	VehicleEconomy VehicleType = "economy"
+	VehicleComfort VehicleType = "comfort"
+	VehicleXL      VehicleType = "xl"
+)
 
 type Driver struct {
-	ID          string  `json:"id"`
-	Name        string  `json:"name"`
-	IsAvailable bool    `json:"is_available"`
-	Lat         float64 `json:"lat"`
-	Lon         float64 `json:"lon"`
+	ID                string      `json:"id"`
+	Name              string      `json:"name"`
+	VehicleType       VehicleType `json:"vehicle_type"`
+	IsAvailable       bool        `json:"is_available"`
+	Lat               float64     `json:"lat"`
+	Lon               float64     `json:"lon"`
+	Heading           float64     `json:"heading"` // degrees clockwise from north
+	SpeedKmh          float64     `json:"speed_kmh"`
+	LocationUpdatedAt time.Time   `json:"location_updated_at"`
+	TripID            string      `json:"trip_id,omitempty"` // trip the driver is reserved for
+	UserID            string      `json:"user_id,omitempty"` // user account the driver signs in with
+}
+
+// LocationPing is a single position report from a driver's app.
+type LocationPing struct {
+	DriverID  string    `json:"driver_id"`
+	Lat       float64   `json:"lat"`
+	Lon       float64   `json:"lon"`
+	Heading   float64   `json:"heading"`
+	SpeedKmh  float64   `json:"speed_kmh"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// TripOffer is a trip proposed to a single driver, who has until ExpiresAt to
+// accept or decline it.
+type TripOffer struct {
+	ID         string      `json:"id"`
+	DriverID   string      `json:"driver_id"`
+	TripID     string      `json:"trip_id"`
+	PickupLat  float64     `json:"pickup_lat"`
+	PickupLon  float64     `json:"pickup_lon"`
+	DropoffLat float64     `json:"dropoff_lat"`
+	DropoffLon float64     `json:"dropoff_lon"`
+	Price      money.Money `json:"price"`
+	ExpiresAt  time.Time   `json:"expires_at"`
 }
 
 type TripStatus string
 
 const (
-	TripStatusRequested  TripStatus = "requested"
-	TripStatusInProgress TripStatus = "in_progress"
-	TripStatusCompleted  TripStatus = "completed"
+	TripStatusRequested      TripStatus = "requested"
+	TripStatusDriverAssigned TripStatus = "driver_assigned"
+	TripStatusDriverArriving TripStatus = "driver_arriving"
+	TripStatusInProgress     TripStatus = "in_progress"
+	TripStatusCompleted      TripStatus = "completed"
+	TripStatusCancelled      TripStatus = "cancelled"
+	TripStatusNoShow         TripStatus = "no_show"
+)
+
+type CancellationParty string
+
+const (
+	CancelledByRider  CancellationParty = "rider"
+	CancelledByDriver CancellationParty = "driver"
+	CancelledBySystem CancellationParty = "system"
 )
 
 type Trip struct {
-	ID          string     `json:"id"`
-	RiderID     string     `json:"rider_id"`
-	DriverID    string     `json:"driver_id,omitempty"`
-	StartLat    float64    `json:"start_lat"`
-	StartLon    float64    `json:"start_lon"`
-	EndLat      float64    `json:"end_lat"`
-	EndLon      float64    `json:"end_lon"`
-	Status      TripStatus `json:"status"`
-	Price       float64    `json:"price,omitempty"`
-	RequestTime time.Time  `json:"request_time"`
+	ID              string      `json:"id"`
+	RiderID         string      `json:"rider_id"`
+	DriverID        string      `json:"driver_id,omitempty"`
+	StartLat        float64     `json:"start_lat"`
+	StartLon        float64     `json:"start_lon"`
+	EndLat          float64     `json:"end_lat"`
+	EndLon          float64     `json:"end_lon"`
+	VehicleType     VehicleType `json:"vehicle_type"`
+	Status          TripStatus  `json:"status"`
+	Price           money.Money `json:"price"` // upfront estimate the rider booked at
+	SurgeMultiplier float64     `json:"surge_multiplier,omitempty"`
+	RequestTime     time.Time   `json:"request_time"`
+	UpdatedAt       time.Time   `json:"updated_at"`
+	Version         int64       `json:"version"` // bumped by every status change
+
+	// Set as the trip starts and completes. FinalPrice is what the rider is
+	// charged, worked out from the DistanceKm actually driven.
+	StartedAt   time.Time   `json:"started_at,omitempty"`
+	CompletedAt time.Time   `json:"completed_at,omitempty"`
+	DistanceKm  float64     `json:"distance_km,omitempty"`
+	FinalPrice  money.Money `json:"final_price"`
+
+	CancelledBy        CancellationParty `json:"cancelled_by,omitempty"`
+	CancellerID        string            `json:"canceller_id,omitempty"`
+	CancellationReason string            `json:"cancellation_reason,omitempty"`
+	CancellationFee    money.Money       `json:"cancellation_fee"`
+	CancelledAt        time.Time         `json:"cancelled_at,omitempty"`
+}
+
+// TracePoint is a driver position recorded while a trip is in progress.
+type TracePoint struct {
+	Lat        float64   `json:"lat"`
+	Lon        float64   `json:"lon"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// TripStatusChange records a single transition in a trip's lifecycle.
+type TripStatusChange struct {
+	TripID    string     `json:"trip_id"`
+	From      TripStatus `json:"from"`
+	To        TripStatus `json:"to"`
+	ChangedAt time.Time  `json:"changed_at"`
 }
@@ -0,0 +1,35 @@
+package money
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrUnknownCurrency = errors.New("unknown currency")
+
+// Currency describes an ISO 4217 currency.
+type Currency struct {
+	Code string
+	// Exponent is the number of minor-unit digits: 2 for USD cents, 0 for JPY.
+	Exponent int
+	Symbol   string
+}
+
+var currencies = map[string]Currency{
+	"USD": {Code: "USD", Exponent: 2, Symbol: "$"},
+	"EUR": {Code: "EUR", Exponent: 2, Symbol: "€"},
+	"GBP": {Code: "GBP", Exponent: 2, Symbol: "£"},
+	"CHF": {Code: "CHF", Exponent: 2, Symbol: "CHF "},
+	"RSD": {Code: "RSD", Exponent: 2, Symbol: "RSD "},
+	"JPY": {Code: "JPY", Exponent: 0, Symbol: "¥"},
+	"KWD": {Code: "KWD", Exponent: 3, Symbol: "KWD "},
+}
+
+// LookupCurrency returns the currency with the given ISO code.
+func LookupCurrency(code string) (Currency, error) {
+	c, ok := currencies[strings.ToUpper(code)]
+	if !ok {
+		return Currency{}, ErrUnknownCurrency
+	}
+	return c, nil
+}
@@ -0,0 +1,153 @@
+// Package money represents amounts as integer minor units of an ISO 4217
+// currency, so prices are never subject to float rounding errors.
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var ErrCurrencyMismatch = errors.New("currency mismatch")
+
+// RoundingMode decides how fractional minor units are rounded.
+type RoundingMode int
+
+const (
+	// HalfUp rounds to the nearest minor unit, halves away from zero.
+	HalfUp RoundingMode = iota
+	// HalfEven rounds to the nearest minor unit, halves to the even neighbour.
+	HalfEven
+	// Down truncates towards zero.
+	Down
+	// Up rounds away from zero.
+	Up
+)
+
+// Money is an amount in minor units (e.g. cents) of Currency.
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// FromMajor converts an amount in major units (e.g. dollars) to Money.
+func FromMajor(amount float64, currency string, mode RoundingMode) (Money, error) {
+	c, err := LookupCurrency(currency)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w %q", err, currency)
+	}
+	return Money{Amount: Round(amount*math.Pow10(c.Exponent), mode), Currency: c.Code}, nil
+}
+
+// Round rounds v to an integer. v is first snapped to 6 decimal places so that
+// binary noise like 267.49999999999997 (2.675*100) rounds as 267.5 would.
+func Round(v float64, mode RoundingMode) int64 {
+	v = math.Round(v*1e6) / 1e6
+	switch mode {
+	case HalfEven:
+		return int64(math.RoundToEven(v))
+	case Down:
+		return int64(math.Trunc(v))
+	case Up:
+		if v < 0 {
+			return int64(math.Floor(v))
+		}
+		return int64(math.Ceil(v))
+	default:
+		return int64(math.Round(v))
+	}
+}
+
+// Major returns the amount in major units. Use it for display and ratios only.
+func (m Money) Major() float64 {
+	return float64(m.Amount) / math.Pow10(m.exponent())
+}
+
+func (m Money) Add(o Money) (Money, error) {
+	if m.Currency != o.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, o.Currency)
+	}
+	return Money{Amount: m.Amount + o.Amount, Currency: m.Currency}, nil
+}
+
+func (m Money) Sub(o Money) (Money, error) {
+	return m.Add(o.Neg())
+}
+
+func (m Money) Neg() Money {
+	return Money{Amount: -m.Amount, Currency: m.Currency}
+}
+
+// Mul scales the amount by factor, rounding the result with mode.
+func (m Money) Mul(factor float64, mode RoundingMode) Money {
+	return Money{Amount: Round(float64(m.Amount)*factor, mode), Currency: m.Currency}
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// Cmp compares two amounts of the same currency, returning -1, 0 or +1.
+func (m Money) Cmp(o Money) (int, error) {
+	if m.Currency != o.Currency {
+		return 0, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, o.Currency)
+	}
+	switch {
+	case m.Amount < o.Amount:
+		return -1, nil
+	case m.Amount > o.Amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// Decimal formats the amount in major units without a currency, e.g. "12.50".
+func (m Money) Decimal() string {
+	exp := m.exponent()
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	digits := strconv.FormatInt(amount, 10)
+	if exp == 0 {
+		return sign + digits
+	}
+	if len(digits) <= exp {
+		digits = strings.Repeat("0", exp-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-exp] + "." + digits[len(digits)-exp:]
+}
+
+// String formats the amount with its ISO code, e.g. "12.50 USD".
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+// Format formats the amount with the currency symbol, e.g. "$12.50".
+func (m Money) Format() string {
+	c, err := LookupCurrency(m.Currency)
+	if err != nil {
+		return m.String()
+	}
+	if m.Amount < 0 {
+		return "-" + c.Symbol + m.Neg().Decimal()
+	}
+	return c.Symbol + m.Decimal()
+}
+
+// exponent defaults to 2 for unknown currencies, which covers most of them.
+func (m Money) exponent() int {
+	if c, err := LookupCurrency(m.Currency); err == nil {
+		return c.Exponent
+	}
+	return 2
+}
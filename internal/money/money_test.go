@@ -0,0 +1,119 @@
+package money
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRound(t *testing.T) {
+	tests := []struct {
+		v                      float64
+		halfUp, halfEven, down int64
+		up                     int64
+	}{
+		{0, 0, 0, 0, 0},
+		{1.4, 1, 1, 1, 2},
+		{1.5, 2, 2, 1, 2},
+		{2.5, 3, 2, 2, 3},
+		{2.6, 3, 3, 2, 3},
+		{-1.4, -1, -1, -1, -2},
+		{-1.5, -2, -2, -1, -2},
+		{-2.5, -3, -2, -2, -3},
+		// 2.675*100 is 267.49999999999997 in binary.
+		{2.675 * 100, 268, 268, 267, 268},
+		{-2.675 * 100, -268, -268, -267, -268},
+		{2.665 * 100, 267, 266, 266, 267},
+	}
+	for _, tt := range tests {
+		for mode, want := range map[RoundingMode]int64{HalfUp: tt.halfUp, HalfEven: tt.halfEven, Down: tt.down, Up: tt.up} {
+			if got := Round(tt.v, mode); got != want {
+				t.Errorf("Round(%v, %d) = %d, want %d", tt.v, mode, got, want)
+			}
+		}
+	}
+}
+
+func TestFromMajor(t *testing.T) {
+	tests := []struct {
+		amount   float64
+		currency string
+		mode     RoundingMode
+		want     Money
+	}{
+		{2.675, "USD", HalfUp, New(268, "USD")},
+		{2.675, "USD", HalfEven, New(268, "USD")},
+		{2.675, "USD", Down, New(267, "USD")},
+		{-2.675, "EUR", HalfUp, New(-268, "EUR")},
+		{1234.5, "JPY", HalfUp, New(1235, "JPY")},
+		{1234.5, "JPY", HalfEven, New(1234, "JPY")},
+		{1.2345, "KWD", HalfUp, New(1235, "KWD")},
+		{1.2345, "KWD", Down, New(1234, "KWD")},
+		{12.5, "eur", HalfUp, New(1250, "EUR")},
+	}
+	for _, tt := range tests {
+		got, err := FromMajor(tt.amount, tt.currency, tt.mode)
+		if err != nil {
+			t.Fatalf("FromMajor(%v, %s): %v", tt.amount, tt.currency, err)
+		}
+		if got != tt.want {
+			t.Errorf("FromMajor(%v, %s, %d) = %+v, want %+v", tt.amount, tt.currency, tt.mode, got, tt.want)
+		}
+	}
+
+	if _, err := FromMajor(1, "XXX", HalfUp); !errors.Is(err, ErrUnknownCurrency) {
+		t.Errorf("FromMajor in XXX: err = %v, want %v", err, ErrUnknownCurrency)
+	}
+}
+
+func TestFormatting(t *testing.T) {
+	tests := []struct {
+		m               Money
+		decimal, format string
+	}{
+		{New(1250, "USD"), "12.50", "$12.50"},
+		{New(5, "USD"), "0.05", "$0.05"},
+		{New(0, "USD"), "0.00", "$0.00"},
+		{New(-1250, "EUR"), "-12.50", "-€12.50"},
+		{New(-5, "EUR"), "-0.05", "-€0.05"},
+		{New(1250, "JPY"), "1250", "¥1250"},
+		{New(-7, "JPY"), "-7", "-¥7"},
+		{New(1250, "KWD"), "1.250", "KWD 1.250"},
+		{New(5, "KWD"), "0.005", "KWD 0.005"},
+		{New(-12345, "KWD"), "-12.345", "-KWD 12.345"},
+		// Unknown currencies assume two decimals and fall back to the code.
+		{New(1250, "XXX"), "12.50", "12.50 XXX"},
+	}
+	for _, tt := range tests {
+		if got := tt.m.Decimal(); got != tt.decimal {
+			t.Errorf("%+v.Decimal() = %q, want %q", tt.m, got, tt.decimal)
+		}
+		if got := tt.m.Format(); got != tt.format {
+			t.Errorf("%+v.Format() = %q, want %q", tt.m, got, tt.format)
+		}
+	}
+	if got := New(1250, "USD").String(); got != "12.50 USD" {
+		t.Errorf("String() = %q, want %q", got, "12.50 USD")
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	sum, err := New(1250, "USD").Add(New(-300, "USD"))
+	if err != nil || sum != New(950, "USD") {
+		t.Errorf("Add = %+v, %v; want 9.50 USD", sum, err)
+	}
+	if _, err := New(1250, "USD").Add(New(100, "EUR")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Add across currencies: err = %v, want %v", err, ErrCurrencyMismatch)
+	}
+	if got := New(1001, "USD").Mul(0.5, HalfEven); got != New(500, "USD") {
+		t.Errorf("Mul half even = %+v, want 5.00 USD", got)
+	}
+	if got := New(1001, "USD").Mul(0.5, HalfUp); got != New(501, "USD") {
+		t.Errorf("Mul half up = %+v, want 5.01 USD", got)
+	}
+	if cmp, err := New(100, "JPY").Cmp(New(99, "JPY")); err != nil || cmp != 1 {
+		t.Errorf("Cmp = %d, %v; want 1", cmp, err)
+	}
+	if major := New(1250, "KWD").Major(); major != 1.25 {
+		t.Errorf("Major = %v, want 1.25", major)
+	}
+}
@@ -0,0 +1,92 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore is an in-process Store. Memory repositories enqueue into it while
+// holding their own lock, which makes the write and its events atomic.
+type MemoryStore struct {
+	mu       sync.Mutex
+	nextID   int64
+	messages []*memoryMessage // in enqueue order
+}
+
+type memoryMessage struct {
+	Message
+	availableAt time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+func (s *MemoryStore) Enqueue(ctx context.Context, msgs ...Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for _, msg := range msgs {
+		s.nextID++
+		msg.ID = s.nextID
+		msg.CreatedAt = now
+		s.messages = append(s.messages, &memoryMessage{Message: msg, availableAt: now})
+	}
+	return nil
+}
+
+func (s *MemoryStore) Claim(ctx context.Context, limit int, lease time.Duration) ([]Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	type topicKey struct{ topic, key string }
+	seen := make(map[topicKey]bool)
+	var claimed []Message
+	for _, m := range s.messages {
+		if len(claimed) == limit {
+			break
+		}
+		k := topicKey{m.Topic, m.Key}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		if m.availableAt.After(now) {
+			continue
+		}
+		m.availableAt = now.Add(lease)
+		claimed = append(claimed, m.Message)
+	}
+	return claimed, nil
+}
+
+func (s *MemoryStore) Delete(ctx context.Context, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, m := range s.messages {
+		if m.ID == id {
+			s.messages = append(s.messages[:i], s.messages[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (s *MemoryStore) Retry(ctx context.Context, id int64, at time.Time, cause error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, m := range s.messages {
+		if m.ID == id {
+			m.Attempts++
+			m.LastError = cause.Error()
+			m.availableAt = at
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+// Package outbox makes event publishing reliable. Repositories store events in
+// an outbox together with the state change they describe, and a Relay
+// publishes them afterwards, retrying until the broker confirms delivery.
+// Events are therefore never lost and never sent for a write that failed.
+// They can be delivered more than once, so consumers must be idempotent.
+package outbox
+
+import (
+	"context"
+	"time"
+)
+
+// Message is an event waiting to be published.
+type Message struct {
+	ID    int64
+	Topic string
+	// Key picks the Kafka partition. Messages with the same topic and key are
+	// published in the order they were enqueued.
+	Key       string
+	Value     []byte
+	CreatedAt time.Time
+	// Attempts counts failed deliveries so far.
+	Attempts  int
+	LastError string
+}
+
+// Store holds messages until the relay has published them.
+type Store interface {
+	// Enqueue adds messages outside of any repository write, for events that
+	// do not record a state change.
+	Enqueue(ctx context.Context, msgs ...Message) error
+	// Claim returns up to limit messages that are due and hides them from
+	// other claims for lease. Only the oldest message per topic and key is
+	// returned, so a message that keeps failing holds back the ones after it.
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]Message, error)
+	// Delete removes a message once it has been delivered.
+	Delete(ctx context.Context, id int64) error
+	// Retry records a failed delivery and makes the message due again at at.
+	Retry(ctx context.Context, id int64, at time.Time, cause error) error
+}
@@ -0,0 +1,89 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresStore keeps messages in the outbox table. Repositories add to it with
+// EnqueueTx inside their own transactions.
+type PostgresStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresStore(pool *pgxpool.Pool) *PostgresStore {
+	return &PostgresStore{pool: pool}
+}
+
+// EnqueueTx adds messages as part of tx, so they are only published if tx
+// commits.
+func EnqueueTx(ctx context.Context, tx pgx.Tx, msgs ...Message) error {
+	for _, msg := range msgs {
+		_, err := tx.Exec(ctx,
+			`INSERT INTO outbox (topic, key, payload) VALUES ($1, $2, $3)`,
+			msg.Topic, msg.Key, msg.Value,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *PostgresStore) Enqueue(ctx context.Context, msgs ...Message) error {
+	return pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		return EnqueueTx(ctx, tx, msgs...)
+	})
+}
+
+// Claim leases due messages that have no older message waiting for the same
+// topic and key. SKIP LOCKED lets several relays share the table.
+func (s *PostgresStore) Claim(ctx context.Context, limit int, lease time.Duration) ([]Message, error) {
+	rows, err := s.pool.Query(ctx, `
+		UPDATE outbox SET available_at = now() + make_interval(secs => $2)
+		WHERE id IN (
+			SELECT o.id FROM outbox o
+			WHERE o.available_at <= now()
+			  AND NOT EXISTS (
+			    SELECT 1 FROM outbox older
+			    WHERE older.topic = o.topic AND older.key = o.key AND older.id < o.id
+			  )
+			ORDER BY o.id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, topic, key, payload, created_at, attempts, last_error`,
+		limit, lease.Seconds(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var claimed []Message
+	for rows.Next() {
+		var m Message
+		if err := rows.Scan(&m.ID, &m.Topic, &m.Key, &m.Value, &m.CreatedAt, &m.Attempts, &m.LastError); err != nil {
+			return nil, err
+		}
+		claimed = append(claimed, m)
+	}
+	return claimed, rows.Err()
+}
+
+func (s *PostgresStore) Delete(ctx context.Context, id int64) error {
+	_, err := s.pool.Exec(ctx, `DELETE FROM outbox WHERE id = $1`, id)
+	return err
+}
+
+func (s *PostgresStore) Retry(ctx context.Context, id int64, at time.Time, cause error) error {
+	_, err := s.pool.Exec(ctx, `
+		UPDATE outbox SET attempts = attempts + 1, last_error = $2, available_at = $3
+		WHERE id = $1`,
+		id, cause.Error(), at,
+	)
+	return err
+}
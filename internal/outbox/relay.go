@@ -0,0 +1,100 @@
+package outbox
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/lukabrx/uber-clone/internal/eventbus"
+)
+
+type RelayConfig struct {
+	// PollInterval is how long the relay waits when the outbox is empty.
+	PollInterval time.Duration
+	BatchSize    int
+	// Lease hides claimed messages from other relays while they are published.
+	Lease time.Duration
+	// Failed deliveries are retried after InitialBackoff, doubling up to
+	// MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+var DefaultRelayConfig = RelayConfig{
+	PollInterval:   200 * time.Millisecond,
+	BatchSize:      100,
+	Lease:          30 * time.Second,
+	InitialBackoff: time.Second,
+	MaxBackoff:     time.Minute,
+}
+
+// Relay moves messages from a Store to an event bus.
+type Relay struct {
+	store     Store
+	publisher eventbus.Publisher
+	cfg       RelayConfig
+}
+
+func NewRelay(store Store, publisher eventbus.Publisher, cfg RelayConfig) *Relay {
+	return &Relay{store: store, publisher: publisher, cfg: cfg}
+}
+
+// Run publishes messages until ctx is cancelled.
+func (r *Relay) Run(ctx context.Context) {
+	for {
+		published, err := r.RunOnce(ctx)
+		if err != nil {
+			log.Printf("Outbox relay failed to claim messages: %v", err)
+		}
+		if published > 0 {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.cfg.PollInterval):
+		}
+	}
+}
+
+// RunOnce publishes one batch of due messages and returns how many were
+// delivered. Messages that fail are scheduled for another attempt.
+func (r *Relay) RunOnce(ctx context.Context) (int, error) {
+	msgs, err := r.store.Claim(ctx, r.cfg.BatchSize, r.cfg.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for _, msg := range msgs {
+		err := r.publisher.Publish(ctx, eventbus.Message{Topic: msg.Topic, Key: msg.Key, Value: msg.Value})
+		if err != nil {
+			retryAt := time.Now().Add(r.backoff(msg.Attempts))
+			log.Printf("Failed to publish outbox message %d to %s (attempt %d), retrying at %s: %v",
+				msg.ID, msg.Topic, msg.Attempts+1, retryAt.Format(time.RFC3339), err)
+			if err := r.store.Retry(ctx, msg.ID, retryAt, err); err != nil {
+				log.Printf("Failed to reschedule outbox message %d: %v", msg.ID, err)
+			}
+			continue
+		}
+		// If this fails the message is published again once its lease expires.
+		if err := r.store.Delete(ctx, msg.ID); err != nil {
+			log.Printf("Failed to delete published outbox message %d: %v", msg.ID, err)
+			continue
+		}
+		published++
+	}
+	return published, nil
+}
+
+func (r *Relay) backoff(attempts int) time.Duration {
+	d := r.cfg.InitialBackoff
+	for range attempts {
+		d *= 2
+		if d >= r.cfg.MaxBackoff {
+			return r.cfg.MaxBackoff
+		}
+	}
+	return d
+}
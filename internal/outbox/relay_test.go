@@ -0,0 +1,182 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lukabrx/uber-clone/internal/eventbus"
+)
+
+// flakyPublisher records what it publishes and fails values in failures the
+// given number of times.
+type flakyPublisher struct {
+	mu        sync.Mutex
+	failures  map[string]int
+	published []string
+}
+
+func (p *flakyPublisher) Publish(_ context.Context, msg eventbus.Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.failures[string(msg.Value)] > 0 {
+		p.failures[string(msg.Value)]--
+		return errors.New("broker unavailable")
+	}
+	p.published = append(p.published, string(msg.Value))
+	return nil
+}
+
+func enqueue(t *testing.T, store Store, key string, values ...string) {
+	t.Helper()
+	for _, v := range values {
+		if err := store.Enqueue(context.Background(), Message{Topic: "trips", Key: key, Value: []byte(v)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func values(msgs []Message) []string {
+	var vs []string
+	for _, m := range msgs {
+		vs = append(vs, string(m.Value))
+	}
+	return vs
+}
+
+func TestClaimOldestPerKey(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	enqueue(t, store, "trip-1", "created", "assigned")
+	enqueue(t, store, "trip-2", "created 2")
+	enqueue(t, store, "trip-1", "started")
+	// Same key, different topic: ordered separately.
+	if err := store.Enqueue(ctx, Message{Topic: "offers", Key: "trip-1", Value: []byte("offer")}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range [][]string{
+		{"created", "created 2", "offer"},
+		{"assigned"},
+		{"started"},
+		nil,
+	} {
+		claimed, err := store.Claim(ctx, 10, time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := values(claimed); !slices.Equal(got, want) {
+			t.Fatalf("claimed %v, want %v", got, want)
+		}
+		for _, m := range claimed {
+			store.Delete(ctx, m.ID)
+		}
+	}
+}
+
+func TestClaimLimit(t *testing.T) {
+	store := NewMemoryStore()
+	enqueue(t, store, "trip-1", "a")
+	enqueue(t, store, "trip-2", "b")
+	enqueue(t, store, "trip-3", "c")
+
+	claimed, err := store.Claim(context.Background(), 2, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := values(claimed); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("claimed %v, want the oldest two", got)
+	}
+}
+
+func TestClaimLeaseExpires(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	enqueue(t, store, "trip-1", "created", "assigned")
+	const lease = 50 * time.Millisecond
+
+	// A relay claims the first event and crashes before publishing it.
+	if claimed, _ := store.Claim(ctx, 10, lease); !slices.Equal(values(claimed), []string{"created"}) {
+		t.Fatalf("claimed %v, want the first event", values(claimed))
+	}
+	// While the lease holds nobody else gets it, nor the event after it.
+	if claimed, _ := store.Claim(ctx, 10, lease); len(claimed) != 0 {
+		t.Fatalf("claimed %v during the lease, want nothing", values(claimed))
+	}
+
+	time.Sleep(2 * lease)
+	claimed, err := store.Claim(ctx, 10, lease)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(values(claimed), []string{"created"}) {
+		t.Fatalf("claimed %v after the lease expired, want the first event again", values(claimed))
+	}
+}
+
+func TestRelayRetriesWithBackoff(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	enqueue(t, store, "trip-1", "created", "assigned")
+	enqueue(t, store, "trip-2", "created 2")
+	publisher := &flakyPublisher{failures: map[string]int{"created": 2}}
+	relay := NewRelay(store, publisher, RelayConfig{
+		BatchSize:      10,
+		Lease:          time.Minute,
+		InitialBackoff: 40 * time.Millisecond,
+		MaxBackoff:     time.Minute,
+	})
+
+	// The first attempt fails and holds back the rest of trip-1; other trips
+	// go ahead.
+	before := time.Now()
+	if n, err := relay.RunOnce(ctx); err != nil || n != 1 {
+		t.Fatalf("RunOnce = %d, %v; want 1 published", n, err)
+	}
+	m := store.messages[0]
+	if m.Attempts != 1 || m.LastError != "broker unavailable" {
+		t.Errorf("after one failure attempts = %d, last error %q", m.Attempts, m.LastError)
+	}
+	if wait := m.availableAt.Sub(before); wait < 40*time.Millisecond || wait > time.Second {
+		t.Errorf("retry due in %s, want the initial backoff of 40ms", wait)
+	}
+	if n, _ := relay.RunOnce(ctx); n != 0 {
+		t.Fatalf("published %d before the backoff elapsed, want 0", n)
+	}
+
+	// The second failure doubles the wait.
+	time.Sleep(50 * time.Millisecond)
+	before = time.Now()
+	if n, _ := relay.RunOnce(ctx); n != 0 {
+		t.Fatalf("published %d, want the second attempt to fail", n)
+	}
+	if wait := m.availableAt.Sub(before); m.Attempts != 2 || wait < 80*time.Millisecond {
+		t.Errorf("after %d failures retry due in %s, want 80ms", m.Attempts, wait)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	if n, _ := relay.RunOnce(ctx); n != 1 {
+		t.Fatalf("published %d, want the third attempt to succeed", n)
+	}
+	if n, _ := relay.RunOnce(ctx); n != 1 {
+		t.Fatalf("published %d, want the event held back to follow", n)
+	}
+	if want := []string{"created 2", "created", "assigned"}; !slices.Equal(publisher.published, want) {
+		t.Errorf("published %v, want %v", publisher.published, want)
+	}
+	if len(store.messages) != 0 {
+		t.Errorf("%d messages left in the outbox", len(store.messages))
+	}
+}
+
+func TestRelayBackoff(t *testing.T) {
+	relay := NewRelay(nil, nil, RelayConfig{InitialBackoff: time.Second, MaxBackoff: 10 * time.Second})
+	for attempts, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second} {
+		if got := relay.backoff(attempts); got != want {
+			t.Errorf("backoff(%d) = %s, want %s", attempts, got, want)
+		}
+	}
+}
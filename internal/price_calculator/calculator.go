@@ -1,52 +1,133 @@
 package pricecalculator
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
 	"fmt"
-	"io"
-	"net/http"
+	"time"
+
+	"github.com/lukabrx/uber-clone/internal/models"
+	"github.com/lukabrx/uber-clone/internal/money"
 )
 
-func CalculatePrice(startLat, startLon, endLat, endLon float64) (float64, error) {
-	url := fmt.Sprintf(
-		"http://router.project-osrm.org/route/v1/car/%.6f,%.6f;%.6f,%.6f?overview=false",
-		startLon, startLat, endLon, endLat,
-	)
-	resp, err := http.Get(url)
+// FareRequest is what a trip is priced on.
+type FareRequest struct {
+	StartLat    float64
+	StartLon    float64
+	EndLat      float64
+	EndLon      float64
+	VehicleType models.VehicleType
+}
+
+// FareBreakdown itemizes a fare. Every line is in the city's currency and
+// Total is their sum.
+type FareBreakdown struct {
+	City        string             `json:"city"`
+	VehicleType models.VehicleType `json:"vehicle_type"`
+	DistanceKm  float64            `json:"distance_km"`
+	Duration    time.Duration      `json:"duration"`
+
+	BaseFare     money.Money `json:"base_fare"`
+	DistanceFare money.Money `json:"distance_fare"`
+	TimeFare     money.Money `json:"time_fare"`
+	// MinimumFareAdjustment tops the ride up to the minimum fare. Booking fee
+	// and tolls are charged on top.
+	MinimumFareAdjustment money.Money `json:"minimum_fare_adjustment"`
+	// SurgeMultiplier scales the ride (base, distance, time and minimum fare
+	// adjustment); Surge is the amount it added.
+	SurgeMultiplier float64     `json:"surge_multiplier"`
+	Surge           money.Money `json:"surge"`
+	BookingFee      money.Money `json:"booking_fee"`
+	Tolls           money.Money `json:"tolls"`
+	Total           money.Money `json:"total"`
+}
+
+// Calculator prices trips from the route its Router returns, the rate card for
+// the pickup's city and the vehicle class, and the surge at the pickup.
+type Calculator struct {
+	router Router
+	rates  *RateCardStore
+	surge  *SurgeEngine
+}
+
+// NewCalculator returns a calculator. surge may be nil to price without surge.
+func NewCalculator(router Router, rates *RateCardStore, surge *SurgeEngine) *Calculator {
+	return &Calculator{router: router, rates: rates, surge: surge}
+}
+
+func (c *Calculator) CalculatePrice(ctx context.Context, req FareRequest) (FareBreakdown, error) {
+	if req.VehicleType == "" {
+		req.VehicleType = models.VehicleEconomy
+	}
+	city, rates, card, err := c.rates.Current().Lookup(req.StartLat, req.StartLon, req.VehicleType)
+	if err != nil {
+		return FareBreakdown{}, err
+	}
+
+	route, err := c.router.Route(ctx, req.StartLat, req.StartLon, req.EndLat, req.EndLon)
 	if err != nil {
-		return 0, err
+		return FareBreakdown{}, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("OSRM request failed with status: %s", resp.Status)
+	multiplier := 1.0
+	if c.surge != nil {
+		multiplier = c.surge.Multiplier(req.StartLat, req.StartLon)
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	return card.Apply(city, req.VehicleType, rates.Currency, route.DistanceKm, route.Duration, multiplier)
+}
+
+// RecalculatePrice prices a finished ride from the distance and time it actually
+// took, with the surge multiplier it was booked at rather than the current one.
+func (c *Calculator) RecalculatePrice(req FareRequest, distanceKm float64, duration time.Duration, surgeMultiplier float64) (FareBreakdown, error) {
+	if req.VehicleType == "" {
+		req.VehicleType = models.VehicleEconomy
+	}
+	city, rates, card, err := c.rates.Current().Lookup(req.StartLat, req.StartLon, req.VehicleType)
 	if err != nil {
-		return 0, err
+		return FareBreakdown{}, err
 	}
+	return card.Apply(city, req.VehicleType, rates.Currency, distanceKm, duration, surgeMultiplier)
+}
 
-	type osrmRoute struct {
-		Distance float64 `json:"distance"`
-	}
-	type osrmResponse struct {
-		Routes []osrmRoute `json:"routes"`
+// Surge returns the surge in force at a point.
+func (c *Calculator) Surge(lat, lon float64) Surge {
+	if c.surge == nil {
+		return Surge{Multiplier: 1}
 	}
+	return c.surge.SurgeAt(lat, lon)
+}
 
-	var osrmResp osrmResponse
-	if err := json.Unmarshal(body, &osrmResp); err != nil {
-		return 0, err
+// Apply prices a ride of the given distance and duration with this card. The
+// distance and time lines are rounded half up to the currency's minor unit
+// before being summed.
+func (card RateCard) Apply(city string, vehicleType models.VehicleType, currency string, distanceKm float64, duration time.Duration, surgeMultiplier float64) (FareBreakdown, error) {
+	if _, err := money.LookupCurrency(currency); err != nil {
+		return FareBreakdown{}, fmt.Errorf("%w %q", err, currency)
 	}
-	if len(osrmResp.Routes) == 0 {
-		return 0, errors.New("no routes found")
+	amount := func(minor int64) money.Money {
+		return money.New(minor, currency)
+	}
+	if surgeMultiplier < 1 {
+		surgeMultiplier = 1
 	}
 
-	distanceInKm := osrmResp.Routes[0].Distance / 1000
-	baseFare := 2.50
-	perKmRate := 1.50
-	price := baseFare + (distanceInKm * perKmRate)
+	b := FareBreakdown{
+		City:         city,
+		VehicleType:  vehicleType,
+		DistanceKm:   distanceKm,
+		Duration:     duration,
+		BaseFare:     amount(card.BaseFare),
+		DistanceFare: money.New(card.PerKm, currency).Mul(distanceKm, money.HalfUp),
+		TimeFare:     money.New(card.PerMinute, currency).Mul(duration.Minutes(), money.HalfUp),
+		BookingFee:   amount(card.BookingFee),
+		Tolls:        amount(card.Tolls),
 
-	return float64(int(price*100)) / 100, nil
+		SurgeMultiplier: surgeMultiplier,
+	}
+	ride := b.BaseFare.Amount + b.DistanceFare.Amount + b.TimeFare.Amount
+	b.MinimumFareAdjustment = amount(max(card.MinimumFare-ride, 0))
+	ride += b.MinimumFareAdjustment.Amount
+	b.Surge = money.New(ride, currency).Mul(surgeMultiplier-1, money.HalfUp)
+	b.Total = money.New(ride+b.Surge.Amount+b.BookingFee.Amount+b.Tolls.Amount, currency)
+	return b, nil
 }
@@ -0,0 +1,57 @@
+package pricecalculator
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// RouterConfig selects and configures the routing provider.
+type RouterConfig struct {
+	// Provider is "osrm" (with an offline fallback) or "haversine" for fully
+	// offline routing.
+	Provider    string
+	OSRMBaseURL string
+	Timeout     time.Duration
+}
+
+var DefaultRouterConfig = RouterConfig{
+	Provider:    "osrm",
+	OSRMBaseURL: DefaultOSRMBaseURL,
+	Timeout:     3 * time.Second,
+}
+
+// RouterConfigFromEnv reads ROUTER_PROVIDER, OSRM_BASE_URL and ROUTER_TIMEOUT,
+// keeping the defaults for anything unset.
+func RouterConfigFromEnv() (RouterConfig, error) {
+	cfg := DefaultRouterConfig
+	if v := os.Getenv("ROUTER_PROVIDER"); v != "" {
+		cfg.Provider = v
+	}
+	if v := os.Getenv("OSRM_BASE_URL"); v != "" {
+		cfg.OSRMBaseURL = v
+	}
+	if v := os.Getenv("ROUTER_TIMEOUT"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return RouterConfig{}, fmt.Errorf("invalid ROUTER_TIMEOUT %q: %w", v, err)
+		}
+		cfg.Timeout = d
+	}
+	return cfg, nil
+}
+
+// NewRouter builds the router described by cfg.
+func NewRouter(cfg RouterConfig) (Router, error) {
+	switch cfg.Provider {
+	case "osrm":
+		return FallbackRouter{
+			Primary:   NewOSRMRouter(cfg.OSRMBaseURL, cfg.Timeout),
+			Secondary: DefaultHaversineRouter,
+		}, nil
+	case "haversine":
+		return DefaultHaversineRouter, nil
+	default:
+		return nil, fmt.Errorf("unknown router provider %q", cfg.Provider)
+	}
+}
@@ -0,0 +1,85 @@
+package pricecalculator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/lukabrx/uber-clone/internal/dlq"
+	"github.com/lukabrx/uber-clone/internal/driver"
+	"github.com/lukabrx/uber-clone/internal/eventbus"
+	"github.com/lukabrx/uber-clone/internal/events"
+	"github.com/lukabrx/uber-clone/internal/types"
+)
+
+// EventConsumer feeds trip requests and driver availability into a SurgeEngine.
+// processed skips redelivered and out-of-date trip events, which would
+// otherwise reopen requests that have closed. Driver updates carry the
+// driver's whole state and can be applied any number of times. Messages that
+// fail are retried by deadLetters and then moved to the dead-letter topic.
+type EventConsumer struct {
+	subscriber  eventbus.Subscriber
+	surge       *SurgeEngine
+	processed   driver.ProcessedEvents
+	deadLetters *dlq.Processor
+}
+
+func NewEventConsumer(subscriber eventbus.Subscriber, surge *SurgeEngine, processed driver.ProcessedEvents, deadLetters *dlq.Processor) *EventConsumer {
+	return &EventConsumer{subscriber: subscriber, surge: surge, processed: processed, deadLetters: deadLetters}
+}
+
+func (c *EventConsumer) SubscribeAndListen(ctx context.Context) {
+	log.Println("Surge consumer subscribed and listening for trip events and driver updates...")
+	go func() {
+		topics := []string{types.TripEventsTopic, types.DriverLocationTopic}
+		err := c.subscriber.Subscribe(ctx, topics, c.deadLetters.Handler(c.handle))
+		if err != nil {
+			log.Fatalf("Failed to subscribe to topic: %v", err)
+		}
+		log.Println("Stopping surge event consumer.")
+	}()
+}
+
+func (c *EventConsumer) handle(ctx context.Context, msg eventbus.Message) error {
+	switch msg.Topic {
+	case types.TripEventsTopic:
+		return c.handleTripEvent(ctx, msg.Value)
+	case types.DriverLocationTopic:
+		return c.handleDriverUpdate(msg.Value)
+	}
+	return nil
+}
+
+func (c *EventConsumer) handleTripEvent(ctx context.Context, value []byte) error {
+	event, err := events.DecodeTripEvent(value)
+	if err != nil {
+		return dlq.Permanent(fmt.Errorf("could not decode trip event: %w", err))
+	}
+
+	err = c.processed.Check(ctx, event)
+	if errors.Is(err, driver.ErrDuplicateEvent) || errors.Is(err, driver.ErrStaleEvent) {
+		log.Printf("Skipping %s event %s (version %d) for trip %s: %v", event.EventType, event.EventID, event.Version, event.TripID, err)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	switch event.EventType {
+	case types.TripCreatedEvent:
+		c.surge.TripRequested(event.TripID, event.PickupLat, event.PickupLon)
+	case types.TripDriverAssignedEvent, types.TripCancelledEvent, types.TripNoShowEvent, types.TripCompletedEvent:
+		c.surge.TripClosed(event.TripID)
+	}
+	return c.processed.Mark(ctx, event)
+}
+
+func (c *EventConsumer) handleDriverUpdate(value []byte) error {
+	d, err := events.DecodeDriver(value)
+	if err != nil {
+		return dlq.Permanent(fmt.Errorf("could not decode driver update: %w", err))
+	}
+	c.surge.DriverUpdated(d)
+	return nil
+}
@@ -0,0 +1,106 @@
+package pricecalculator
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lukabrx/uber-clone/internal/dlq"
+	"github.com/lukabrx/uber-clone/internal/driver"
+	"github.com/lukabrx/uber-clone/internal/eventbus"
+	"github.com/lukabrx/uber-clone/internal/events"
+	"github.com/lukabrx/uber-clone/internal/models"
+	"github.com/lukabrx/uber-clone/internal/types"
+)
+
+// deadLetterBox is a publisher that keeps what is dead-lettered.
+type deadLetterBox struct {
+	mu       sync.Mutex
+	messages []eventbus.Message
+}
+
+func (b *deadLetterBox) Publish(_ context.Context, msg eventbus.Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.messages = append(b.messages, msg)
+	return nil
+}
+
+func tripEventMessage(t *testing.T, event types.TripEvent) eventbus.Message {
+	t.Helper()
+	value, err := events.Marshal(events.NewTripEvent(context.Background(), event))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return eventbus.Message{Topic: types.TripEventsTopic, Key: event.TripID, Value: value}
+}
+
+func TestEventConsumer(t *testing.T) {
+	ctx := context.Background()
+	surge := NewSurgeEngine(testSurgeConfig)
+	box := &deadLetterBox{}
+	c := NewEventConsumer(nil, surge, driver.NewMemoryProcessedEvents(time.Hour),
+		dlq.NewProcessor(box, "trip_surge_group", dlq.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}))
+	handle := c.deadLetters.Handler(c.handle)
+
+	created := types.TripEvent{EventID: "e1", Version: 1, EventType: types.TripCreatedEvent, TripID: "trip-1", PickupLat: pickupLat, PickupLon: pickupLon}
+	assigned := types.TripEvent{EventID: "e2", Version: 2, EventType: types.TripDriverAssignedEvent, TripID: "trip-1", DriverID: "driver-1"}
+	// The same creation published again under a new ID, e.g. by a relay
+	// that crashed before marking it sent.
+	republished := created
+	republished.EventID = "e1-again"
+
+	steps := []struct {
+		name         string
+		msg          eventbus.Message
+		wantRequests int
+	}{
+		{"created", tripEventMessage(t, created), 1},
+		{"created redelivered", tripEventMessage(t, created), 1},
+		{"assigned", tripEventMessage(t, assigned), 0},
+		{"assigned redelivered", tripEventMessage(t, assigned), 0},
+		// Reopening the request would count it as demand until the TTL.
+		{"created after assigned", tripEventMessage(t, republished), 0},
+	}
+	for _, step := range steps {
+		if err := handle(ctx, step.msg); err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if got := len(surge.requests); got != step.wantRequests {
+			t.Fatalf("%s: %d open requests, want %d", step.name, got, step.wantRequests)
+		}
+	}
+
+	update, err := events.Marshal(events.NewDriverUpdated(ctx, models.Driver{ID: "driver-1", Lat: pickupLat, Lon: pickupLon, IsAvailable: true}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 2 {
+		if err := handle(ctx, eventbus.Message{Topic: types.DriverLocationTopic, Value: update}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(surge.drivers) != 1 {
+		t.Errorf("%d drivers counted, want 1", len(surge.drivers))
+	}
+	if len(box.messages) != 0 {
+		t.Fatalf("%d messages dead-lettered, want none", len(box.messages))
+	}
+
+	// Malformed messages are dead-lettered without being retried.
+	for _, topic := range []string{types.TripEventsTopic, types.DriverLocationTopic} {
+		if err := handle(ctx, eventbus.Message{Topic: topic, Value: []byte("not protobuf")}); err != nil {
+			t.Fatalf("%s: %v", topic, err)
+		}
+	}
+	if len(box.messages) != 2 {
+		t.Fatalf("%d messages dead-lettered, want 2", len(box.messages))
+	}
+	for _, msg := range box.messages {
+		if msg.Topic != types.DeadLetterTopic || msg.Header(dlq.HeaderAttempts) != "1" {
+			t.Errorf("dead-lettered %s from %s after %s attempt(s), want the dead-letter topic after 1",
+				msg.Topic, msg.Header(dlq.HeaderOriginalTopic), msg.Header(dlq.HeaderAttempts))
+		}
+	}
+}
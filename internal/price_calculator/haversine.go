@@ -0,0 +1,64 @@
+package pricecalculator
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/lukabrx/uber-clone/internal/geo"
+)
+
+// HaversineRouter estimates routes without any network access: the great-circle
+// distance stretched by a road factor, driven at a constant speed.
+type HaversineRouter struct {
+	// RoadFactor accounts for roads not being straight lines.
+	RoadFactor float64
+	SpeedKmh   float64
+}
+
+// DefaultHaversineRouter matches typical city driving.
+var DefaultHaversineRouter = HaversineRouter{RoadFactor: 1.3, SpeedKmh: 30}
+
+func (r HaversineRouter) Route(ctx context.Context, startLat, startLon, endLat, endLon float64) (Route, error) {
+	if err := ctx.Err(); err != nil {
+		return Route{}, err
+	}
+
+	distance := geo.DistanceKm(startLat, startLon, endLat, endLon) * r.RoadFactor
+	var duration time.Duration
+	if r.SpeedKmh > 0 {
+		duration = time.Duration(distance / r.SpeedKmh * float64(time.Hour))
+	}
+	return Route{
+		DistanceKm: distance,
+		Duration:   duration,
+		Polyline:   encodePolyline([][2]float64{{startLat, startLon}, {endLat, endLon}}),
+	}, nil
+}
+
+// encodePolyline encodes lat/lon points with Google's polyline algorithm.
+func encodePolyline(points [][2]float64) string {
+	var b strings.Builder
+	var prevLat, prevLon int64
+	for _, p := range points {
+		lat := int64(math.Round(p[0] * 1e5))
+		lon := int64(math.Round(p[1] * 1e5))
+		encodePolylineValue(&b, lat-prevLat)
+		encodePolylineValue(&b, lon-prevLon)
+		prevLat, prevLon = lat, lon
+	}
+	return b.String()
+}
+
+func encodePolylineValue(b *strings.Builder, v int64) {
+	u := uint64(v << 1)
+	if v < 0 {
+		u = ^u
+	}
+	for u >= 0x20 {
+		b.WriteByte(byte((0x20 | (u & 0x1f)) + 63))
+		u >>= 5
+	}
+	b.WriteByte(byte(u + 63))
+}
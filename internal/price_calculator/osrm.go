@@ -0,0 +1,81 @@
+package pricecalculator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultOSRMBaseURL is the public OSRM demo server. It is rate limited and
+// should only be used for development.
+const DefaultOSRMBaseURL = "http://router.project-osrm.org"
+
+// OSRMRouter routes through an OSRM server's /route/v1/car endpoint.
+type OSRMRouter struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewOSRMRouter returns a router for the OSRM server at baseURL. Every request is
+// bounded by timeout in addition to the caller's context.
+func NewOSRMRouter(baseURL string, timeout time.Duration) *OSRMRouter {
+	return &OSRMRouter{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+func (r *OSRMRouter) Route(ctx context.Context, startLat, startLon, endLat, endLon float64) (Route, error) {
+	url := fmt.Sprintf(
+		"%s/route/v1/car/%.6f,%.6f;%.6f,%.6f?overview=simplified&geometries=polyline",
+		r.baseURL, startLon, startLat, endLon, endLat,
+	)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return Route{}, err
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return Route{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Route{}, fmt.Errorf("OSRM request failed with status: %s", resp.Status)
+	}
+
+	type osrmRoute struct {
+		Distance float64 `json:"distance"` // meters
+		Duration float64 `json:"duration"` // seconds
+		Geometry string  `json:"geometry"`
+	}
+	type osrmResponse struct {
+		Code    string      `json:"code"`
+		Message string      `json:"message"`
+		Routes  []osrmRoute `json:"routes"`
+	}
+
+	var osrmResp osrmResponse
+	if err := json.NewDecoder(resp.Body).Decode(&osrmResp); err != nil {
+		return Route{}, err
+	}
+	// OSRM reports problems such as unroutable points in the body.
+	if osrmResp.Code != "Ok" {
+		return Route{}, fmt.Errorf("OSRM request failed with code %s: %s", osrmResp.Code, osrmResp.Message)
+	}
+	if len(osrmResp.Routes) == 0 {
+		return Route{}, errors.New("no routes found")
+	}
+
+	route := osrmResp.Routes[0]
+	return Route{
+		DistanceKm: route.Distance / 1000,
+		Duration:   time.Duration(route.Duration * float64(time.Second)),
+		Polyline:   route.Geometry,
+	}, nil
+}
@@ -0,0 +1,97 @@
+package pricecalculator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// osrmServer serves handler as an OSRM server and returns a router for it.
+func osrmServer(t *testing.T, timeout time.Duration, handler http.HandlerFunc) *OSRMRouter {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return NewOSRMRouter(srv.URL+"/", timeout)
+}
+
+func TestOSRMRoute(t *testing.T) {
+	router := osrmServer(t, time.Second, func(w http.ResponseWriter, r *http.Request) {
+		// OSRM takes coordinates as lon,lat.
+		if want := "/route/v1/car/20.457300,44.787200;20.400000,44.800000"; r.URL.Path != want {
+			t.Errorf("path = %s, want %s", r.URL.Path, want)
+		}
+		w.Write([]byte(`{"code":"Ok","routes":[{"distance":8450.5,"duration":912.4,"geometry":"abc"},{"distance":9000,"duration":800,"geometry":"def"}]}`))
+	})
+
+	route, err := router.Route(context.Background(), 44.7872, 20.4573, 44.8, 20.4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Route{DistanceKm: 8.4505, Duration: 912400 * time.Millisecond, Polyline: "abc"}
+	if route != want {
+		t.Errorf("Route = %+v, want the first route %+v", route, want)
+	}
+}
+
+func TestOSRMRouteFails(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		delay   time.Duration
+		wantErr string
+	}{
+		{
+			name:    "no route",
+			status:  http.StatusOK,
+			body:    `{"code":"NoRoute","message":"Impossible route between points","routes":[]}`,
+			wantErr: "NoRoute",
+		},
+		{
+			name:    "bad request",
+			status:  http.StatusBadRequest,
+			body:    `{"code":"InvalidQuery","message":"Query string malformed"}`,
+			wantErr: "400",
+		},
+		{
+			name:    "empty routes",
+			status:  http.StatusOK,
+			body:    `{"code":"Ok","routes":[]}`,
+			wantErr: "no routes found",
+		},
+		{
+			name:    "malformed body",
+			status:  http.StatusOK,
+			body:    `<html>`,
+			wantErr: "invalid character",
+		},
+		{
+			name:    "timeout",
+			status:  http.StatusOK,
+			body:    `{"code":"Ok","routes":[{"distance":1000,"duration":60}]}`,
+			delay:   time.Second,
+			wantErr: "Timeout",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := osrmServer(t, 50*time.Millisecond, func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-time.After(tt.delay):
+				case <-r.Context().Done():
+					return
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			_, err := router.Route(context.Background(), 44.7872, 20.4573, 44.8, 20.4)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want one mentioning %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package pricecalculator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lukabrx/uber-clone/internal/models"
+	"github.com/lukabrx/uber-clone/internal/money"
+)
+
+var ErrNoRateCard = errors.New("no rate card for vehicle class")
+
+// RateCard is the tariff for one vehicle class in one city. Amounts are in
+// minor units (e.g. cents) of the city's currency.
+type RateCard struct {
+	BaseFare    int64 `json:"base_fare"`
+	PerKm       int64 `json:"per_km"`
+	PerMinute   int64 `json:"per_minute"`
+	MinimumFare int64 `json:"minimum_fare"`
+	BookingFee  int64 `json:"booking_fee"`
+	// Tolls is a flat toll estimate added to every trip.
+	Tolls int64 `json:"tolls"`
+}
+
+// Bounds is a lat/lon box used to decide which city a pickup is in.
+type Bounds struct {
+	MinLat float64 `json:"min_lat"`
+	MaxLat float64 `json:"max_lat"`
+	MinLon float64 `json:"min_lon"`
+	MaxLon float64 `json:"max_lon"`
+}
+
+func (b Bounds) Contains(lat, lon float64) bool {
+	return lat >= b.MinLat && lat <= b.MaxLat && lon >= b.MinLon && lon <= b.MaxLon
+}
+
+type CityRates struct {
+	Currency       string                          `json:"currency"`
+	Bounds         *Bounds                         `json:"bounds,omitempty"`
+	VehicleClasses map[models.VehicleType]RateCard `json:"vehicle_classes"`
+}
+
+// RateCards is the contents of a rate card file. Pickups outside every city's
+// bounds are priced with DefaultCity.
+type RateCards struct {
+	DefaultCity string               `json:"default_city"`
+	Cities      map[string]CityRates `json:"cities"`
+}
+
+// LoadRateCards reads and validates a rate card file.
+func LoadRateCards(path string) (*RateCards, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cards RateCards
+	if err := json.Unmarshal(data, &cards); err != nil {
+		return nil, fmt.Errorf("parse rate cards %s: %w", path, err)
+	}
+	if err := cards.validate(); err != nil {
+		return nil, fmt.Errorf("invalid rate cards %s: %w", path, err)
+	}
+	return &cards, nil
+}
+
+func (c *RateCards) validate() error {
+	if _, ok := c.Cities[c.DefaultCity]; !ok {
+		return fmt.Errorf("default city %q is not defined", c.DefaultCity)
+	}
+	for name, city := range c.Cities {
+		if _, err := money.LookupCurrency(city.Currency); err != nil {
+			return fmt.Errorf("city %q: %w %q", name, err, city.Currency)
+		}
+		for class, card := range city.VehicleClasses {
+			if card.BaseFare < 0 || card.PerKm < 0 || card.PerMinute < 0 ||
+				card.MinimumFare < 0 || card.BookingFee < 0 || card.Tolls < 0 {
+				return fmt.Errorf("city %q vehicle class %q has a negative rate", name, class)
+			}
+		}
+	}
+	return nil
+}
+
+// Lookup returns the city a pickup is priced in and its rate card for the
+// vehicle class.
+func (c *RateCards) Lookup(lat, lon float64, class models.VehicleType) (string, CityRates, RateCard, error) {
+	// Check cities in name order so overlapping bounds resolve the same way
+	// every time.
+	names := make([]string, 0, len(c.Cities))
+	for cityName := range c.Cities {
+		names = append(names, cityName)
+	}
+	sort.Strings(names)
+
+	name := c.DefaultCity
+	for _, cityName := range names {
+		if b := c.Cities[cityName].Bounds; b != nil && b.Contains(lat, lon) {
+			name = cityName
+			break
+		}
+	}
+
+	city := c.Cities[name]
+	card, ok := city.VehicleClasses[class]
+	if !ok {
+		return "", CityRates{}, RateCard{}, fmt.Errorf("%w %q in %s", ErrNoRateCard, class, name)
+	}
+	return name, city, card, nil
+}
+
+// RateCardStore holds the current rate cards and reloads them when the file
+// on disk changes.
+type RateCardStore struct {
+	path string
+
+	mu      sync.RWMutex
+	cards   *RateCards
+	modTime time.Time
+}
+
+// NewRateCardStore loads the rate cards at path. It fails if the initial load
+// fails; later reload failures keep the previous cards.
+func NewRateCardStore(path string) (*RateCardStore, error) {
+	s := &RateCardStore{path: path}
+	if err := s.Reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *RateCardStore) Current() *RateCards {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cards
+}
+
+// Reload re-reads the rate card file.
+func (s *RateCardStore) Reload() error {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return err
+	}
+	cards, err := LoadRateCards(s.path)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.cards = cards
+	s.modTime = info.ModTime()
+	s.mu.Unlock()
+	return nil
+}
+
+// Watch polls the file every interval and reloads it when its modification
+// time changes, until ctx is done.
+func (s *RateCardStore) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			info, err := os.Stat(s.path)
+			if err != nil {
+				log.Printf("Failed to stat rate cards %s: %v", s.path, err)
+				continue
+			}
+			s.mu.RLock()
+			changed := !info.ModTime().Equal(s.modTime)
+			s.mu.RUnlock()
+			if !changed {
+				continue
+			}
+			if err := s.Reload(); err != nil {
+				log.Printf("Failed to reload rate cards, keeping previous ones: %v", err)
+				continue
+			}
+			log.Printf("Reloaded rate cards from %s", s.path)
+		}
+	}
+}
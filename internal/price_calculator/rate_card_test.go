@@ -0,0 +1,216 @@
+package pricecalculator
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lukabrx/uber-clone/internal/models"
+	"github.com/lukabrx/uber-clone/internal/money"
+)
+
+func TestApply(t *testing.T) {
+	card := RateCard{BaseFare: 250, PerKm: 150, PerMinute: 25, MinimumFare: 600, BookingFee: 150, Tolls: 655}
+	usd := func(minor int64) money.Money { return money.New(minor, "USD") }
+
+	tests := []struct {
+		name       string
+		distanceKm float64
+		duration   time.Duration
+		surge      float64
+		want       FareBreakdown
+	}{
+		{
+			// 3.333 km at 1.50 is 4.9995 and 7.5 minutes at 0.25 is 1.875;
+			// each line rounds half up to the cent on its own.
+			name:       "lines round half up",
+			distanceKm: 3.333,
+			duration:   7*time.Minute + 30*time.Second,
+			surge:      1,
+			want: FareBreakdown{
+				BaseFare: usd(250), DistanceFare: usd(500), TimeFare: usd(188),
+				MinimumFareAdjustment: usd(0), SurgeMultiplier: 1, Surge: usd(0),
+				BookingFee: usd(150), Tolls: usd(655), Total: usd(1743),
+			},
+		},
+		{
+			name:       "short ride topped up to the minimum",
+			distanceKm: 1,
+			duration:   2 * time.Minute,
+			surge:      1,
+			want: FareBreakdown{
+				BaseFare: usd(250), DistanceFare: usd(150), TimeFare: usd(50),
+				MinimumFareAdjustment: usd(150), SurgeMultiplier: 1, Surge: usd(0),
+				BookingFee: usd(150), Tolls: usd(655), Total: usd(1405),
+			},
+		},
+		{
+			// Surge scales the ride including the minimum fare adjustment,
+			// but not the booking fee or tolls.
+			name:       "surge on the ride only",
+			distanceKm: 1,
+			duration:   2 * time.Minute,
+			surge:      1.5,
+			want: FareBreakdown{
+				BaseFare: usd(250), DistanceFare: usd(150), TimeFare: usd(50),
+				MinimumFareAdjustment: usd(150), SurgeMultiplier: 1.5, Surge: usd(300),
+				BookingFee: usd(150), Tolls: usd(655), Total: usd(1705),
+			},
+		},
+		{
+			name:       "surge rounds half up",
+			distanceKm: 3.333,
+			duration:   7*time.Minute + 30*time.Second,
+			surge:      1.25,
+			want: FareBreakdown{
+				BaseFare: usd(250), DistanceFare: usd(500), TimeFare: usd(188),
+				MinimumFareAdjustment: usd(0), SurgeMultiplier: 1.25, Surge: usd(235),
+				BookingFee: usd(150), Tolls: usd(655), Total: usd(1978),
+			},
+		},
+		{
+			name:       "multiplier below one is ignored",
+			distanceKm: 10,
+			duration:   20 * time.Minute,
+			surge:      0.5,
+			want: FareBreakdown{
+				BaseFare: usd(250), DistanceFare: usd(1500), TimeFare: usd(500),
+				MinimumFareAdjustment: usd(0), SurgeMultiplier: 1, Surge: usd(0),
+				BookingFee: usd(150), Tolls: usd(655), Total: usd(3055),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := card.Apply("belgrade", models.VehicleEconomy, "USD", tt.distanceKm, tt.duration, tt.surge)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.want.City = "belgrade"
+			tt.want.VehicleType = models.VehicleEconomy
+			tt.want.DistanceKm = tt.distanceKm
+			tt.want.Duration = tt.duration
+			if got != tt.want {
+				t.Errorf("Apply =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := card.Apply("belgrade", models.VehicleEconomy, "XXX", 1, time.Minute, 1); !errors.Is(err, money.ErrUnknownCurrency) {
+		t.Errorf("Apply in XXX: err = %v, want %v", err, money.ErrUnknownCurrency)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	cards, err := LoadRateCards("../../configs/rate_cards.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		lat, lon float64
+		class    models.VehicleType
+		wantCity string
+		wantCard RateCard
+	}{
+		{"inside a city", 44.8, 20.46, models.VehicleComfort, "belgrade", cards.Cities["belgrade"].VehicleClasses[models.VehicleComfort]},
+		{"outside every city", 51.5, -0.12, models.VehicleXL, "default", cards.Cities["default"].VehicleClasses[models.VehicleXL]},
+	}
+	for _, tt := range tests {
+		city, rates, card, err := cards.Lookup(tt.lat, tt.lon, tt.class)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if city != tt.wantCity || rates.Currency != "USD" || card != tt.wantCard {
+			t.Errorf("%s: Lookup = %s, %s, %+v; want %s, USD, %+v", tt.name, city, rates.Currency, card, tt.wantCity, tt.wantCard)
+		}
+	}
+
+	if _, _, _, err := cards.Lookup(44.8, 20.46, "helicopter"); !errors.Is(err, ErrNoRateCard) {
+		t.Errorf("unknown class: err = %v, want %v", err, ErrNoRateCard)
+	}
+}
+
+const (
+	cheapCards = `{"default_city":"x","cities":{"x":{"currency":"USD","vehicle_classes":{"economy":{"base_fare":100}}}}}`
+	dearCards  = `{"default_city":"x","cities":{"x":{"currency":"USD","vehicle_classes":{"economy":{"base_fare":900}}}}}`
+)
+
+// writeCards writes a rate card file and sets its modification time.
+func writeCards(t *testing.T, path, contents string, modTime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func baseFare(s *RateCardStore) int64 {
+	return s.Current().Cities["x"].VehicleClasses[models.VehicleEconomy].BaseFare
+}
+
+func TestRateCardStoreKeepsCardsOnInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rate_cards.json")
+	start := time.Now().Add(-time.Hour)
+	writeCards(t, path, cheapCards, start)
+	store, err := NewRateCardStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	invalid := []string{
+		`{"default_city":`,
+		`{"default_city":"missing","cities":{}}`,
+		`{"default_city":"x","cities":{"x":{"currency":"XXX"}}}`,
+		`{"default_city":"x","cities":{"x":{"currency":"USD","vehicle_classes":{"economy":{"per_km":-1}}}}}`,
+	}
+	for i, contents := range invalid {
+		writeCards(t, path, contents, start.Add(time.Duration(i+1)*time.Minute))
+		if err := store.Reload(); err == nil {
+			t.Errorf("Reload of %s succeeded, want an error", contents)
+		}
+		if got := baseFare(store); got != 100 {
+			t.Errorf("after loading %s base fare = %d, want the previous 100", contents, got)
+		}
+	}
+
+	if _, err := NewRateCardStore(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("NewRateCardStore of a missing file succeeded, want an error")
+	}
+}
+
+func TestRateCardStoreWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rate_cards.json")
+	start := time.Now().Add(-time.Hour)
+	writeCards(t, path, cheapCards, start)
+	store, err := NewRateCardStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go store.Watch(ctx, 10*time.Millisecond)
+
+	// New contents under the old modification time are not noticed.
+	writeCards(t, path, dearCards, start)
+	time.Sleep(100 * time.Millisecond)
+	if got := baseFare(store); got != 100 {
+		t.Fatalf("base fare = %d with the modification time unchanged, want 100", got)
+	}
+
+	// Touching the file picks them up.
+	writeCards(t, path, dearCards, start.Add(time.Minute))
+	deadline := time.Now().Add(5 * time.Second)
+	for baseFare(store) != 900 {
+		if time.Now().After(deadline) {
+			t.Fatal("rate cards were not reloaded after the file changed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,40 @@
+package pricecalculator
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Route is a driving route between two points.
+type Route struct {
+	DistanceKm float64
+	Duration   time.Duration
+	// Polyline is the route geometry in Google's encoded polyline format
+	// (precision 5). It may be a straight line for offline routers.
+	Polyline string
+}
+
+// Router computes driving routes. Implementations must honour ctx cancellation.
+type Router interface {
+	Route(ctx context.Context, startLat, startLon, endLat, endLon float64) (Route, error)
+}
+
+// FallbackRouter asks Primary first and falls back to Secondary when it fails,
+// so an unreachable routing provider doesn't stop trips from being priced.
+type FallbackRouter struct {
+	Primary   Router
+	Secondary Router
+}
+
+func (r FallbackRouter) Route(ctx context.Context, startLat, startLon, endLat, endLon float64) (Route, error) {
+	route, err := r.Primary.Route(ctx, startLat, startLon, endLat, endLon)
+	if err == nil {
+		return route, nil
+	}
+	if ctx.Err() != nil {
+		return Route{}, ctx.Err()
+	}
+	log.Printf("Primary router failed, falling back: %v", err)
+	return r.Secondary.Route(ctx, startLat, startLon, endLat, endLon)
+}
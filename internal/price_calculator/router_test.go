@@ -0,0 +1,65 @@
+package pricecalculator
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestHaversineRoute(t *testing.T) {
+	route, err := DefaultHaversineRouter.Route(context.Background(), 38.5, -120.2, 40.7, -120.95)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// About 253 km as the crow flies.
+	if math.Abs(route.DistanceKm-253.1*1.3) > 0.5 {
+		t.Errorf("DistanceKm = %v, want about %v", route.DistanceKm, 253.1*1.3)
+	}
+	wantDuration := time.Duration(route.DistanceKm / 30 * float64(time.Hour))
+	if route.Duration != wantDuration {
+		t.Errorf("Duration = %v, want %v", route.Duration, wantDuration)
+	}
+	// The first two points of Google's polyline example.
+	if want := "_p~iF~ps|U_ulLnnqC"; route.Polyline != want {
+		t.Errorf("Polyline = %q, want %q", route.Polyline, want)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := DefaultHaversineRouter.Route(ctx, 38.5, -120.2, 40.7, -120.95); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled Route: err = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestFallbackRouter(t *testing.T) {
+	down := osrmServer(t, time.Second, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	up := osrmServer(t, time.Second, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"code":"Ok","routes":[{"distance":1000,"duration":60,"geometry":"abc"}]}`))
+	})
+	offline, err := DefaultHaversineRouter.Route(context.Background(), 44.7872, 20.4573, 44.8, 20.4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	route, err := FallbackRouter{Primary: up, Secondary: DefaultHaversineRouter}.Route(context.Background(), 44.7872, 20.4573, 44.8, 20.4)
+	if err != nil || route.Polyline != "abc" {
+		t.Errorf("with OSRM up: Route = %+v, %v; want the OSRM route", route, err)
+	}
+
+	route, err = FallbackRouter{Primary: down, Secondary: DefaultHaversineRouter}.Route(context.Background(), 44.7872, 20.4573, 44.8, 20.4)
+	if err != nil || route != offline {
+		t.Errorf("with OSRM down: Route = %+v, %v; want the haversine route %+v", route, err, offline)
+	}
+
+	// A caller that gave up is not answered from the fallback.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := (FallbackRouter{Primary: down, Secondary: DefaultHaversineRouter}).Route(ctx, 44.7872, 20.4573, 44.8, 20.4); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled Route: err = %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,213 @@
+package pricecalculator
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/lukabrx/uber-clone/internal/geo"
+	"github.com/lukabrx/uber-clone/internal/models"
+)
+
+// SurgeConfig tunes how demand in a zone turns into a price multiplier.
+type SurgeConfig struct {
+	// ZoneSizeDeg is the side of a surge zone in degrees (0.02° is roughly 2 km).
+	ZoneSizeDeg float64
+	// Sensitivity is how much the multiplier rises for every open request per
+	// available driver above one.
+	Sensitivity float64
+	// Smoothing is the weight, between 0 and 1, given to the newly computed
+	// target on every update. Lower values react more slowly.
+	Smoothing float64
+	// MaxMultiplier caps the multiplier. It never drops below 1.
+	MaxMultiplier float64
+	// Step is what published multipliers are rounded to, so prices don't
+	// flicker with every update.
+	Step float64
+	// UpdateInterval is how often multipliers are recomputed.
+	UpdateInterval time.Duration
+	// RequestTTL drops open requests we never saw close, e.g. after missed events.
+	RequestTTL time.Duration
+}
+
+var DefaultSurgeConfig = SurgeConfig{
+	ZoneSizeDeg:    0.02,
+	Sensitivity:    0.5,
+	Smoothing:      0.3,
+	MaxMultiplier:  3,
+	Step:           0.1,
+	UpdateInterval: 15 * time.Second,
+	RequestTTL:     15 * time.Minute,
+}
+
+// Surge is the multiplier in force for a zone.
+type Surge struct {
+	ZoneID     string  `json:"zone_id"`
+	Multiplier float64 `json:"multiplier"`
+	// Demand and Supply are the open requests and available drivers the
+	// multiplier was last computed from.
+	Demand int `json:"demand"`
+	Supply int `json:"supply"`
+}
+
+type openRequest struct {
+	zone     geo.Cell
+	openedAt time.Time
+}
+
+// SurgeEngine counts open trip requests against available drivers per zone and
+// turns the ratio into a smoothed, capped multiplier per zone.
+type SurgeEngine struct {
+	config SurgeConfig
+
+	mu       sync.RWMutex
+	requests map[string]openRequest // by trip ID
+	drivers  map[string]geo.Cell    // available drivers by ID
+	// smoothed holds the unrounded multiplier of every zone above 1.
+	smoothed map[geo.Cell]float64
+	surges   map[geo.Cell]Surge
+}
+
+func NewSurgeEngine(config SurgeConfig) *SurgeEngine {
+	return &SurgeEngine{
+		config:   config,
+		requests: make(map[string]openRequest),
+		drivers:  make(map[string]geo.Cell),
+		smoothed: make(map[geo.Cell]float64),
+		surges:   make(map[geo.Cell]Surge),
+	}
+}
+
+// TripRequested counts a new trip request as demand in its pickup zone.
+func (e *SurgeEngine) TripRequested(tripID string, lat, lon float64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.requests[tripID] = openRequest{zone: e.zoneOf(lat, lon), openedAt: time.Now()}
+}
+
+// TripClosed stops counting a request, once a driver is assigned or it is cancelled.
+func (e *SurgeEngine) TripClosed(tripID string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.requests, tripID)
+}
+
+// DriverUpdated counts the driver as supply in their current zone while they
+// are available.
+func (e *SurgeEngine) DriverUpdated(d models.Driver) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !d.IsAvailable {
+		delete(e.drivers, d.ID)
+		return
+	}
+	e.drivers[d.ID] = e.zoneOf(d.Lat, d.Lon)
+}
+
+// SurgeAt returns the surge in force at a point.
+func (e *SurgeEngine) SurgeAt(lat, lon float64) Surge {
+	zone := e.zoneOf(lat, lon)
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if s, ok := e.surges[zone]; ok {
+		return s
+	}
+	return Surge{ZoneID: zoneID(zone), Multiplier: 1}
+}
+
+// Multiplier returns the surge multiplier at a point.
+func (e *SurgeEngine) Multiplier(lat, lon float64) float64 {
+	return e.SurgeAt(lat, lon).Multiplier
+}
+
+// Run recomputes multipliers every UpdateInterval until ctx is done.
+func (e *SurgeEngine) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.config.UpdateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			e.Update(time.Now())
+		}
+	}
+}
+
+// Update recomputes every zone's multiplier from the current counts.
+func (e *SurgeEngine) Update(now time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	demand := make(map[geo.Cell]int)
+	for tripID, req := range e.requests {
+		if now.Sub(req.openedAt) > e.config.RequestTTL {
+			delete(e.requests, tripID)
+			continue
+		}
+		demand[req.zone]++
+	}
+	supply := make(map[geo.Cell]int)
+	for _, zone := range e.drivers {
+		supply[zone]++
+	}
+
+	// Zones without demand still need updating while they decay back to 1.
+	zones := make(map[geo.Cell]bool, len(demand)+len(e.smoothed))
+	for zone := range demand {
+		zones[zone] = true
+	}
+	for zone := range e.smoothed {
+		zones[zone] = true
+	}
+
+	surges := make(map[geo.Cell]Surge, len(zones))
+	for zone := range zones {
+		target := e.target(demand[zone], supply[zone])
+		prev, ok := e.smoothed[zone]
+		if !ok {
+			prev = 1
+		}
+		next := prev + e.config.Smoothing*(target-prev)
+
+		multiplier := e.round(next)
+		if multiplier <= 1 {
+			delete(e.smoothed, zone)
+			continue
+		}
+		e.smoothed[zone] = next
+		surges[zone] = Surge{
+			ZoneID:     zoneID(zone),
+			Multiplier: multiplier,
+			Demand:     demand[zone],
+			Supply:     supply[zone],
+		}
+	}
+	e.surges = surges
+}
+
+// target is the multiplier the zone is moving towards. A zone with no drivers
+// is treated as having one so a single request doesn't hit the cap.
+func (e *SurgeEngine) target(demand, supply int) float64 {
+	ratio := float64(demand) / float64(max(supply, 1))
+	return math.Min(math.Max(1+e.config.Sensitivity*(ratio-1), 1), e.config.MaxMultiplier)
+}
+
+func (e *SurgeEngine) round(m float64) float64 {
+	if e.config.Step <= 0 {
+		return m
+	}
+	// Round again to drop float noise like 1.2000000000000002.
+	return math.Round(math.Round(m/e.config.Step)*e.config.Step*100) / 100
+}
+
+func (e *SurgeEngine) zoneOf(lat, lon float64) geo.Cell {
+	return geo.CellOf(lat, lon, e.config.ZoneSizeDeg)
+}
+
+func zoneID(zone geo.Cell) string {
+	return fmt.Sprintf("%d:%d", zone.Row, zone.Col)
+}
@@ -0,0 +1,130 @@
+package pricecalculator
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/lukabrx/uber-clone/internal/models"
+)
+
+var testSurgeConfig = SurgeConfig{
+	ZoneSizeDeg:   0.02,
+	Sensitivity:   0.5,
+	Smoothing:     0.5,
+	MaxMultiplier: 3,
+	Step:          0.1,
+	RequestTTL:    15 * time.Minute,
+}
+
+// A pickup in central Belgrade and one in a zone well away from it.
+const (
+	pickupLat, pickupLon = 44.8125, 20.4612
+	farLat, farLon       = 44.7, 20.3
+)
+
+func TestSurgeTarget(t *testing.T) {
+	e := NewSurgeEngine(testSurgeConfig)
+	tests := []struct {
+		demand, supply int
+		want           float64
+	}{
+		{0, 0, 1},
+		{0, 5, 1},
+		{2, 4, 1},
+		{4, 4, 1},
+		// With no drivers a zone counts as having one.
+		{1, 0, 1},
+		{2, 0, 1.5},
+		{3, 1, 2},
+		{6, 4, 1.25},
+		{5, 1, 3},
+		{50, 1, 3},
+	}
+	for _, tt := range tests {
+		if got := e.target(tt.demand, tt.supply); got != tt.want {
+			t.Errorf("target(%d, %d) = %v, want %v", tt.demand, tt.supply, got, tt.want)
+		}
+	}
+}
+
+// multipliers runs n updates and returns the multiplier at the pickup after each.
+func multipliers(e *SurgeEngine, n int, now time.Time) []float64 {
+	var got []float64
+	for range n {
+		e.Update(now)
+		got = append(got, e.Multiplier(pickupLat, pickupLon))
+	}
+	return got
+}
+
+func TestSurgeSmoothsAndDecays(t *testing.T) {
+	e := NewSurgeEngine(testSurgeConfig)
+	now := time.Now()
+	e.DriverUpdated(models.Driver{ID: "driver-1", Lat: pickupLat, Lon: pickupLon, IsAvailable: true})
+	// Busy drivers are not supply.
+	e.DriverUpdated(models.Driver{ID: "driver-2", Lat: pickupLat, Lon: pickupLon, IsAvailable: false})
+	for i := range 5 {
+		e.TripRequested(fmt.Sprintf("trip-%d", i), pickupLat, pickupLon)
+	}
+
+	// Five requests for one driver aims at the cap of 3, halving the gap on
+	// every update: 2, 2.5, 2.75, 2.875, 2.9375.
+	if got, want := multipliers(e, 5, now), []float64{2, 2.5, 2.8, 2.9, 2.9}; !slices.Equal(got, want) {
+		t.Fatalf("rising multipliers = %v, want %v", got, want)
+	}
+	if s := e.SurgeAt(pickupLat, pickupLon); s.Demand != 5 || s.Supply != 1 {
+		t.Errorf("surge counted %d requests and %d drivers, want 5 and 1", s.Demand, s.Supply)
+	}
+	if got := e.Multiplier(farLat, farLon); got != 1 {
+		t.Errorf("multiplier in another zone = %v, want 1", got)
+	}
+
+	// Once every request is served the zone decays back to 1 and is dropped.
+	for i := range 5 {
+		e.TripClosed(fmt.Sprintf("trip-%d", i))
+	}
+	if got, want := multipliers(e, 7, now), []float64{2, 1.5, 1.2, 1.1, 1.1, 1, 1}; !slices.Equal(got, want) {
+		t.Fatalf("decaying multipliers = %v, want %v", got, want)
+	}
+	if len(e.smoothed) != 0 || len(e.surges) != 0 {
+		t.Errorf("zones still tracked after decaying: %v", e.smoothed)
+	}
+}
+
+func TestSurgeCap(t *testing.T) {
+	config := testSurgeConfig
+	config.Smoothing = 1
+	config.MaxMultiplier = 2.5
+	e := NewSurgeEngine(config)
+	for i := range 100 {
+		e.TripRequested(fmt.Sprintf("trip-%d", i), pickupLat, pickupLon)
+	}
+	if got := multipliers(e, 3, time.Now()); !slices.Equal(got, []float64{2.5, 2.5, 2.5}) {
+		t.Errorf("multipliers = %v, want capped at 2.5", got)
+	}
+}
+
+func TestSurgeRequestTTL(t *testing.T) {
+	config := testSurgeConfig
+	config.Smoothing = 1
+	e := NewSurgeEngine(config)
+	e.TripRequested("trip-1", pickupLat, pickupLon)
+	e.TripRequested("trip-2", pickupLat, pickupLon)
+	e.TripRequested("trip-3", pickupLat, pickupLon)
+
+	e.Update(time.Now().Add(config.RequestTTL - time.Minute))
+	if got := e.Multiplier(pickupLat, pickupLon); got != 2 {
+		t.Fatalf("multiplier before the TTL = %v, want 2", got)
+	}
+
+	// Requests we never saw close stop counting once they are older than the TTL.
+	e.Update(time.Now().Add(config.RequestTTL + time.Minute))
+	if got := e.Multiplier(pickupLat, pickupLon); got != 1 {
+		t.Errorf("multiplier after the TTL = %v, want 1", got)
+	}
+	if len(e.requests) != 0 {
+		t.Errorf("%d expired requests kept", len(e.requests))
+	}
+}
@@ -0,0 +1,62 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lukabrx/uber-clone/internal/auth"
+)
+
+// RunRefreshTokenStore checks the auth.RefreshTokenStore contract.
+// Stores that reference users must accept the user ID "user-1".
+func RunRefreshTokenStore(t *testing.T, newStore func(t *testing.T) auth.RefreshTokenStore) {
+	ctx := context.Background()
+
+	t.Run("StoreAndGet", func(t *testing.T) {
+		store := newStore(t)
+		token := auth.RefreshToken{Token: "token-1", UserID: "user-1", ExpiresAt: time.Now().Add(time.Hour)}
+		if err := store.Store(ctx, token); err != nil {
+			t.Fatal(err)
+		}
+		got, err := store.Get(ctx, token.Token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.UserID != token.UserID || !sameTime(got.ExpiresAt, token.ExpiresAt) {
+			t.Fatalf("Get = %+v, want %+v", got, token)
+		}
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		_, err := newStore(t).Get(ctx, "missing")
+		wantErr(t, err, auth.ErrInvalidRefreshToken)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		store := newStore(t)
+		token := auth.RefreshToken{Token: "token-1", UserID: "user-1", ExpiresAt: time.Now().Add(-time.Minute)}
+		if err := store.Store(ctx, token); err != nil {
+			t.Fatal(err)
+		}
+		_, err := store.Get(ctx, token.Token)
+		wantErr(t, err, auth.ErrInvalidRefreshToken)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		store := newStore(t)
+		token := auth.RefreshToken{Token: "token-1", UserID: "user-1", ExpiresAt: time.Now().Add(time.Hour)}
+		if err := store.Store(ctx, token); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.Delete(ctx, token.Token); err != nil {
+			t.Fatal(err)
+		}
+		_, err := store.Get(ctx, token.Token)
+		wantErr(t, err, auth.ErrInvalidRefreshToken)
+
+		if err := store.Delete(ctx, "missing"); err != nil {
+			t.Fatalf("deleting an unknown token: %v", err)
+		}
+	})
+}
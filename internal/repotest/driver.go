@@ -0,0 +1,312 @@
+package repotest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lukabrx/uber-clone/internal/driver"
+	"github.com/lukabrx/uber-clone/internal/models"
+	"github.com/lukabrx/uber-clone/internal/types"
+)
+
+// RunDriverRepository checks the driver.DriverRepository contract.
+func RunDriverRepository(t *testing.T, newRepo func(t *testing.T) driver.DriverRepository) {
+	ctx := context.Background()
+
+	register := func(t *testing.T, repo driver.DriverRepository, d models.Driver) models.Driver {
+		t.Helper()
+		registered, err := repo.RegisterDriver(ctx, d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return registered
+	}
+
+	t.Run("RegisterAndGet", func(t *testing.T) {
+		repo := newRepo(t)
+		d := register(t, repo, models.Driver{Name: "Marko", Lat: 44.81, Lon: 20.46})
+		if d.ID == "" || !d.IsAvailable || d.VehicleType != models.VehicleEconomy {
+			t.Fatalf("RegisterDriver = %+v, want an available economy driver with an ID", d)
+		}
+		got, err := repo.GetDriverByID(ctx, d.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Name != d.Name || got.Lat != d.Lat || got.Lon != d.Lon || !got.IsAvailable {
+			t.Fatalf("GetDriverByID = %+v, want %+v", *got, d)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		repo := newRepo(t)
+		_, err := repo.GetDriverByID(ctx, "missing")
+		wantErr(t, err, driver.ErrDriverNotFound)
+		_, err = repo.IsDriverAvailable(ctx, "missing")
+		wantErr(t, err, driver.ErrDriverNotFound)
+		wantErr(t, repo.UpdateDriverStatus(ctx, "missing", true), driver.ErrDriverNotFound)
+		_, err = repo.ReserveDriver(ctx, "missing", "trip-1")
+		wantErr(t, err, driver.ErrDriverNotFound)
+		_, err = repo.GetOfferStats(ctx, "missing")
+		wantErr(t, err, driver.ErrDriverNotFound)
+	})
+
+	t.Run("GetByUser", func(t *testing.T) {
+		repo := newRepo(t)
+		register(t, repo, models.Driver{Name: "no account"})
+		d := register(t, repo, models.Driver{Name: "Marko", UserID: "user-1"})
+
+		got, err := repo.GetDriverByUserID(ctx, "user-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.ID != d.ID || got.UserID != "user-1" {
+			t.Fatalf("GetDriverByUserID = %+v, want %+v", got, d)
+		}
+		_, err = repo.RegisterDriver(ctx, models.Driver{Name: "Marko again", UserID: "user-1"})
+		wantErr(t, err, driver.ErrUserHasDriver)
+		_, err = repo.GetDriverByUserID(ctx, "user-2")
+		wantErr(t, err, driver.ErrDriverNotFound)
+		_, err = repo.GetDriverByUserID(ctx, "")
+		wantErr(t, err, driver.ErrDriverNotFound)
+	})
+
+	t.Run("UpdateDriverStatus", func(t *testing.T) {
+		repo := newRepo(t)
+		d := register(t, repo, models.Driver{Name: "Marko"})
+		if err := repo.UpdateDriverStatus(ctx, d.ID, false); err != nil {
+			t.Fatal(err)
+		}
+		available, err := repo.IsDriverAvailable(ctx, d.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if available {
+			t.Fatal("driver still available")
+		}
+	})
+
+	t.Run("StaleLocation", func(t *testing.T) {
+		repo := newRepo(t)
+		d := register(t, repo, models.Driver{Name: "Marko"})
+		now := time.Now()
+		moved, err := repo.UpdateDriverLocation(ctx, models.LocationPing{DriverID: d.ID, Lat: 1, Lon: 2, Timestamp: now.Add(time.Second)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if moved.Lat != 1 || moved.Lon != 2 {
+			t.Fatalf("UpdateDriverLocation = %+v, want it at 1,2", moved)
+		}
+		_, err = repo.UpdateDriverLocation(ctx, models.LocationPing{DriverID: d.ID, Lat: 3, Lon: 4, Timestamp: now})
+		wantErr(t, err, driver.ErrStaleLocation)
+	})
+
+	t.Run("ReserveAndRelease", func(t *testing.T) {
+		repo := newRepo(t)
+		d := register(t, repo, models.Driver{Name: "Marko"})
+
+		reserved, err := repo.ReserveDriver(ctx, d.ID, "trip-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reserved.IsAvailable || reserved.TripID != "trip-1" {
+			t.Fatalf("ReserveDriver = %+v, want it reserved for trip-1", reserved)
+		}
+		if _, err := repo.ReserveDriver(ctx, d.ID, "trip-1"); err != nil {
+			t.Fatalf("reserving again for the same trip: %v", err)
+		}
+		_, err = repo.ReserveDriver(ctx, d.ID, "trip-2")
+		wantErr(t, err, driver.ErrDriverReserved)
+		_, err = repo.ReleaseDriver(ctx, d.ID, "trip-2")
+		wantErr(t, err, driver.ErrDriverReserved)
+
+		released, err := repo.ReleaseDriver(ctx, d.ID, "trip-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !released.IsAvailable || released.TripID != "" {
+			t.Fatalf("ReleaseDriver = %+v, want it available", released)
+		}
+		if _, err := repo.ReleaseDriver(ctx, d.ID, "trip-1"); err != nil {
+			t.Fatalf("releasing a free driver: %v", err)
+		}
+	})
+
+	t.Run("StatusWhileReserved", func(t *testing.T) {
+		repo := newRepo(t)
+		d := register(t, repo, models.Driver{Name: "Marko"})
+		if _, err := repo.ReserveDriver(ctx, d.ID, "trip-1"); err != nil {
+			t.Fatal(err)
+		}
+		wantErr(t, repo.UpdateDriverStatus(ctx, d.ID, true), driver.ErrDriverReserved)
+		wantErr(t, repo.UpdateDriverStatus(ctx, d.ID, false), driver.ErrDriverReserved)
+		_, err := repo.ReserveDriver(ctx, d.ID, "trip-2")
+		wantErr(t, err, driver.ErrDriverReserved)
+	})
+
+	t.Run("ReserveUnavailable", func(t *testing.T) {
+		repo := newRepo(t)
+		d := register(t, repo, models.Driver{Name: "Marko"})
+		if err := repo.UpdateDriverStatus(ctx, d.ID, false); err != nil {
+			t.Fatal(err)
+		}
+		_, err := repo.ReserveDriver(ctx, d.ID, "trip-1")
+		wantErr(t, err, driver.ErrDriverUnavailable)
+	})
+
+	t.Run("ConcurrentReserve", func(t *testing.T) {
+		repo := newRepo(t)
+		d := register(t, repo, models.Driver{Name: "Marko"})
+		won := race(10, func(i int) error {
+			_, err := repo.ReserveDriver(ctx, d.ID, fmt.Sprintf("trip-%d", i))
+			return err
+		})
+		if won != 1 {
+			t.Fatalf("%d concurrent reservations succeeded, want 1", won)
+		}
+	})
+
+	t.Run("FindNearby", func(t *testing.T) {
+		repo := newRepo(t)
+		// Roughly 1, 3 and 8 km north of the query point.
+		near := register(t, repo, models.Driver{Name: "near", Lat: 0.009, Lon: 0})
+		mid := register(t, repo, models.Driver{Name: "mid", Lat: 0.027, Lon: 0, VehicleType: models.VehicleXL})
+		far := register(t, repo, models.Driver{Name: "far", Lat: 0.072, Lon: 0})
+		busy := register(t, repo, models.Driver{Name: "busy", Lat: 0.001, Lon: 0})
+		if err := repo.UpdateDriverStatus(ctx, busy.ID, false); err != nil {
+			t.Fatal(err)
+		}
+
+		ids := func(nearby []driver.NearbyDriver, err error) []string {
+			t.Helper()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var ids []string
+			for _, n := range nearby {
+				ids = append(ids, n.Driver.ID)
+			}
+			return ids
+		}
+		check := func(name string, got []string, want ...string) {
+			t.Helper()
+			if len(got) != len(want) {
+				t.Fatalf("%s: got %v, want %v", name, got, want)
+			}
+			for i := range got {
+				if got[i] != want[i] {
+					t.Fatalf("%s: got %v, want %v", name, got, want)
+				}
+			}
+		}
+
+		check("no radius or limit", ids(repo.FindNearbyAvailableDrivers(ctx, 0, 0, 0, 0, nil)), near.ID, mid.ID, far.ID)
+		check("5 km radius", ids(repo.FindNearbyAvailableDrivers(ctx, 0, 0, 5, 0, nil)), near.ID, mid.ID)
+		check("limit 1", ids(repo.FindNearbyAvailableDrivers(ctx, 0, 0, 0, 1, nil)), near.ID)
+		check("xl only", ids(repo.FindNearbyAvailableDrivers(ctx, 0, 0, 10, 0, []models.VehicleType{models.VehicleXL})), mid.ID)
+
+		nearby, err := repo.FindNearbyAvailableDrivers(ctx, 0, 0, 5, 1, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d := nearby[0].DistanceKm; d < 0.9 || d > 1.1 {
+			t.Fatalf("distance to the nearest driver = %.3f km, want about 1", d)
+		}
+	})
+
+	t.Run("OfferStats", func(t *testing.T) {
+		repo := newRepo(t)
+		d := register(t, repo, models.Driver{Name: "Marko"})
+		for _, outcome := range []driver.OfferOutcome{driver.OfferAccepted, driver.OfferDeclined, driver.OfferExpired, driver.OfferAccepted, driver.OfferWithdrawn} {
+			if err := repo.RecordOfferOutcome(ctx, d.ID, outcome); err != nil {
+				t.Fatal(err)
+			}
+		}
+		stats, err := repo.GetOfferStats(ctx, d.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := driver.OfferStats{DriverID: d.ID, Offered: 4, Accepted: 2, Declined: 1, Expired: 1}
+		if stats != want {
+			t.Fatalf("GetOfferStats = %+v, want %+v", stats, want)
+		}
+	})
+}
+
+// RunProcessedEvents checks the driver.ProcessedEvents contract.
+func RunProcessedEvents(t *testing.T, newStore func(t *testing.T) driver.ProcessedEvents) {
+	ctx := context.Background()
+
+	// handle checks event and marks it if it is not to be skipped, like the
+	// driver event consumer does.
+	handle := func(t *testing.T, store driver.ProcessedEvents, event types.TripEvent) error {
+		t.Helper()
+		if err := store.Check(ctx, event); err != nil {
+			return err
+		}
+		if err := store.Mark(ctx, event); err != nil {
+			t.Fatal(err)
+		}
+		return nil
+	}
+	event := func(id string, version int64, tripID string) types.TripEvent {
+		return types.TripEvent{EventID: id, Version: version, TripID: tripID, EventType: types.TripStartedEvent}
+	}
+
+	t.Run("SkipsDuplicates", func(t *testing.T) {
+		store := newStore(t)
+		if err := handle(t, store, event("e1", 1, "trip-1")); err != nil {
+			t.Fatal(err)
+		}
+		wantErr(t, store.Check(ctx, event("e1", 1, "trip-1")), driver.ErrDuplicateEvent)
+		// Checking alone does not mark an event.
+		if err := store.Check(ctx, event("e2", 2, "trip-1")); err != nil {
+			t.Fatal(err)
+		}
+		if err := handle(t, store, event("e2", 2, "trip-1")); err != nil {
+			t.Fatal(err)
+		}
+		wantErr(t, store.Check(ctx, event("e2", 2, "trip-1")), driver.ErrDuplicateEvent)
+	})
+
+	t.Run("SkipsStaleVersions", func(t *testing.T) {
+		store := newStore(t)
+		if err := handle(t, store, event("e3", 3, "trip-1")); err != nil {
+			t.Fatal(err)
+		}
+		// An older event delivered late, and another event claiming the same
+		// version.
+		wantErr(t, store.Check(ctx, event("e1", 1, "trip-1")), driver.ErrStaleEvent)
+		wantErr(t, store.Check(ctx, event("e3-again", 3, "trip-1")), driver.ErrStaleEvent)
+		if err := handle(t, store, event("e5", 5, "trip-1")); err != nil {
+			t.Fatal(err)
+		}
+		wantErr(t, store.Check(ctx, event("e4", 4, "trip-1")), driver.ErrStaleEvent)
+	})
+
+	t.Run("TripsAreIndependent", func(t *testing.T) {
+		store := newStore(t)
+		if err := handle(t, store, event("e5", 5, "trip-1")); err != nil {
+			t.Fatal(err)
+		}
+		if err := handle(t, store, event("other-e1", 1, "trip-2")); err != nil {
+			t.Fatalf("first event of another trip: %v", err)
+		}
+	})
+
+	t.Run("UnversionedEvents", func(t *testing.T) {
+		store := newStore(t)
+		if err := handle(t, store, event("e2", 2, "trip-1")); err != nil {
+			t.Fatal(err)
+		}
+		// Events from before versions existed are only checked by ID.
+		if err := handle(t, store, event("legacy", 0, "trip-1")); err != nil {
+			t.Fatal(err)
+		}
+		wantErr(t, store.Check(ctx, event("legacy", 0, "trip-1")), driver.ErrDuplicateEvent)
+		if err := store.Check(ctx, event("legacy-2", 0, "trip-1")); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
@@ -0,0 +1,65 @@
+package repotest
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/lukabrx/uber-clone/internal/database"
+	"github.com/lukabrx/uber-clone/migrations"
+)
+
+// PostgresURLEnv names the database Postgres implementations are tested
+// against. Their tests are skipped when it is not set.
+const PostgresURLEnv = "TEST_DATABASE_URL"
+
+// Postgres returns a pool on a new schema of the test database with every
+// migration applied. The schema is dropped when the test ends, so no two tests
+// see each other's rows, even when packages are tested in parallel.
+func Postgres(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+	url := os.Getenv(PostgresURLEnv)
+	if url == "" {
+		t.Skipf("%s is not set", PostgresURLEnv)
+	}
+	ctx := context.Background()
+
+	admin, err := database.Connect(ctx, url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(admin.Close)
+
+	schema := "repotest_" + strings.ReplaceAll(uuid.New().String(), "-", "")
+	if _, err := admin.Exec(ctx, "CREATE SCHEMA "+schema); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if _, err := admin.Exec(context.Background(), "DROP SCHEMA "+schema+" CASCADE"); err != nil {
+			t.Errorf("drop schema %s: %v", schema, err)
+		}
+	})
+
+	cfg, err := pgxpool.ParseConfig(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.ConnConfig.RuntimeParams["search_path"] = schema
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+
+	migrator, err := database.NewMigrator(pool, migrations.FS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+	return pool
+}
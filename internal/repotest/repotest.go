@@ -0,0 +1,54 @@
+// Package repotest is the contract every repository implementation must meet.
+// Call its functions from a test in the implementation's package, e.g.
+//
+//	func TestMemoryRepository(t *testing.T) {
+//		repotest.RunTripRepository(t, func(t *testing.T) trip.TripRepository {
+//			return trip.NewMemoryRepository(outbox.NewMemoryStore())
+//		})
+//	}
+//
+// Each subtest asks for a fresh repository, which must start out empty.
+// Postgres implementations can get one from a pool returned by Postgres.
+package repotest
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// race runs f from n goroutines at once, passing each its index, and returns
+// how many calls succeeded.
+func race(n int, f func(i int) error) int {
+	var wg sync.WaitGroup
+	var ok atomic.Int32
+	start := make(chan struct{})
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			if f(i) == nil {
+				ok.Add(1)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+	return int(ok.Load())
+}
+
+func wantErr(t *testing.T, err, want error) {
+	t.Helper()
+	if !errors.Is(err, want) {
+		t.Fatalf("got error %v, want %v", err, want)
+	}
+}
+
+// sameTime compares timestamps to the microsecond, the precision databases
+// such as Postgres keep.
+func sameTime(a, b time.Time) bool {
+	return a.Truncate(time.Microsecond).Equal(b.Truncate(time.Microsecond))
+}
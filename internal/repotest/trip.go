@@ -0,0 +1,276 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lukabrx/uber-clone/internal/models"
+	"github.com/lukabrx/uber-clone/internal/money"
+	"github.com/lukabrx/uber-clone/internal/trip"
+)
+
+// RunTripRepository checks the trip.TripRepository contract.
+func RunTripRepository(t *testing.T, newRepo func(t *testing.T) trip.TripRepository) {
+	ctx := context.Background()
+
+	create := func(t *testing.T, repo trip.TripRepository, riderID string, requestTime time.Time) models.Trip {
+		t.Helper()
+		created, err := repo.CreateTrip(ctx, models.Trip{
+			RiderID:         riderID,
+			StartLat:        44.81,
+			StartLon:        20.46,
+			EndLat:          44.79,
+			EndLon:          20.47,
+			VehicleType:     models.VehicleEconomy,
+			Status:          models.TripStatusRequested,
+			Price:           money.New(1250, "EUR"),
+			SurgeMultiplier: 1,
+			RequestTime:     requestTime,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return created
+	}
+
+	t.Run("CreateAndGet", func(t *testing.T) {
+		repo := newRepo(t)
+		created := create(t, repo, "rider-1", time.Now())
+		if created.ID == "" {
+			t.Fatal("CreateTrip did not assign an ID")
+		}
+		got, err := repo.GetTripByID(ctx, created.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.RiderID != created.RiderID || got.Status != created.Status || got.Price != created.Price ||
+			got.EndLat != created.EndLat || !sameTime(got.RequestTime, created.RequestTime) {
+			t.Fatalf("GetTripByID = %+v, want %+v", got, created)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		repo := newRepo(t)
+		_, err := repo.GetTripByID(ctx, "missing")
+		wantErr(t, err, trip.ErrTripNotFound)
+		wantErr(t, repo.UpdateTrip(ctx, models.Trip{ID: "missing"}), trip.ErrTripNotFound)
+		_, err = repo.UpdateTripStatus(ctx, "missing", models.TripStatusRequested, models.TripStatusCancelled, nil)
+		wantErr(t, err, trip.ErrTripNotFound)
+		_, err = repo.GetTripStatusHistory(ctx, "missing")
+		wantErr(t, err, trip.ErrTripNotFound)
+		wantErr(t, repo.AppendTracePoint(ctx, "missing", models.TracePoint{RecordedAt: time.Now()}), trip.ErrTripNotFound)
+		_, err = repo.GetTrace(ctx, "missing")
+		wantErr(t, err, trip.ErrTripNotFound)
+	})
+
+	t.Run("UpdateTrip", func(t *testing.T) {
+		repo := newRepo(t)
+		tr := create(t, repo, "rider-1", time.Now())
+		tr.DriverID = "driver-1"
+		tr.FinalPrice = money.New(1400, "EUR")
+		if err := repo.UpdateTrip(ctx, tr); err != nil {
+			t.Fatal(err)
+		}
+		got, err := repo.GetTripByID(ctx, tr.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.DriverID != "driver-1" || got.FinalPrice != tr.FinalPrice {
+			t.Fatalf("GetTripByID = %+v, want the update stored", got)
+		}
+	})
+
+	t.Run("UpdateTripStatus", func(t *testing.T) {
+		repo := newRepo(t)
+		tr := create(t, repo, "rider-1", time.Now())
+
+		updated, err := repo.UpdateTripStatus(ctx, tr.ID, models.TripStatusRequested, models.TripStatusDriverAssigned, func(t *models.Trip) {
+			t.DriverID = "driver-1"
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if updated.Status != models.TripStatusDriverAssigned || updated.DriverID != "driver-1" {
+			t.Fatalf("UpdateTripStatus = %+v, want it assigned to driver-1", updated)
+		}
+		if updated.Version != tr.Version+1 {
+			t.Fatalf("version = %d after one status change from %d", updated.Version, tr.Version)
+		}
+		got, err := repo.GetTripByID(ctx, tr.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Status != models.TripStatusDriverAssigned || got.DriverID != "driver-1" {
+			t.Fatalf("GetTripByID = %+v, want the transition stored", got)
+		}
+
+		// The trip is no longer in the requested status.
+		_, err = repo.UpdateTripStatus(ctx, tr.ID, models.TripStatusRequested, models.TripStatusCancelled, nil)
+		var invalid *trip.InvalidTransitionError
+		if !errors.As(err, &invalid) || invalid.From != models.TripStatusDriverAssigned {
+			t.Fatalf("got error %v, want an InvalidTransitionError from driver_assigned", err)
+		}
+
+		history, err := repo.GetTripStatusHistory(ctx, tr.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(history) != 1 || history[0].From != models.TripStatusRequested || history[0].To != models.TripStatusDriverAssigned {
+			t.Fatalf("GetTripStatusHistory = %+v, want the one transition", history)
+		}
+	})
+
+	t.Run("ConcurrentUpdateTripStatus", func(t *testing.T) {
+		repo := newRepo(t)
+		tr := create(t, repo, "rider-1", time.Now())
+		won := race(10, func(int) error {
+			_, err := repo.UpdateTripStatus(ctx, tr.ID, models.TripStatusRequested, models.TripStatusCancelled, nil)
+			return err
+		})
+		if won != 1 {
+			t.Fatalf("%d concurrent transitions succeeded, want 1", won)
+		}
+		history, err := repo.GetTripStatusHistory(ctx, tr.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(history) != 1 {
+			t.Fatalf("%d history entries, want 1", len(history))
+		}
+	})
+
+	t.Run("CreateQuotedTrip", func(t *testing.T) {
+		repo := newRepo(t)
+		quoted := models.Trip{
+			RiderID:     "rider-1",
+			VehicleType: models.VehicleEconomy,
+			Status:      models.TripStatusRequested,
+			Price:       money.New(1250, "EUR"),
+			RequestTime: time.Now(),
+		}
+		expiresAt := time.Now().Add(time.Minute)
+		won := race(10, func(int) error {
+			_, err := repo.CreateQuotedTrip(ctx, quoted, "nonce-1", expiresAt)
+			if err != nil && !errors.Is(err, trip.ErrQuoteRedeemed) {
+				t.Errorf("CreateQuotedTrip: %v", err)
+			}
+			return err
+		})
+		if won != 1 {
+			t.Fatalf("%d concurrent redemptions succeeded, want 1", won)
+		}
+		_, err := repo.CreateQuotedTrip(ctx, quoted, "nonce-1", expiresAt)
+		wantErr(t, err, trip.ErrQuoteRedeemed)
+
+		trips, _, err := repo.ListTrips(ctx, trip.TripFilter{RiderID: "rider-1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(trips) != 1 {
+			t.Fatalf("%d trips created, want 1", len(trips))
+		}
+
+		// Another quote books another trip.
+		if _, err := repo.CreateQuotedTrip(ctx, quoted, "nonce-2", expiresAt); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("GetTripsByStatus", func(t *testing.T) {
+		repo := newRepo(t)
+		requested := create(t, repo, "rider-1", time.Now())
+		cancelled := create(t, repo, "rider-1", time.Now())
+		if _, err := repo.UpdateTripStatus(ctx, cancelled.ID, models.TripStatusRequested, models.TripStatusCancelled, nil); err != nil {
+			t.Fatal(err)
+		}
+
+		trips, err := repo.GetTripsByStatus(ctx, models.TripStatusRequested, models.TripStatusInProgress)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(trips) != 1 || trips[0].ID != requested.ID {
+			t.Fatalf("GetTripsByStatus = %+v, want only trip %s", trips, requested.ID)
+		}
+	})
+
+	t.Run("ListTrips", func(t *testing.T) {
+		repo := newRepo(t)
+		base := time.Now().Add(-time.Hour)
+		var want []string
+		for i := range 5 {
+			tr := create(t, repo, "rider-1", base.Add(time.Duration(i)*time.Minute))
+			want = append([]string{tr.ID}, want...)
+		}
+		// Two trips at the same instant are ordered by ID.
+		create(t, repo, "rider-2", base)
+		create(t, repo, "rider-2", base)
+
+		var got []string
+		filter := trip.TripFilter{RiderID: "rider-1", PageSize: 2}
+		for pages := 0; ; pages++ {
+			if pages > 3 {
+				t.Fatal("ListTrips did not stop paging")
+			}
+			page, next, err := repo.ListTrips(ctx, filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, tr := range page {
+				got = append(got, tr.ID)
+			}
+			if next == "" {
+				break
+			}
+			filter.PageToken = next
+		}
+		if len(got) != len(want) {
+			t.Fatalf("ListTrips returned %v, want %v", got, want)
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Fatalf("ListTrips returned %v, want newest first %v", got, want)
+			}
+		}
+
+		tied, _, err := repo.ListTrips(ctx, trip.TripFilter{RiderID: "rider-2"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(tied) != 2 || tied[0].ID < tied[1].ID {
+			t.Fatalf("ListTrips = %+v, want both trips by descending ID", tied)
+		}
+
+		_, _, err = repo.ListTrips(ctx, trip.TripFilter{PageToken: "not a token"})
+		wantErr(t, err, trip.ErrInvalidPageToken)
+	})
+
+	t.Run("Trace", func(t *testing.T) {
+		repo := newRepo(t)
+		tr := create(t, repo, "rider-1", time.Now())
+		start := time.Now()
+		points := []models.TracePoint{
+			{Lat: 44.81, Lon: 20.46, RecordedAt: start},
+			{Lat: 44.80, Lon: 20.46, RecordedAt: start.Add(time.Minute)},
+			{Lat: 44.79, Lon: 20.47, RecordedAt: start.Add(2 * time.Minute)},
+		}
+		for _, p := range points {
+			if err := repo.AppendTracePoint(ctx, tr.ID, p); err != nil {
+				t.Fatal(err)
+			}
+		}
+		trace, err := repo.GetTrace(ctx, tr.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(trace) != len(points) {
+			t.Fatalf("GetTrace returned %d points, want %d", len(trace), len(points))
+		}
+		for i, p := range trace {
+			if p.Lat != points[i].Lat || p.Lon != points[i].Lon || !sameTime(p.RecordedAt, points[i].RecordedAt) {
+				t.Fatalf("point %d = %+v, want %+v", i, p, points[i])
+			}
+		}
+	})
+}
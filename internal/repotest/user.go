@@ -0,0 +1,58 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lukabrx/uber-clone/internal/user"
+)
+
+// RunUserRepository checks the user.UserRepository contract.
+func RunUserRepository(t *testing.T, newRepo func(t *testing.T) user.UserRepository) {
+	ctx := context.Background()
+
+	t.Run("CreateAndGet", func(t *testing.T) {
+		repo := newRepo(t)
+		created, err := repo.CreateOrUpdateUser(ctx, user.User{Email: "ana@example.com", Name: "Ana"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if created.ID == "" {
+			t.Fatal("CreateOrUpdateUser did not assign an ID")
+		}
+		got, err := repo.GetUserByID(ctx, created.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != created {
+			t.Fatalf("GetUserByID = %+v, want %+v", got, created)
+		}
+	})
+
+	t.Run("UpdateByEmail", func(t *testing.T) {
+		repo := newRepo(t)
+		first, err := repo.CreateOrUpdateUser(ctx, user.User{Email: "ana@example.com", Name: "Ana"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		second, err := repo.CreateOrUpdateUser(ctx, user.User{Email: "ana@example.com", Name: "Ana Petrović"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if second.ID != first.ID {
+			t.Fatalf("same email got a new ID: %s, want %s", second.ID, first.ID)
+		}
+		got, err := repo.GetUserByID(ctx, first.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Name != "Ana Petrović" {
+			t.Fatalf("name = %q, want the updated one", got.Name)
+		}
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := newRepo(t).GetUserByID(ctx, "missing")
+		wantErr(t, err, user.ErrUserNotFound)
+	})
+}
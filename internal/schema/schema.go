@@ -0,0 +1,170 @@
+// Package schema snapshots protobuf message definitions and checks a new
+// definition against an old snapshot for changes that would break consumers
+// still reading the old one.
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// File is the part of a .proto file that matters for wire compatibility,
+// keyed by fully qualified message name.
+type File struct {
+	Messages map[string]Message `json:"messages"`
+}
+
+type Message struct {
+	Fields   map[protoreflect.FieldNumber]Field `json:"fields"`
+	Reserved []protoreflect.FieldNumber         `json:"reserved,omitempty"`
+}
+
+type Field struct {
+	Name        string `json:"name"`
+	Kind        string `json:"kind"`
+	Cardinality string `json:"cardinality"`
+	// TypeName is the message or enum type of the field, if any.
+	TypeName string `json:"type_name,omitempty"`
+	Oneof    string `json:"oneof,omitempty"`
+}
+
+// Snapshot describes every message in fd, including nested ones, and in the
+// files it imports, since a breaking change to an imported message breaks fd's
+// messages too. The protobuf well-known types are left out; they never change.
+func Snapshot(fd protoreflect.FileDescriptor) File {
+	file := File{Messages: make(map[string]Message)}
+	var add func(protoreflect.MessageDescriptors)
+	add = func(messages protoreflect.MessageDescriptors) {
+		for i := range messages.Len() {
+			md := messages.Get(i)
+			msg := Message{Fields: make(map[protoreflect.FieldNumber]Field)}
+			for j := range md.Fields().Len() {
+				fd := md.Fields().Get(j)
+				field := Field{
+					Name:        string(fd.Name()),
+					Kind:        fd.Kind().String(),
+					Cardinality: fd.Cardinality().String(),
+				}
+				if fd.Message() != nil {
+					field.TypeName = string(fd.Message().FullName())
+				} else if fd.Enum() != nil {
+					field.TypeName = string(fd.Enum().FullName())
+				}
+				if oneof := fd.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() {
+					field.Oneof = string(oneof.Name())
+				}
+				msg.Fields[fd.Number()] = field
+			}
+			ranges := md.ReservedRanges()
+			for j := range ranges.Len() {
+				r := ranges.Get(j)
+				for n := r[0]; n < r[1]; n++ {
+					msg.Reserved = append(msg.Reserved, n)
+				}
+			}
+			file.Messages[string(md.FullName())] = msg
+			add(md.Messages())
+		}
+	}
+	seen := make(map[string]bool)
+	var addFile func(protoreflect.FileDescriptor)
+	addFile = func(fd protoreflect.FileDescriptor) {
+		if seen[fd.Path()] || strings.HasPrefix(fd.Path(), "google/protobuf/") {
+			return
+		}
+		seen[fd.Path()] = true
+		add(fd.Messages())
+		imports := fd.Imports()
+		for i := range imports.Len() {
+			addFile(imports.Get(i).FileDescriptor)
+		}
+	}
+	addFile(fd)
+	return file
+}
+
+// Compare lists the changes from old to current that break compatibility:
+// removed messages, fields removed without reserving their number, reserved
+// numbers reused, and fields whose name, type, cardinality or oneof changed.
+// Adding messages and fields is always allowed.
+func Compare(old, current File) []string {
+	var problems []string
+	for _, name := range sortedKeys(old.Messages) {
+		before := old.Messages[name]
+		after, ok := current.Messages[name]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("message %s was removed", name))
+			continue
+		}
+
+		for _, number := range sortedKeys(before.Fields) {
+			was := before.Fields[number]
+			is, ok := after.Fields[number]
+			switch {
+			case !ok && !slices.Contains(after.Reserved, number):
+				problems = append(problems, fmt.Sprintf("%s: field %d (%s) was removed without reserving its number", name, number, was.Name))
+			case ok && is != was:
+				problems = append(problems, fmt.Sprintf("%s: field %d changed from %s to %s", name, number, describe(was), describe(is)))
+			}
+		}
+
+		for _, number := range sortedKeys(after.Fields) {
+			if _, existed := before.Fields[number]; !existed && slices.Contains(before.Reserved, number) {
+				problems = append(problems, fmt.Sprintf("%s: field %d (%s) reuses a reserved number", name, number, after.Fields[number].Name))
+			}
+		}
+		for _, number := range before.Reserved {
+			if !slices.Contains(after.Reserved, number) {
+				if _, used := after.Fields[number]; !used {
+					problems = append(problems, fmt.Sprintf("%s: number %d is no longer reserved", name, number))
+				}
+			}
+		}
+	}
+	return problems
+}
+
+// ReadFile loads a snapshot written by WriteFile.
+func ReadFile(path string) (File, error) {
+	var file File
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return file, err
+	}
+	err = json.Unmarshal(data, &file)
+	return file, err
+}
+
+// WriteFile stores a snapshot as indented JSON, so changes to it review well.
+func WriteFile(path string, file File) error {
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+func describe(f Field) string {
+	s := fmt.Sprintf("%s %s %s", f.Cardinality, f.Kind, f.Name)
+	if f.TypeName != "" {
+		s += " (" + f.TypeName + ")"
+	}
+	if f.Oneof != "" {
+		s += " in oneof " + f.Oneof
+	}
+	return s
+}
+
+func sortedKeys[K interface{ ~string | ~int32 }, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,55 @@
+package trip
+
+import (
+	"log"
+	"time"
+
+	"github.com/lukabrx/uber-clone/internal/models"
+	"github.com/lukabrx/uber-clone/internal/money"
+)
+
+// CancellationPolicy decides what a rider is charged for cancelling a trip,
+// based on how long ago the trip was requested.
+type CancellationPolicy struct {
+	// FreeWindow is how long after requesting a rider can cancel for free.
+	FreeWindow time.Duration
+	// Fee is charged after the free window once a driver has been assigned.
+	// Fees must be in the trip's currency.
+	Fee money.Money
+	// LateWindow is when the fee is replaced by LateFee.
+	LateWindow time.Duration
+	LateFee    money.Money
+}
+
+var DefaultCancellationPolicy = CancellationPolicy{
+	FreeWindow: 2 * time.Minute,
+	Fee:        money.New(500, "USD"),
+	LateWindow: 5 * time.Minute,
+	LateFee:    money.New(1000, "USD"),
+}
+
+// FeeFor returns the fee owed for cancelling trip at the given time. Only
+// riders pay, and only when a driver had already been assigned to them.
+func (p CancellationPolicy) FeeFor(trip models.Trip, by models.CancellationParty, at time.Time) money.Money {
+	none := money.New(0, trip.Price.Currency)
+	if by != models.CancelledByRider || trip.DriverID == "" {
+		return none
+	}
+
+	var fee money.Money
+	elapsed := at.Sub(trip.RequestTime)
+	switch {
+	case elapsed < p.FreeWindow:
+		return none
+	case elapsed < p.LateWindow:
+		fee = p.Fee
+	default:
+		fee = p.LateFee
+	}
+
+	if fee.Currency != trip.Price.Currency {
+		log.Printf("Cannot charge cancellation fee for trip %s: fee is in %s, trip in %s", trip.ID, fee.Currency, trip.Price.Currency)
+		return none
+	}
+	return fee
+}
@@ -0,0 +1,138 @@
+package trip
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	pb_driver "github.com/lukabrx/uber-clone/api/proto/driver/v1"
+	"github.com/lukabrx/uber-clone/internal/models"
+	"github.com/lukabrx/uber-clone/internal/money"
+	"google.golang.org/grpc"
+)
+
+func TestFeeFor(t *testing.T) {
+	policy := DefaultCancellationPolicy
+	requested := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	assigned := models.Trip{ID: "t", DriverID: "driver-1", RequestTime: requested, Price: money.New(1500, "USD")}
+	unassigned := assigned
+	unassigned.DriverID = ""
+	inYen := assigned
+	inYen.Price = money.New(1500, "JPY")
+
+	tests := []struct {
+		name  string
+		trip  models.Trip
+		by    models.CancellationParty
+		after time.Duration
+		want  money.Money
+	}{
+		{"free window", assigned, models.CancelledByRider, time.Minute, money.New(0, "USD")},
+		{"fee window starts", assigned, models.CancelledByRider, policy.FreeWindow, policy.Fee},
+		{"fee window", assigned, models.CancelledByRider, 3 * time.Minute, policy.Fee},
+		{"late window starts", assigned, models.CancelledByRider, policy.LateWindow, policy.LateFee},
+		{"late window", assigned, models.CancelledByRider, time.Hour, policy.LateFee},
+		{"no driver yet", unassigned, models.CancelledByRider, time.Hour, money.New(0, "USD")},
+		{"by driver", assigned, models.CancelledByDriver, time.Hour, money.New(0, "USD")},
+		{"by system", assigned, models.CancelledBySystem, time.Hour, money.New(0, "USD")},
+		{"fee in another currency", inYen, models.CancelledByRider, time.Hour, money.New(0, "JPY")},
+	}
+	for _, tt := range tests {
+		if got := policy.FeeFor(tt.trip, tt.by, requested.Add(tt.after)); got != tt.want {
+			t.Errorf("%s: FeeFor = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// releasingDrivers is a driver service that records the drivers released.
+type releasingDrivers struct {
+	pb_driver.DriverServiceClient
+
+	mu       sync.Mutex
+	released []string
+}
+
+func (d *releasingDrivers) ReleaseDriver(_ context.Context, req *pb_driver.ReleaseDriverRequest, _ ...grpc.CallOption) (*pb_driver.ReleaseDriverResponse, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.released = append(d.released, req.DriverId+"/"+req.TripId)
+	return &pb_driver.ReleaseDriverResponse{}, nil
+}
+
+func TestCancelTrip(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name        string
+		by          models.CancellationParty
+		cancellerID string
+		wantErr     error
+	}{
+		{"by rider", models.CancelledByRider, "rider-1", nil},
+		{"by driver", models.CancelledByDriver, "driver-1", nil},
+		{"by system", models.CancelledBySystem, "", nil},
+		{"by another rider", models.CancelledByRider, "rider-2", ErrNotTripParticipant},
+		{"by another driver", models.CancelledByDriver, "driver-2", ErrNotTripParticipant},
+		{"by the rider as driver", models.CancelledByDriver, "rider-1", ErrNotTripParticipant},
+		{"by nobody", "", "rider-1", ErrInvalidCanceller},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMemoryRepository(nil)
+			drivers := &releasingDrivers{}
+			svc := NewService(repo, drivers, NewDispatcher(drivers, nil, DispatchConfig{}), nil, nil)
+
+			// Requested long enough ago for the late fee.
+			created, err := repo.CreateTrip(ctx, models.Trip{
+				RiderID:     "rider-1",
+				DriverID:    "driver-1",
+				Status:      models.TripStatusDriverAssigned,
+				Price:       money.New(1500, "USD"),
+				RequestTime: time.Now().Add(-time.Hour),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cancelled, err := svc.CancelTrip(ctx, created.ID, tt.by, tt.cancellerID, "changed plans")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				stored, err := repo.GetTripByID(ctx, created.ID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if stored.Status != models.TripStatusDriverAssigned || len(drivers.released) != 0 {
+					t.Fatalf("refused cancel changed the trip to %s and released %v", stored.Status, drivers.released)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if cancelled.Status != models.TripStatusCancelled || cancelled.CancelledBy != tt.by ||
+				cancelled.CancellerID != tt.cancellerID || cancelled.CancellationReason != "changed plans" {
+				t.Errorf("cancelled trip = %+v", cancelled)
+			}
+			wantFee := money.New(0, "USD")
+			if tt.by == models.CancelledByRider {
+				wantFee = DefaultCancellationPolicy.LateFee
+			}
+			if cancelled.CancellationFee != wantFee {
+				t.Errorf("fee = %v, want %v", cancelled.CancellationFee, wantFee)
+			}
+			if len(drivers.released) != 1 || drivers.released[0] != "driver-1/"+created.ID {
+				t.Errorf("released %v, want driver-1 freed from the trip", drivers.released)
+			}
+
+			_, err = svc.CancelTrip(ctx, created.ID, tt.by, tt.cancellerID, "again")
+			var transitionErr *InvalidTransitionError
+			if !errors.As(err, &transitionErr) {
+				t.Errorf("second cancel: err = %v, want *InvalidTransitionError", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package trip
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	pb_driver "github.com/lukabrx/uber-clone/api/proto/driver/v1"
+	"github.com/lukabrx/uber-clone/internal/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// OfferSender asks a single driver to take a trip. Implementations must give up
+// when ctx is done, which is how the dispatcher enforces the accept timeout.
+type OfferSender interface {
+	SendOffer(ctx context.Context, driverID string, trip models.Trip) (accepted bool, err error)
+}
+
+// AutoAcceptOffers accepts every offer on the driver's behalf.
+type AutoAcceptOffers struct{}
+
+func (AutoAcceptOffers) SendOffer(ctx context.Context, driverID string, trip models.Trip) (bool, error) {
+	return true, nil
+}
+
+// DriverServiceOffers sends offers through the driver service, which pushes them
+// to the driver's app and waits for the driver to answer.
+type DriverServiceOffers struct {
+	driverClient pb_driver.DriverServiceClient
+}
+
+func NewDriverServiceOffers(driverClient pb_driver.DriverServiceClient) *DriverServiceOffers {
+	return &DriverServiceOffers{driverClient: driverClient}
+}
+
+func (o *DriverServiceOffers) SendOffer(ctx context.Context, driverID string, trip models.Trip) (bool, error) {
+	res, err := o.driverClient.OfferTrip(ctx, &pb_driver.OfferTripRequest{
+		DriverId:   driverID,
+		TripId:     trip.ID,
+		PickupLat:  trip.StartLat,
+		PickupLon:  trip.StartLon,
+		DropoffLat: trip.EndLat,
+		DropoffLon: trip.EndLon,
+		Price:      toPbMoney(trip.Price),
+	})
+	if err != nil {
+		// The accept timeout ran out before the driver service answered.
+		if status.Code(err) == codes.DeadlineExceeded {
+			return false, nil
+		}
+		return false, err
+	}
+	return res.Outcome == "accepted", nil
+}
+
+type DispatchConfig struct {
+	// SearchRadiusKm bounds how far from the pickup drivers are looked for.
+	SearchRadiusKm float64
+	// MaxCandidates is how many drivers are offered a trip before giving up.
+	MaxCandidates int
+	// OfferTimeout is how long to wait for each driver. It should be a little
+	// longer than the driver service's offer window so offers expire there first.
+	OfferTimeout time.Duration
+}
+
+var DefaultDispatchConfig = DispatchConfig{
+	SearchRadiusKm: 10,
+	MaxCandidates:  5,
+	OfferTimeout:   20 * time.Second,
+}
+
+// Dispatcher finds a driver for a trip by offering it to the closest available
+// drivers one at a time. A driver is reserved for the trip in the driver service
+// while it is being offered to them, so concurrent dispatches, even from other
+// trip service instances, never offer the same driver.
+type Dispatcher struct {
+	driverClient pb_driver.DriverServiceClient
+	offers       OfferSender
+	config       DispatchConfig
+
+	mu       sync.Mutex
+	inflight map[string]context.CancelFunc
+}
+
+func NewDispatcher(driverClient pb_driver.DriverServiceClient, offers OfferSender, config DispatchConfig) *Dispatcher {
+	return &Dispatcher{
+		driverClient: driverClient,
+		offers:       offers,
+		config:       config,
+		inflight:     make(map[string]context.CancelFunc),
+	}
+}
+
+// Dispatch returns the ID of the driver that accepted the trip. The driver stays
+// reserved for the trip until Release is called.
+func (d *Dispatcher) Dispatch(trip models.Trip) (string, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	d.mu.Lock()
+	d.inflight[trip.ID] = cancel
+	d.mu.Unlock()
+	defer func() {
+		d.mu.Lock()
+		delete(d.inflight, trip.ID)
+		d.mu.Unlock()
+		cancel()
+	}()
+
+	// Ask for more drivers than will be offered, since some may get reserved by
+	// other dispatches before we reach them.
+	req := &pb_driver.FindAvailableDriversRequest{
+		Lat:      trip.StartLat,
+		Lon:      trip.StartLon,
+		RadiusKm: d.config.SearchRadiusKm,
+		Limit:    int32(2 * d.config.MaxCandidates),
+	}
+	if trip.VehicleType != "" {
+		req.VehicleTypes = []string{string(trip.VehicleType)}
+	}
+	res, err := d.driverClient.FindAvailableDrivers(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	offered := 0
+	for _, candidate := range res.Drivers {
+		if offered == d.config.MaxCandidates {
+			break
+		}
+		if err := d.reserve(ctx, candidate.Id, trip.ID); err != nil {
+			// Someone else booked the driver first.
+			if status.Code(err) == codes.FailedPrecondition {
+				continue
+			}
+			// The reservation may have gone through even though the call
+			// failed, so undo it before giving up.
+			log.Printf("Failed to reserve driver %s for trip %s: %v", candidate.Id, trip.ID, err)
+			d.Release(candidate.Id, trip.ID)
+			if ctx.Err() != nil {
+				return "", ctx.Err()
+			}
+			return "", err
+		}
+		offered++
+
+		accepted, err := d.offer(ctx, candidate.Id, trip)
+		if err == nil && accepted {
+			return candidate.Id, nil
+		}
+		if err != nil {
+			log.Printf("Offer of trip %s to driver %s failed: %v", trip.ID, candidate.Id, err)
+		}
+		d.Release(candidate.Id, trip.ID)
+
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+	}
+
+	return "", ErrNoDriverAvailable
+}
+
+// Abort stops an in-flight dispatch for the trip, if there is one.
+func (d *Dispatcher) Abort(tripID string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if cancel, ok := d.inflight[tripID]; ok {
+		cancel()
+	}
+}
+
+// Release frees the driver if it is still reserved for the given trip.
+func (d *Dispatcher) Release(driverID, tripID string) {
+	_, err := d.driverClient.ReleaseDriver(context.Background(), &pb_driver.ReleaseDriverRequest{
+		DriverId: driverID,
+		TripId:   tripID,
+	})
+	if err != nil {
+		log.Printf("Failed to release driver %s from trip %s: %v", driverID, tripID, err)
+	}
+}
+
+func (d *Dispatcher) reserve(ctx context.Context, driverID, tripID string) error {
+	_, err := d.driverClient.ReserveDriver(ctx, &pb_driver.ReserveDriverRequest{
+		DriverId: driverID,
+		TripId:   tripID,
+	})
+	return err
+}
+
+func (d *Dispatcher) offer(ctx context.Context, driverID string, trip models.Trip) (bool, error) {
+	offerCtx, cancel := context.WithTimeout(ctx, d.config.OfferTimeout)
+	defer cancel()
+	return d.offers.SendOffer(offerCtx, driverID, trip)
+}
@@ -0,0 +1,203 @@
+package trip
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	pb_driver "github.com/lukabrx/uber-clone/api/proto/driver/v1"
+	"github.com/lukabrx/uber-clone/internal/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// dispatchDrivers is a driver service with a fixed set of nearby drivers.
+// reserveErrs makes reserving a driver fail.
+type dispatchDrivers struct {
+	pb_driver.DriverServiceClient
+	nearby      []string
+	reserveErrs map[string]error
+
+	mu       sync.Mutex
+	reserved []string
+	released []string
+}
+
+func (d *dispatchDrivers) FindAvailableDrivers(context.Context, *pb_driver.FindAvailableDriversRequest, ...grpc.CallOption) (*pb_driver.FindAvailableDriversResponse, error) {
+	res := &pb_driver.FindAvailableDriversResponse{}
+	for _, id := range d.nearby {
+		res.Drivers = append(res.Drivers, &pb_driver.Driver{Id: id})
+	}
+	return res, nil
+}
+
+func (d *dispatchDrivers) ReserveDriver(_ context.Context, req *pb_driver.ReserveDriverRequest, _ ...grpc.CallOption) (*pb_driver.ReserveDriverResponse, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.reserved = append(d.reserved, req.DriverId)
+	if err := d.reserveErrs[req.DriverId]; err != nil {
+		return nil, err
+	}
+	return &pb_driver.ReserveDriverResponse{}, nil
+}
+
+func (d *dispatchDrivers) ReleaseDriver(_ context.Context, req *pb_driver.ReleaseDriverRequest, _ ...grpc.CallOption) (*pb_driver.ReleaseDriverResponse, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.released = append(d.released, req.DriverId)
+	return &pb_driver.ReleaseDriverResponse{}, nil
+}
+
+// answers is an OfferSender whose drivers answer with their function; drivers
+// without one decline.
+type answers map[string]func(ctx context.Context) (bool, error)
+
+func (a answers) SendOffer(ctx context.Context, driverID string, trip models.Trip) (bool, error) {
+	if answer, ok := a[driverID]; ok {
+		return answer(ctx)
+	}
+	return false, nil
+}
+
+func accept(context.Context) (bool, error) { return true, nil }
+
+// ignore never answers, like a driver who looks away from their phone.
+func ignore(ctx context.Context) (bool, error) {
+	<-ctx.Done()
+	return false, nil
+}
+
+var testDispatchConfig = DispatchConfig{SearchRadiusKm: 10, MaxCandidates: 3, OfferTimeout: time.Second}
+
+func TestDispatch(t *testing.T) {
+	failed := errors.New("offer failed")
+	tests := []struct {
+		name        string
+		nearby      []string
+		reserveErrs map[string]error
+		answers     answers
+		timeout     time.Duration
+
+		want         string
+		wantErr      error
+		wantReserved []string
+		wantReleased []string
+	}{
+		{
+			name:         "first driver accepts",
+			nearby:       []string{"d1", "d2"},
+			answers:      answers{"d1": accept},
+			want:         "d1",
+			wantReserved: []string{"d1"},
+		},
+		{
+			name:         "falls back to the next driver",
+			nearby:       []string{"d1", "d2", "d3"},
+			answers:      answers{"d2": func(context.Context) (bool, error) { return false, failed }, "d3": accept},
+			want:         "d3",
+			wantReserved: []string{"d1", "d2", "d3"},
+			wantReleased: []string{"d1", "d2"},
+		},
+		{
+			name:         "offer times out",
+			nearby:       []string{"d1", "d2"},
+			answers:      answers{"d1": ignore, "d2": accept},
+			timeout:      50 * time.Millisecond,
+			want:         "d2",
+			wantReserved: []string{"d1", "d2"},
+			wantReleased: []string{"d1"},
+		},
+		{
+			name:   "skips drivers booked by another trip",
+			nearby: []string{"d1", "d2", "d3", "d4"},
+			reserveErrs: map[string]error{
+				"d1": status.Error(codes.FailedPrecondition, "driver is reserved for another trip"),
+				"d2": status.Error(codes.FailedPrecondition, "driver is reserved for another trip"),
+			},
+			answers:      answers{"d4": accept},
+			want:         "d4",
+			wantReserved: []string{"d1", "d2", "d3", "d4"},
+			wantReleased: []string{"d3"},
+		},
+		{
+			name:         "stops after MaxCandidates offers",
+			nearby:       []string{"d1", "d2", "d3", "d4"},
+			answers:      answers{"d4": accept},
+			wantErr:      ErrNoDriverAvailable,
+			wantReserved: []string{"d1", "d2", "d3"},
+			wantReleased: []string{"d1", "d2", "d3"},
+		},
+		{
+			name:         "releases after a failed reservation",
+			nearby:       []string{"d1", "d2"},
+			reserveErrs:  map[string]error{"d1": status.Error(codes.Unavailable, "connection reset")},
+			answers:      answers{"d2": accept},
+			wantErr:      status.Error(codes.Unavailable, "connection reset"),
+			wantReserved: []string{"d1"},
+			wantReleased: []string{"d1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			drivers := &dispatchDrivers{nearby: tt.nearby, reserveErrs: tt.reserveErrs}
+			config := testDispatchConfig
+			if tt.timeout > 0 {
+				config.OfferTimeout = tt.timeout
+			}
+
+			got, err := NewDispatcher(drivers, tt.answers, config).Dispatch(models.Trip{ID: "trip-1"})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) && status.Code(err) != status.Code(tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil || got != tt.want {
+				t.Fatalf("Dispatch = %q, %v; want %q", got, err, tt.want)
+			}
+			if !slices.Equal(drivers.reserved, tt.wantReserved) {
+				t.Errorf("reserved %v, want %v", drivers.reserved, tt.wantReserved)
+			}
+			if !slices.Equal(drivers.released, tt.wantReleased) {
+				t.Errorf("released %v, want %v", drivers.released, tt.wantReleased)
+			}
+		})
+	}
+}
+
+func TestDispatchAbort(t *testing.T) {
+	drivers := &dispatchDrivers{nearby: []string{"d1", "d2"}}
+	offered := make(chan struct{})
+	dispatcher := NewDispatcher(drivers, answers{
+		"d1": func(ctx context.Context) (bool, error) {
+			close(offered)
+			return ignore(ctx)
+		},
+		"d2": accept,
+	}, testDispatchConfig)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := dispatcher.Dispatch(models.Trip{ID: "trip-1"})
+		done <- err
+	}()
+	<-offered
+	dispatcher.Abort("trip-1")
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("err = %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Dispatch did not stop after Abort")
+	}
+	if !slices.Equal(drivers.reserved, []string{"d1"}) || !slices.Equal(drivers.released, []string{"d1"}) {
+		t.Errorf("reserved %v and released %v, want only d1 offered and freed", drivers.reserved, drivers.released)
+	}
+
+	// Aborting a trip that is not being dispatched is a no-op.
+	dispatcher.Abort("trip-2")
+}
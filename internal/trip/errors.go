@@ -0,0 +1,61 @@
+package trip
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lukabrx/uber-clone/internal/models"
+	pricecalculator "github.com/lukabrx/uber-clone/internal/price_calculator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrTripNotFound       = errors.New("trip not found")
+	ErrUnknownStatus      = errors.New("unknown trip status")
+	ErrCancelViaCancel    = errors.New("trips must be cancelled through CancelTrip")
+	ErrInvalidCanceller   = errors.New("cancelled_by must be rider or driver")
+	ErrNotTripParticipant = errors.New("only the trip's rider or driver can cancel it")
+	ErrInvalidPageToken   = errors.New("invalid page token")
+	ErrNoDriverAvailable  = errors.New("no driver accepted the trip")
+	ErrInvalidQuote       = errors.New("invalid fare quote")
+	ErrQuoteExpired       = errors.New("fare quote has expired")
+	ErrQuoteNotForRider   = errors.New("fare quote was issued to another rider")
+	ErrQuoteRedeemed      = errors.New("fare quote has already been used")
+	ErrQuoteMismatch      = errors.New("trip does not match the fare quote")
+	ErrWatcherTooSlow     = errors.New("trip watcher fell too far behind")
+)
+
+// InvalidTransitionError is returned when a trip is asked to move to a status
+// that is not reachable from its current one.
+type InvalidTransitionError struct {
+	TripID string
+	From   models.TripStatus
+	To     models.TripStatus
+}
+
+func (e *InvalidTransitionError) Error() string {
+	return fmt.Sprintf("trip %s cannot transition from %s to %s", e.TripID, e.From, e.To)
+}
+
+// grpcError converts service errors into gRPC status errors so clients can
+// tell a missing trip from an illegal transition.
+func grpcError(err error) error {
+	var transitionErr *InvalidTransitionError
+	switch {
+	case errors.Is(err, ErrTripNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrUnknownStatus), errors.Is(err, ErrCancelViaCancel), errors.Is(err, ErrInvalidCanceller),
+		errors.Is(err, ErrInvalidPageToken), errors.Is(err, pricecalculator.ErrNoRateCard),
+		errors.Is(err, ErrInvalidQuote), errors.Is(err, ErrQuoteMismatch):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, ErrNotTripParticipant), errors.Is(err, ErrQuoteNotForRider):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.As(err, &transitionErr), errors.Is(err, ErrQuoteExpired), errors.Is(err, ErrQuoteRedeemed):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, ErrWatcherTooSlow):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	default:
+		return err
+	}
+}
@@ -0,0 +1,43 @@
+package trip
+
+import (
+	"context"
+	"log"
+
+	"github.com/lukabrx/uber-clone/internal/eventbus"
+	"github.com/lukabrx/uber-clone/internal/events"
+	"github.com/lukabrx/uber-clone/internal/types"
+)
+
+// EventConsumer records the location updates of drivers on a trip so the final
+// fare can be worked out from the route actually driven.
+type EventConsumer struct {
+	subscriber eventbus.Subscriber
+	service    *Service
+}
+
+func NewEventConsumer(subscriber eventbus.Subscriber, service *Service) *EventConsumer {
+	return &EventConsumer{subscriber: subscriber, service: service}
+}
+
+func (c *EventConsumer) SubscribeAndListen(ctx context.Context) {
+	log.Println("Trip consumer subscribed and listening for driver location updates...")
+	go func() {
+		if err := c.subscriber.Subscribe(ctx, []string{types.DriverLocationTopic}, c.handle); err != nil {
+			log.Fatalf("Failed to subscribe to topic: %v", err)
+		}
+		log.Println("Stopping trip event consumer.")
+	}()
+}
+
+func (c *EventConsumer) handle(ctx context.Context, msg eventbus.Message) error {
+	driver, err := events.DecodeDriver(msg.Value)
+	if err != nil {
+		log.Printf("Could not decode driver update: %v", err)
+		return nil
+	}
+	if err := c.service.RecordLocation(ctx, driver); err != nil {
+		log.Printf("Failed to record location of driver %s for trip %s: %v", driver.ID, driver.TripID, err)
+	}
+	return nil
+}
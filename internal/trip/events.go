@@ -0,0 +1,56 @@
+package trip
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lukabrx/uber-clone/internal/events"
+	"github.com/lukabrx/uber-clone/internal/models"
+	"github.com/lukabrx/uber-clone/internal/outbox"
+	"github.com/lukabrx/uber-clone/internal/types"
+)
+
+// tripCreatedMessage is the outbox entry stored with a new trip.
+func tripCreatedMessage(ctx context.Context, trip models.Trip) (outbox.Message, error) {
+	return tripEventMessage(ctx, types.TripEvent{
+		Version:   trip.Version,
+		EventType: types.TripCreatedEvent,
+		TripID:    trip.ID,
+		DriverID:  trip.DriverID,
+		Status:    string(trip.Status),
+		PickupLat: trip.StartLat,
+		PickupLon: trip.StartLon,
+	})
+}
+
+// tripStatusMessage is the outbox entry stored with a status change.
+func tripStatusMessage(ctx context.Context, trip models.Trip, from models.TripStatus) (outbox.Message, error) {
+	eventType, ok := statusEvents[trip.Status]
+	if !ok {
+		return outbox.Message{}, fmt.Errorf("no event registered for trip status %s", trip.Status)
+	}
+
+	return tripEventMessage(ctx, types.TripEvent{
+		Version:        trip.Version,
+		EventType:      eventType,
+		TripID:         trip.ID,
+		DriverID:       trip.DriverID,
+		Status:         string(trip.Status),
+		PreviousStatus: string(from),
+		CancelledBy:    string(trip.CancelledBy),
+		Reason:         trip.CancellationReason,
+		PickupLat:      trip.StartLat,
+		PickupLon:      trip.StartLon,
+	})
+}
+
+// Trip events are keyed by trip, so each trip's events stay in order.
+func tripEventMessage(ctx context.Context, event types.TripEvent) (outbox.Message, error) {
+	event.EventID = uuid.New().String()
+	value, err := events.Marshal(events.NewTripEvent(ctx, event))
+	if err != nil {
+		return outbox.Message{}, err
+	}
+	return outbox.Message{Topic: types.TripEventsTopic, Key: event.TripID, Value: value}, nil
+}
@@ -0,0 +1,73 @@
+package trip
+
+import (
+	"time"
+
+	"github.com/lukabrx/uber-clone/internal/geo"
+	"github.com/lukabrx/uber-clone/internal/models"
+	"github.com/lukabrx/uber-clone/internal/money"
+)
+
+// maxPlausibleSpeedKmh drops trace segments that imply faster travel, which are
+// GPS glitches rather than driving.
+const maxPlausibleSpeedKmh = 200
+
+// FareTolerance decides whether the upfront price or the re-priced fare is
+// charged at completion. While the final fare is within the tolerance of the
+// estimate the rider pays the estimate they agreed to; a larger difference means
+// the trip changed (a detour, a new destination, an early drop-off) and the
+// final fare is charged instead.
+type FareTolerance struct {
+	// Percent of the estimate the final fare may differ by, e.g. 0.2 for 20%.
+	Percent float64
+	// Minimum allowance, so short trips aren't re-priced over small detours.
+	// It only applies to estimates in the same currency.
+	Minimum money.Money
+}
+
+var DefaultFareTolerance = FareTolerance{Percent: 0.2, Minimum: money.New(200, "USD")}
+
+// Settle returns the amount to charge given the estimate and the final fare.
+func (t FareTolerance) Settle(estimate, final money.Money) money.Money {
+	if final.Currency != estimate.Currency {
+		return estimate
+	}
+
+	allowance := estimate.Mul(t.Percent, money.HalfUp).Amount
+	if t.Minimum.Currency == estimate.Currency {
+		allowance = max(allowance, t.Minimum.Amount)
+	}
+
+	diff := final.Amount - estimate.Amount
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff <= allowance {
+		return estimate
+	}
+	return final
+}
+
+// traceDistanceKm is the length of a GPS trace, skipping implausible jumps.
+func traceDistanceKm(trace []models.TracePoint) float64 {
+	var total float64
+	for i := 1; i < len(trace); i++ {
+		prev, cur := trace[i-1], trace[i]
+		d := geo.DistanceKm(prev.Lat, prev.Lon, cur.Lat, cur.Lon)
+		if elapsed := cur.RecordedAt.Sub(prev.RecordedAt); elapsed > 0 {
+			if d/elapsed.Hours() > maxPlausibleSpeedKmh {
+				continue
+			}
+		}
+		total += d
+	}
+	return total
+}
+
+// elapsedSince is how long the trip has been in progress at now.
+func elapsedSince(started, now time.Time) time.Duration {
+	if started.IsZero() || now.Before(started) {
+		return 0
+	}
+	return now.Sub(started)
+}
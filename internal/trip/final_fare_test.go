@@ -0,0 +1,97 @@
+package trip
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/lukabrx/uber-clone/internal/geo"
+	"github.com/lukabrx/uber-clone/internal/models"
+	"github.com/lukabrx/uber-clone/internal/money"
+)
+
+func TestSettle(t *testing.T) {
+	tolerance := DefaultFareTolerance
+	usd := func(minor int64) money.Money { return money.New(minor, "USD") }
+
+	tests := []struct {
+		name            string
+		estimate, final money.Money
+		want            money.Money
+	}{
+		{"exact", usd(2000), usd(2000), usd(2000)},
+		{"a little more", usd(2000), usd(2300), usd(2000)},
+		{"a little less", usd(2000), usd(1700), usd(2000)},
+		{"up to the percentage", usd(2000), usd(2400), usd(2000)},
+		{"past the percentage", usd(2000), usd(2401), usd(2401)},
+		{"early drop-off", usd(2000), usd(1599), usd(1599)},
+		// 20% of 5.00 is 1.00, less than the 2.00 minimum allowance.
+		{"short trip within the minimum", usd(500), usd(700), usd(500)},
+		{"short trip past the minimum", usd(500), usd(701), usd(701)},
+		// The minimum is in dollars, so other currencies only get the percentage.
+		{"minimum in another currency", money.New(500, "EUR"), money.New(650, "EUR"), money.New(650, "EUR")},
+		{"percentage in another currency", money.New(500, "EUR"), money.New(600, "EUR"), money.New(500, "EUR")},
+		{"fare in another currency", usd(2000), money.New(9000, "EUR"), usd(2000)},
+	}
+	for _, tt := range tests {
+		if got := tolerance.Settle(tt.estimate, tt.final); got != tt.want {
+			t.Errorf("%s: Settle(%v, %v) = %v, want %v", tt.name, tt.estimate, tt.final, got, tt.want)
+		}
+	}
+}
+
+func TestTraceDistanceKm(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(lat, lon float64, after time.Duration) models.TracePoint {
+		return models.TracePoint{Lat: lat, Lon: lon, RecordedAt: start.Add(after)}
+	}
+	// Each hop east is about 0.79 km, a minute apart.
+	hop := geo.DistanceKm(44.8, 20.40, 44.8, 20.41)
+
+	tests := []struct {
+		name  string
+		trace []models.TracePoint
+		want  float64
+	}{
+		{"empty", nil, 0},
+		{"one point", []models.TracePoint{at(44.8, 20.40, 0)}, 0},
+		{
+			name:  "straight line",
+			trace: []models.TracePoint{at(44.8, 20.40, 0), at(44.8, 20.41, time.Minute), at(44.8, 20.42, 2*time.Minute)},
+			want:  2 * hop,
+		},
+		{
+			// A fix 11 km away for a second is a glitch. Both the jump out and
+			// the jump back are dropped.
+			name: "glitch",
+			trace: []models.TracePoint{
+				at(44.8, 20.40, 0), at(44.8, 20.41, time.Minute),
+				at(44.9, 20.41, time.Minute+time.Second),
+				at(44.8, 20.42, 2*time.Minute),
+			},
+			want: hop,
+		},
+		{
+			// 0.79 km in 15 seconds is about 190 km/h, fast but possible.
+			name:  "fast but plausible",
+			trace: []models.TracePoint{at(44.8, 20.40, 0), at(44.8, 20.41, 15*time.Second)},
+			want:  hop,
+		},
+		{
+			name:  "too fast",
+			trace: []models.TracePoint{at(44.8, 20.40, 0), at(44.8, 20.41, 14*time.Second)},
+			want:  0,
+		},
+		{
+			// Points without usable timestamps are counted.
+			name:  "same timestamp",
+			trace: []models.TracePoint{at(44.8, 20.40, 0), at(44.8, 20.41, 0)},
+			want:  hop,
+		},
+	}
+	for _, tt := range tests {
+		if got := traceDistanceKm(tt.trace); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: traceDistanceKm = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -2,9 +2,12 @@ package trip
 
 import (
 	"context"
+	"time"
 
+	pb_money "github.com/lukabrx/uber-clone/api/proto/money/v1"
 	pb "github.com/lukabrx/uber-clone/api/proto/trip/v1"
 	"github.com/lukabrx/uber-clone/internal/models"
+	"github.com/lukabrx/uber-clone/internal/money"
 )
 
 type GrpcHandler struct {
@@ -17,33 +20,192 @@ func NewGrpcHandler(service *Service) *GrpcHandler {
 }
 
 func (h *GrpcHandler) CreateTrip(ctx context.Context, req *pb.CreateTripRequest) (*pb.CreateTripResponse, error) {
-	trip, err := h.service.CreateTrip(models.Trip{
-		RiderID:  req.RiderId,
-		DriverID: req.DriverId,
-		StartLat: req.StartLat,
-		StartLon: req.StartLon,
-		EndLat:   req.EndLat,
-		EndLon:   req.EndLon,
+	trip, err := h.service.CreateTrip(ctx, models.Trip{
+		RiderID:     req.RiderId,
+		StartLat:    req.StartLat,
+		StartLon:    req.StartLon,
+		EndLat:      req.EndLat,
+		EndLon:      req.EndLon,
+		VehicleType: models.VehicleType(req.VehicleType),
+	}, req.GetQuoteId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &pb.CreateTripResponse{Trip: toPbTrip(trip)}, nil
+}
+
+func (h *GrpcHandler) GetFareQuote(ctx context.Context, req *pb.GetFareQuoteRequest) (*pb.GetFareQuoteResponse, error) {
+	quote, err := h.service.GetFareQuote(ctx, models.Trip{
+		RiderID:     req.RiderId,
+		StartLat:    req.StartLat,
+		StartLon:    req.StartLon,
+		EndLat:      req.EndLat,
+		EndLon:      req.EndLon,
+		VehicleType: models.VehicleType(req.VehicleType),
 	})
 	if err != nil {
-		return nil, err
+		return nil, grpcError(err)
 	}
-	return &pb.CreateTripResponse{Trip: &pb.Trip{Id: trip.ID, RiderId: trip.RiderID, DriverId: trip.DriverID, Status: string(trip.Status), Price: trip.Price}}, nil
+
+	fare := quote.Fare
+	return &pb.GetFareQuoteResponse{
+		QuoteId:          quote.ID,
+		Price:            toPbMoney(fare.Total),
+		VehicleType:      string(quote.VehicleType),
+		DistanceKm:       fare.DistanceKm,
+		DurationSeconds:  int64(fare.Duration.Seconds()),
+		PickupEtaSeconds: int64(quote.PickupETA.Seconds()),
+		ExpiresAt:        quote.ExpiresAt.Unix(),
+		Breakdown: &pb.FareBreakdown{
+			City:                  fare.City,
+			BaseFare:              toPbMoney(fare.BaseFare),
+			DistanceFare:          toPbMoney(fare.DistanceFare),
+			TimeFare:              toPbMoney(fare.TimeFare),
+			MinimumFareAdjustment: toPbMoney(fare.MinimumFareAdjustment),
+			SurgeMultiplier:       fare.SurgeMultiplier,
+			Surge:                 toPbMoney(fare.Surge),
+			BookingFee:            toPbMoney(fare.BookingFee),
+			Tolls:                 toPbMoney(fare.Tolls),
+			Total:                 toPbMoney(fare.Total),
+		},
+	}, nil
 }
 
 func (h *GrpcHandler) CompleteTrip(ctx context.Context, req *pb.CompleteTripRequest) (*pb.CompleteTripResponse, error) {
-	trip, err := h.service.CompleteTrip(req.GetTripId())
+	trip, err := h.service.CompleteTrip(ctx, req.GetTripId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return &pb.CompleteTripResponse{Trip: toPbTrip(trip)}, nil
+}
+
+func (h *GrpcHandler) UpdateTripStatus(ctx context.Context, req *pb.UpdateTripStatusRequest) (*pb.UpdateTripStatusResponse, error) {
+	trip, err := h.service.UpdateTripStatus(ctx, req.GetTripId(), models.TripStatus(req.GetStatus()))
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return &pb.UpdateTripStatusResponse{Trip: toPbTrip(trip)}, nil
+}
+
+func (h *GrpcHandler) CancelTrip(ctx context.Context, req *pb.CancelTripRequest) (*pb.CancelTripResponse, error) {
+	trip, err := h.service.CancelTrip(ctx,
+		req.GetTripId(),
+		models.CancellationParty(req.GetCancelledBy()),
+		req.GetCancellerId(),
+		req.GetReason(),
+	)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return &pb.CancelTripResponse{Trip: toPbTrip(trip)}, nil
+}
+
+func (h *GrpcHandler) GetTrip(ctx context.Context, req *pb.GetTripRequest) (*pb.GetTripResponse, error) {
+	trip, err := h.service.GetTrip(ctx, req.GetTripId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	return &pb.GetTripResponse{Trip: toPbTrip(trip)}, nil
+}
+
+func (h *GrpcHandler) GetSurge(ctx context.Context, req *pb.GetSurgeRequest) (*pb.GetSurgeResponse, error) {
+	surge := h.service.GetSurge(req.GetLat(), req.GetLon())
+	return &pb.GetSurgeResponse{ZoneId: surge.ZoneID, Multiplier: surge.Multiplier}, nil
+}
+
+func (h *GrpcHandler) ListTrips(ctx context.Context, req *pb.ListTripsRequest) (*pb.ListTripsResponse, error) {
+	filter := TripFilter{
+		RiderID:   req.GetRiderId(),
+		DriverID:  req.GetDriverId(),
+		Status:    models.TripStatus(req.GetStatus()),
+		PageSize:  int(req.GetPageSize()),
+		PageToken: req.GetPageToken(),
+	}
+	if req.GetFrom() > 0 {
+		filter.From = time.Unix(req.GetFrom(), 0)
+	}
+	if req.GetTo() > 0 {
+		filter.To = time.Unix(req.GetTo(), 0)
+	}
+
+	trips, nextPageToken, err := h.service.ListTrips(ctx, filter)
 	if err != nil {
-		return nil, err
+		return nil, grpcError(err)
 	}
 
-	pbTrip := &pb.Trip{
-		Id:       trip.ID,
-		RiderId:  trip.RiderID,
-		DriverId: trip.DriverID,
-		Status:   string(trip.Status),
-		Price:    trip.Price,
+	pbTrips := make([]*pb.Trip, 0, len(trips))
+	for _, trip := range trips {
+		pbTrips = append(pbTrips, toPbTrip(trip))
 	}
+	return &pb.ListTripsResponse{Trips: pbTrips, NextPageToken: nextPageToken}, nil
+}
+
+func (h *GrpcHandler) WatchTrip(req *pb.WatchTripRequest, stream pb.TripService_WatchTripServer) error {
+	err := h.service.WatchTrip(stream.Context(), req.TripId, func(update TripUpdate) error {
+		return stream.Send(toPbTripUpdate(update))
+	})
+	if err != nil {
+		return grpcError(err)
+	}
+	return nil
+}
+
+func toPbTripUpdate(update TripUpdate) *pb.TripUpdate {
+	res := &pb.TripUpdate{EtaSeconds: int64(update.ETA.Seconds())}
+	switch {
+	case update.Trip != nil:
+		res.Update = &pb.TripUpdate_Trip{Trip: toPbTrip(*update.Trip)}
+	case update.Location != nil:
+		loc := update.Location
+		res.Update = &pb.TripUpdate_DriverLocation{DriverLocation: &pb.DriverLocation{
+			DriverId:   loc.DriverID,
+			Lat:        loc.Lat,
+			Lon:        loc.Lon,
+			Heading:    loc.Heading,
+			SpeedKmh:   loc.SpeedKmh,
+			RecordedAt: unixOrZero(loc.RecordedAt),
+		}}
+	}
+	return res
+}
+
+func toPbTrip(trip models.Trip) *pb.Trip {
+	return &pb.Trip{
+		Id:                 trip.ID,
+		RiderId:            trip.RiderID,
+		DriverId:           trip.DriverID,
+		Status:             string(trip.Status),
+		Price:              toPbMoney(trip.Price),
+		CancelledBy:        string(trip.CancelledBy),
+		CancellationReason: trip.CancellationReason,
+		CancellationFee:    toPbMoney(trip.CancellationFee),
+		FinalPrice:         toPbMoney(trip.FinalPrice),
+		DistanceKm:         trip.DistanceKm,
+		StartedAt:          unixOrZero(trip.StartedAt),
+		CompletedAt:        unixOrZero(trip.CompletedAt),
+		StartLat:           trip.StartLat,
+		StartLon:           trip.StartLon,
+		EndLat:             trip.EndLat,
+		EndLon:             trip.EndLon,
+		RequestTime:        trip.RequestTime.Unix(),
+		UpdatedAt:          trip.UpdatedAt.Unix(),
+		VehicleType:        string(trip.VehicleType),
+		SurgeMultiplier:    trip.SurgeMultiplier,
+	}
+}
+
+// unixOrZero keeps unset times as 0 rather than a large negative number.
+func unixOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
 
-	return &pb.CompleteTripResponse{Trip: pbTrip}, nil
+func toPbMoney(m money.Money) *pb_money.Money {
+	return &pb_money.Money{Amount: m.Amount, Currency: m.Currency}
 }
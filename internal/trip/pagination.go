@@ -0,0 +1,106 @@
+package trip
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lukabrx/uber-clone/internal/models"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// TripFilter narrows ListTrips. Zero values match everything.
+type TripFilter struct {
+	RiderID  string
+	DriverID string
+	Status   models.TripStatus
+	// From and To bound RequestTime, inclusive of From and exclusive of To.
+	From      time.Time
+	To        time.Time
+	PageSize  int
+	PageToken string
+}
+
+func (f TripFilter) matches(trip models.Trip) bool {
+	if f.RiderID != "" && trip.RiderID != f.RiderID {
+		return false
+	}
+	if f.DriverID != "" && trip.DriverID != f.DriverID {
+		return false
+	}
+	if f.Status != "" && trip.Status != f.Status {
+		return false
+	}
+	if !f.From.IsZero() && trip.RequestTime.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !trip.RequestTime.Before(f.To) {
+		return false
+	}
+	return true
+}
+
+func (f TripFilter) pageSize() int {
+	switch {
+	case f.PageSize <= 0:
+		return defaultPageSize
+	case f.PageSize > maxPageSize:
+		return maxPageSize
+	default:
+		return f.PageSize
+	}
+}
+
+// tripCursor marks the last trip of a page. Trips are listed newest first,
+// with the ID breaking ties between trips requested at the same instant.
+type tripCursor struct {
+	RequestTime time.Time
+	ID          string
+}
+
+func cursorAfter(trip models.Trip) tripCursor {
+	return tripCursor{RequestTime: trip.RequestTime, ID: trip.ID}
+}
+
+// before reports whether trip sorts before the cursor, i.e. was already returned.
+func (c tripCursor) before(trip models.Trip) bool {
+	if !trip.RequestTime.Equal(c.RequestTime) {
+		return trip.RequestTime.After(c.RequestTime)
+	}
+	return trip.ID >= c.ID
+}
+
+func (c tripCursor) encode() string {
+	raw := strconv.FormatInt(c.RequestTime.UnixNano(), 10) + "|" + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(token string) (tripCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return tripCursor{}, fmt.Errorf("%w: %v", ErrInvalidPageToken, err)
+	}
+	nanos, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return tripCursor{}, ErrInvalidPageToken
+	}
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return tripCursor{}, fmt.Errorf("%w: %v", ErrInvalidPageToken, err)
+	}
+	return tripCursor{RequestTime: time.Unix(0, n), ID: id}, nil
+}
+
+// newestFirst orders trips by descending request time, then descending ID.
+func newestFirst(a, b models.Trip) int {
+	if c := b.RequestTime.Compare(a.RequestTime); c != 0 {
+		return c
+	}
+	return strings.Compare(b.ID, a.ID)
+}
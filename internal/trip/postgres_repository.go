@@ -0,0 +1,345 @@
+package trip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/lukabrx/uber-clone/internal/database"
+	"github.com/lukabrx/uber-clone/internal/models"
+	"github.com/lukabrx/uber-clone/internal/outbox"
+)
+
+const tripColumns = `id, rider_id, driver_id, start_lat, start_lon, end_lat, end_lon, vehicle_type, status,
+	price_amount, price_currency, surge_multiplier, request_time, updated_at,
+	started_at, completed_at, distance_km, final_price_amount, final_price_currency,
+	cancelled_by, canceller_id, cancellation_reason, cancellation_fee_amount, cancellation_fee_currency, cancelled_at, version`
+
+type PostgresRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresRepository(pool *pgxpool.Pool) *PostgresRepository {
+	return &PostgresRepository{pool: pool}
+}
+
+func scanTrip(row pgx.Row) (models.Trip, error) {
+	var t models.Trip
+	var startedAt, completedAt, cancelledAt *time.Time
+	err := row.Scan(&t.ID, &t.RiderID, &t.DriverID, &t.StartLat, &t.StartLon, &t.EndLat, &t.EndLon,
+		&t.VehicleType, &t.Status, &t.Price.Amount, &t.Price.Currency, &t.SurgeMultiplier,
+		&t.RequestTime, &t.UpdatedAt, &startedAt, &completedAt, &t.DistanceKm,
+		&t.FinalPrice.Amount, &t.FinalPrice.Currency, &t.CancelledBy, &t.CancellerID,
+		&t.CancellationReason, &t.CancellationFee.Amount, &t.CancellationFee.Currency, &cancelledAt, &t.Version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.Trip{}, ErrTripNotFound
+	}
+	if err != nil {
+		return models.Trip{}, err
+	}
+	t.StartedAt = database.TimeOrZero(startedAt)
+	t.CompletedAt = database.TimeOrZero(completedAt)
+	t.CancelledAt = database.TimeOrZero(cancelledAt)
+	return t, nil
+}
+
+func tripArgs(t models.Trip) []any {
+	return []any{t.ID, t.RiderID, t.DriverID, t.StartLat, t.StartLon, t.EndLat, t.EndLon,
+		t.VehicleType, t.Status, t.Price.Amount, t.Price.Currency, t.SurgeMultiplier,
+		t.RequestTime, t.UpdatedAt, database.NullTime(t.StartedAt), database.NullTime(t.CompletedAt), t.DistanceKm,
+		t.FinalPrice.Amount, t.FinalPrice.Currency, t.CancelledBy, t.CancellerID,
+		t.CancellationReason, t.CancellationFee.Amount, t.CancellationFee.Currency, database.NullTime(t.CancelledAt), t.Version}
+}
+
+const updateTripSQL = `
+	UPDATE trips SET
+		rider_id = $2, driver_id = $3, start_lat = $4, start_lon = $5, end_lat = $6, end_lon = $7,
+		vehicle_type = $8, status = $9, price_amount = $10, price_currency = $11, surge_multiplier = $12,
+		request_time = $13, updated_at = $14, started_at = $15, completed_at = $16, distance_km = $17,
+		final_price_amount = $18, final_price_currency = $19, cancelled_by = $20, canceller_id = $21,
+		cancellation_reason = $22, cancellation_fee_amount = $23, cancellation_fee_currency = $24,
+		cancelled_at = $25, version = $26
+	WHERE id = $1`
+
+func (r *PostgresRepository) CreateTrip(ctx context.Context, trip models.Trip) (models.Trip, error) {
+	return r.create(ctx, trip, nil)
+}
+
+// CreateQuotedTrip records the nonce in redeemed_quotes, whose primary key
+// turns a second redemption into ErrQuoteRedeemed, in the trip's transaction.
+func (r *PostgresRepository) CreateQuotedTrip(ctx context.Context, trip models.Trip, nonce string, expiresAt time.Time) (models.Trip, error) {
+	return r.create(ctx, trip, func(tx pgx.Tx, trip models.Trip) error {
+		if _, err := tx.Exec(ctx, `DELETE FROM redeemed_quotes WHERE expires_at < now()`); err != nil {
+			return err
+		}
+		_, err := tx.Exec(ctx,
+			`INSERT INTO redeemed_quotes (nonce, trip_id, expires_at) VALUES ($1, $2, $3)`,
+			nonce, trip.ID, expiresAt,
+		)
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return ErrQuoteRedeemed
+		}
+		return err
+	})
+}
+
+// create inserts a new trip and its event, then runs also, if set, in the same
+// transaction.
+func (r *PostgresRepository) create(ctx context.Context, trip models.Trip, also func(pgx.Tx, models.Trip) error) (models.Trip, error) {
+	trip.ID = uuid.New().String()
+	// Postgres keeps microseconds; truncate so page tokens built from the
+	// returned trip match the stored one.
+	trip.RequestTime = trip.RequestTime.Truncate(time.Microsecond)
+	trip.UpdatedAt = trip.RequestTime
+	trip.Version = 1
+
+	msg, err := tripCreatedMessage(ctx, trip)
+	if err != nil {
+		return models.Trip{}, err
+	}
+	err = pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx,
+			`INSERT INTO trips (`+tripColumns+`)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26)`,
+			tripArgs(trip)...,
+		)
+		if err != nil {
+			return err
+		}
+		if also != nil {
+			if err := also(tx, trip); err != nil {
+				return err
+			}
+		}
+		return outbox.EnqueueTx(ctx, tx, msg)
+	})
+	if err != nil {
+		return models.Trip{}, err
+	}
+	return trip, nil
+}
+
+func (r *PostgresRepository) GetTripByID(ctx context.Context, id string) (models.Trip, error) {
+	return scanTrip(r.pool.QueryRow(ctx, `SELECT `+tripColumns+` FROM trips WHERE id = $1`, id))
+}
+
+func (r *PostgresRepository) UpdateTrip(ctx context.Context, trip models.Trip) error {
+	tag, err := r.pool.Exec(ctx, updateTripSQL, tripArgs(trip)...)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrTripNotFound
+	}
+	return nil
+}
+
+// UpdateTripStatus has the same compare-and-set semantics as
+// MemoryRepository.UpdateTripStatus. The trip row stays locked while apply
+// runs, and the history row and the event are written in the same transaction.
+func (r *PostgresRepository) UpdateTripStatus(ctx context.Context, id string, from, to models.TripStatus, apply func(*models.Trip)) (models.Trip, error) {
+	var trip models.Trip
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		t, err := scanTrip(tx.QueryRow(ctx, `SELECT `+tripColumns+` FROM trips WHERE id = $1 FOR UPDATE`, id))
+		if err != nil {
+			return err
+		}
+		if t.Status != from {
+			return &InvalidTransitionError{TripID: id, From: t.Status, To: to}
+		}
+
+		now := time.Now().Truncate(time.Microsecond)
+		if apply != nil {
+			apply(&t)
+		}
+		t.Status = to
+		t.UpdatedAt = now
+		t.Version++
+
+		if _, err := tx.Exec(ctx, updateTripSQL, tripArgs(t)...); err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, `
+			INSERT INTO trip_status_changes (trip_id, from_status, to_status, changed_at)
+			VALUES ($1, $2, $3, $4)`,
+			id, from, to, now,
+		)
+		if err != nil {
+			return err
+		}
+		msg, err := tripStatusMessage(ctx, t, from)
+		if err != nil {
+			return err
+		}
+		trip = t
+		return outbox.EnqueueTx(ctx, tx, msg)
+	})
+	if err != nil {
+		return models.Trip{}, err
+	}
+	return trip, nil
+}
+
+func (r *PostgresRepository) GetTripStatusHistory(ctx context.Context, id string) ([]models.TripStatusChange, error) {
+	if err := r.ensureTrip(ctx, id); err != nil {
+		return nil, err
+	}
+	rows, err := r.pool.Query(ctx, `
+		SELECT trip_id, from_status, to_status, changed_at
+		FROM trip_status_changes WHERE trip_id = $1 ORDER BY id`,
+		id,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := []models.TripStatusChange{}
+	for rows.Next() {
+		var c models.TripStatusChange
+		if err := rows.Scan(&c.TripID, &c.From, &c.To, &c.ChangedAt); err != nil {
+			return nil, err
+		}
+		history = append(history, c)
+	}
+	return history, rows.Err()
+}
+
+func (r *PostgresRepository) GetTripsByStatus(ctx context.Context, statuses ...models.TripStatus) ([]models.Trip, error) {
+	names := make([]string, len(statuses))
+	for i, s := range statuses {
+		names[i] = string(s)
+	}
+	return r.queryTrips(ctx, `SELECT `+tripColumns+` FROM trips WHERE status = ANY($1)`, names)
+}
+
+// ListTrips pages through trips with the same order and page tokens as
+// MemoryRepository.ListTrips.
+func (r *PostgresRepository) ListTrips(ctx context.Context, filter TripFilter) ([]models.Trip, string, error) {
+	var where []string
+	var args []any
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		where = append(where, fmt.Sprintf(cond, len(args)))
+	}
+	if filter.RiderID != "" {
+		add("rider_id = $%d", filter.RiderID)
+	}
+	if filter.DriverID != "" {
+		add("driver_id = $%d", filter.DriverID)
+	}
+	if filter.Status != "" {
+		add("status = $%d", filter.Status)
+	}
+	if !filter.From.IsZero() {
+		add("request_time >= $%d", filter.From)
+	}
+	if !filter.To.IsZero() {
+		add("request_time < $%d", filter.To)
+	}
+	if filter.PageToken != "" {
+		c, err := decodeCursor(filter.PageToken)
+		if err != nil {
+			return nil, "", err
+		}
+		args = append(args, c.RequestTime, c.ID)
+		where = append(where, fmt.Sprintf("(request_time, id) < ($%d, $%d)", len(args)-1, len(args)))
+	}
+
+	query := `SELECT ` + tripColumns + ` FROM trips`
+	if len(where) > 0 {
+		query += ` WHERE ` + strings.Join(where, " AND ")
+	}
+	size := filter.pageSize()
+	args = append(args, size+1)
+	query += fmt.Sprintf(` ORDER BY request_time DESC, id DESC LIMIT $%d`, len(args))
+
+	trips, err := r.queryTrips(ctx, query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(trips) <= size {
+		return trips, "", nil
+	}
+	page := trips[:size]
+	return page, cursorAfter(page[size-1]).encode(), nil
+}
+
+func (r *PostgresRepository) AppendTracePoint(ctx context.Context, tripID string, point models.TracePoint) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO trip_trace_points (trip_id, lat, lon, recorded_at) VALUES ($1, $2, $3, $4)`,
+		tripID, point.Lat, point.Lon, point.RecordedAt,
+	)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+		return ErrTripNotFound
+	}
+	return err
+}
+
+// GetTrace returns a trip's recorded positions, oldest first.
+func (r *PostgresRepository) GetTrace(ctx context.Context, tripID string) ([]models.TracePoint, error) {
+	if err := r.ensureTrip(ctx, tripID); err != nil {
+		return nil, err
+	}
+	rows, err := r.pool.Query(ctx, `
+		SELECT lat, lon, recorded_at FROM trip_trace_points WHERE trip_id = $1 ORDER BY id`,
+		tripID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var trace []models.TracePoint
+	for rows.Next() {
+		var p models.TracePoint
+		if err := rows.Scan(&p.Lat, &p.Lon, &p.RecordedAt); err != nil {
+			return nil, err
+		}
+		trace = append(trace, p)
+	}
+	return trace, rows.Err()
+}
+
+const (
+	foreignKeyViolation = "23503"
+	uniqueViolation     = "23505"
+)
+
+func (r *PostgresRepository) ensureTrip(ctx context.Context, id string) error {
+	var exists bool
+	err := r.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM trips WHERE id = $1)`, id).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrTripNotFound
+	}
+	return nil
+}
+
+func (r *PostgresRepository) queryTrips(ctx context.Context, query string, args ...any) ([]models.Trip, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var trips []models.Trip
+	for rows.Next() {
+		t, err := scanTrip(rows)
+		if err != nil {
+			return nil, err
+		}
+		trips = append(trips, t)
+	}
+	return trips, rows.Err()
+}
@@ -0,0 +1,14 @@
+package trip_test
+
+import (
+	"testing"
+
+	"github.com/lukabrx/uber-clone/internal/repotest"
+	"github.com/lukabrx/uber-clone/internal/trip"
+)
+
+func TestPostgresRepository(t *testing.T) {
+	repotest.RunTripRepository(t, func(t *testing.T) trip.TripRepository {
+		return trip.NewPostgresRepository(repotest.Postgres(t))
+	})
+}
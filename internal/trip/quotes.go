@@ -0,0 +1,105 @@
+package trip
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lukabrx/uber-clone/internal/auth"
+	"github.com/lukabrx/uber-clone/internal/models"
+	"github.com/lukabrx/uber-clone/internal/money"
+	pricecalculator "github.com/lukabrx/uber-clone/internal/price_calculator"
+)
+
+// DefaultQuoteValidity is how long a rider has to book a quoted fare.
+const DefaultQuoteValidity = 5 * time.Minute
+
+// FareQuote is an upfront price for a trip. ID is a signed token carrying the
+// quote itself, so it cannot be altered and needs no storage until redeemed.
+type FareQuote struct {
+	ID          string
+	RiderID     string
+	StartLat    float64
+	StartLon    float64
+	EndLat      float64
+	EndLon      float64
+	VehicleType models.VehicleType
+	Fare        pricecalculator.FareBreakdown
+	// PickupETA is how far away the nearest matching driver is; zero if none is.
+	PickupETA time.Duration
+	ExpiresAt time.Time
+}
+
+// quotePayload is what gets sealed into a quote ID.
+type quotePayload struct {
+	Nonce           string             `json:"nonce"`
+	RiderID         string             `json:"rider_id"`
+	StartLat        float64            `json:"start_lat"`
+	StartLon        float64            `json:"start_lon"`
+	EndLat          float64            `json:"end_lat"`
+	EndLon          float64            `json:"end_lon"`
+	VehicleType     models.VehicleType `json:"vehicle_type"`
+	Price           money.Money        `json:"price"`
+	SurgeMultiplier float64            `json:"surge_multiplier"`
+	ExpiresAt       time.Time          `json:"expires_at"`
+}
+
+// Quotes signs and verifies fare quotes. That a quote is used at most once is
+// up to the repository, which records its nonce with the trip it booked.
+type Quotes struct {
+	maker    *auth.PasetoMaker
+	validity time.Duration
+}
+
+func NewQuotes(maker *auth.PasetoMaker, validity time.Duration) *Quotes {
+	return &Quotes{maker: maker, validity: validity}
+}
+
+// Sign sets the quote's expiry and ID.
+func (q *Quotes) Sign(quote *FareQuote) error {
+	quote.ExpiresAt = time.Now().Add(q.validity)
+	id, err := q.maker.Seal(quotePayload{
+		Nonce:           uuid.New().String(),
+		RiderID:         quote.RiderID,
+		StartLat:        quote.StartLat,
+		StartLon:        quote.StartLon,
+		EndLat:          quote.EndLat,
+		EndLon:          quote.EndLon,
+		VehicleType:     quote.VehicleType,
+		Price:           quote.Fare.Total,
+		SurgeMultiplier: quote.Fare.SurgeMultiplier,
+		ExpiresAt:       quote.ExpiresAt,
+	})
+	if err != nil {
+		return err
+	}
+	quote.ID = id
+	return nil
+}
+
+// Open verifies a quote ID for the rider.
+func (q *Quotes) Open(id, riderID string) (quotePayload, error) {
+	var payload quotePayload
+	if err := q.maker.Open(id, &payload); err != nil {
+		return quotePayload{}, ErrInvalidQuote
+	}
+	if time.Now().After(payload.ExpiresAt) {
+		return quotePayload{}, ErrQuoteExpired
+	}
+	if payload.RiderID != riderID {
+		return quotePayload{}, ErrQuoteNotForRider
+	}
+	return payload, nil
+}
+
+// matches reports whether a trip request is for the quoted route. Unset
+// coordinates and vehicle type are taken from the quote.
+func (p quotePayload) matches(req models.Trip) bool {
+	const epsilon = 1e-6
+	same := func(got, want float64) bool {
+		return got == 0 || math.Abs(got-want) < epsilon
+	}
+	return same(req.StartLat, p.StartLat) && same(req.StartLon, p.StartLon) &&
+		same(req.EndLat, p.EndLat) && same(req.EndLon, p.EndLon) &&
+		(req.VehicleType == "" || req.VehicleType == p.VehicleType)
+}
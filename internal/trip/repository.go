@@ -1,66 +1,258 @@
 package trip
 
 import (
-	"errors"
+	"context"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/lukabrx/uber-clone/internal/models"
+	"github.com/lukabrx/uber-clone/internal/outbox"
 )
 
+// TripRepository stores trips together with their status history and GPS traces.
+// Creating a trip and changing its status also store the matching trip event
+// in the outbox, in the same write.
+type TripRepository interface {
+	CreateTrip(ctx context.Context, trip models.Trip) (models.Trip, error)
+	// CreateQuotedTrip creates a trip booked with a fare quote and records the
+	// quote's nonce in the same write. It fails with ErrQuoteRedeemed, creating
+	// nothing, if the nonce has been recorded before. Nonces may be forgotten
+	// after expiresAt, when the quote can no longer be redeemed anyway.
+	CreateQuotedTrip(ctx context.Context, trip models.Trip, nonce string, expiresAt time.Time) (models.Trip, error)
+	GetTripByID(ctx context.Context, id string) (models.Trip, error)
+	UpdateTrip(ctx context.Context, trip models.Trip) error
+	UpdateTripStatus(ctx context.Context, id string, from, to models.TripStatus, apply func(*models.Trip)) (models.Trip, error)
+	GetTripStatusHistory(ctx context.Context, id string) ([]models.TripStatusChange, error)
+	GetTripsByStatus(ctx context.Context, statuses ...models.TripStatus) ([]models.Trip, error)
+	ListTrips(ctx context.Context, filter TripFilter) ([]models.Trip, string, error)
+	AppendTracePoint(ctx context.Context, tripID string, point models.TracePoint) error
+	GetTrace(ctx context.Context, tripID string) ([]models.TracePoint, error)
+}
+
 type MemoryRepository struct {
-	trips map[string]*models.Trip
-	mu    sync.RWMutex
+	trips   map[string]*models.Trip
+	history map[string][]models.TripStatusChange
+	traces  map[string][]models.TracePoint
+	events  outbox.Store // nil drops events
+	// redeemed holds the nonces of used quotes until they expire.
+	redeemed map[string]time.Time
+	mu       sync.RWMutex
 }
 
-func NewMemoryRepository() *MemoryRepository {
+func NewMemoryRepository(events outbox.Store) *MemoryRepository {
 	return &MemoryRepository{
-		trips: make(map[string]*models.Trip),
+		trips:    make(map[string]*models.Trip),
+		history:  make(map[string][]models.TripStatusChange),
+		traces:   make(map[string][]models.TracePoint),
+		events:   events,
+		redeemed: make(map[string]time.Time),
+	}
+}
+
+// enqueue stores msg in the outbox. Callers must hold the write lock and only
+// apply their change once it succeeds.
+func (r *MemoryRepository) enqueue(ctx context.Context, msg outbox.Message) error {
+	if r.events == nil {
+		return nil
 	}
+	return r.events.Enqueue(ctx, msg)
+}
+
+func (r *MemoryRepository) CreateTrip(ctx context.Context, trip models.Trip) (models.Trip, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.create(ctx, trip)
 }
 
-func (r *MemoryRepository) CreateTrip(trip models.Trip) (models.Trip, error) {
+// CreateQuotedTrip creates a trip booked with a fare quote and records the
+// quote's nonce under the same lock. It fails with ErrQuoteRedeemed, creating
+// nothing, if the nonce has been recorded before. Nonces are forgotten after
+// expiresAt, when the quote can no longer be redeemed anyway.
+func (r *MemoryRepository) CreateQuotedTrip(ctx context.Context, trip models.Trip, nonce string, expiresAt time.Time) (models.Trip, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	now := time.Now()
+	for n, expiry := range r.redeemed {
+		if now.After(expiry) {
+			delete(r.redeemed, n)
+		}
+	}
+	if _, ok := r.redeemed[nonce]; ok {
+		return models.Trip{}, ErrQuoteRedeemed
+	}
+	created, err := r.create(ctx, trip)
+	if err != nil {
+		return models.Trip{}, err
+	}
+	r.redeemed[nonce] = expiresAt
+	return created, nil
+}
+
+// create stores a new trip and its event. Callers must hold the write lock.
+func (r *MemoryRepository) create(ctx context.Context, trip models.Trip) (models.Trip, error) {
 	trip.ID = uuid.New().String()
+	trip.UpdatedAt = trip.RequestTime
+	trip.Version = 1
+	msg, err := tripCreatedMessage(ctx, trip)
+	if err != nil {
+		return models.Trip{}, err
+	}
+	if err := r.enqueue(ctx, msg); err != nil {
+		return models.Trip{}, err
+	}
 	r.trips[trip.ID] = &trip
 
 	return trip, nil
 }
 
-func (r *MemoryRepository) GetTripByID(id string) (models.Trip, error) {
+func (r *MemoryRepository) GetTripByID(ctx context.Context, id string) (models.Trip, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	trip, ok := r.trips[id]
 	if !ok {
-		return models.Trip{}, errors.New("trip not found")
+		return models.Trip{}, ErrTripNotFound
 	}
 	return *trip, nil
 }
 
-func (r *MemoryRepository) UpdateTrip(trip models.Trip) error {
+func (r *MemoryRepository) UpdateTrip(ctx context.Context, trip models.Trip) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	existing, ok := r.trips[trip.ID]
 	if !ok {
-		return errors.New("trip not found")
+		return ErrTripNotFound
 	}
 	*existing = trip
 	return nil
 }
 
-func (r *MemoryRepository) GetInProgressTrips() ([]models.Trip, error) {
+// UpdateTripStatus moves a trip from one status to another only if it is still
+// in the expected status, so concurrent callers cannot apply the same transition
+// twice. apply, when set, runs under the lock to update other fields together
+// with the status.
+func (r *MemoryRepository) UpdateTripStatus(ctx context.Context, id string, from, to models.TripStatus, apply func(*models.Trip)) (models.Trip, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.trips[id]
+	if !ok {
+		return models.Trip{}, ErrTripNotFound
+	}
+	if stored.Status != from {
+		return models.Trip{}, &InvalidTransitionError{TripID: id, From: stored.Status, To: to}
+	}
+
+	now := time.Now()
+	trip := *stored
+	if apply != nil {
+		apply(&trip)
+	}
+	trip.Status = to
+	trip.UpdatedAt = now
+	trip.Version++
+	msg, err := tripStatusMessage(ctx, trip, from)
+	if err != nil {
+		return models.Trip{}, err
+	}
+	if err := r.enqueue(ctx, msg); err != nil {
+		return models.Trip{}, err
+	}
+	*stored = trip
+
+	r.history[id] = append(r.history[id], models.TripStatusChange{
+		TripID:    id,
+		From:      from,
+		To:        to,
+		ChangedAt: now,
+	})
+
+	return trip, nil
+}
+
+func (r *MemoryRepository) GetTripStatusHistory(ctx context.Context, id string) ([]models.TripStatusChange, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if _, ok := r.trips[id]; !ok {
+		return nil, ErrTripNotFound
+	}
+	history := make([]models.TripStatusChange, len(r.history[id]))
+	copy(history, r.history[id])
+	return history, nil
+}
+
+func (r *MemoryRepository) GetTripsByStatus(ctx context.Context, statuses ...models.TripStatus) ([]models.Trip, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	var inProgressTrips []models.Trip
+	var trips []models.Trip
+	for _, trip := range r.trips {
+		for _, status := range statuses {
+			if trip.Status == status {
+				trips = append(trips, *trip)
+				break
+			}
+		}
+	}
+	return trips, nil
+}
+
+// ListTrips returns one page of trips matching filter, newest first, and the
+// token for the next page. The token is empty on the last page.
+func (r *MemoryRepository) ListTrips(ctx context.Context, filter TripFilter) ([]models.Trip, string, error) {
+	var cursor *tripCursor
+	if filter.PageToken != "" {
+		c, err := decodeCursor(filter.PageToken)
+		if err != nil {
+			return nil, "", err
+		}
+		cursor = &c
+	}
+
+	r.mu.RLock()
+	var matched []models.Trip
 	for _, trip := range r.trips {
-		if trip.Status == "in_progress" {
-			inProgressTrips = append(inProgressTrips, *trip)
+		if filter.matches(*trip) && (cursor == nil || !cursor.before(*trip)) {
+			matched = append(matched, *trip)
 		}
 	}
-	return inProgressTrips, nil
+	r.mu.RUnlock()
+
+	slices.SortFunc(matched, newestFirst)
+
+	size := filter.pageSize()
+	if len(matched) <= size {
+		return matched, "", nil
+	}
+	page := matched[:size]
+	return page, cursorAfter(page[size-1]).encode(), nil
+}
+
+// AppendTracePoint records a driver position against a trip.
+func (r *MemoryRepository) AppendTracePoint(ctx context.Context, tripID string, point models.TracePoint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.trips[tripID]; !ok {
+		return ErrTripNotFound
+	}
+	r.traces[tripID] = append(r.traces[tripID], point)
+	return nil
+}
+
+// GetTrace returns a trip's recorded positions, oldest first.
+func (r *MemoryRepository) GetTrace(ctx context.Context, tripID string) ([]models.TracePoint, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if _, ok := r.trips[tripID]; !ok {
+		return nil, ErrTripNotFound
+	}
+	return slices.Clone(r.traces[tripID]), nil
 }
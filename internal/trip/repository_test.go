@@ -0,0 +1,15 @@
+package trip_test
+
+import (
+	"testing"
+
+	"github.com/lukabrx/uber-clone/internal/outbox"
+	"github.com/lukabrx/uber-clone/internal/repotest"
+	"github.com/lukabrx/uber-clone/internal/trip"
+)
+
+func TestMemoryRepository(t *testing.T) {
+	repotest.RunTripRepository(t, func(t *testing.T) trip.TripRepository {
+		return trip.NewMemoryRepository(outbox.NewMemoryStore())
+	})
+}
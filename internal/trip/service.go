@@ -2,6 +2,9 @@ package trip
 
 import (
 	"context"
+	"errors"
+	"log"
+	"time"
 
 	pb_driver "github.com/lukabrx/uber-clone/api/proto/driver/v1"
 	"github.com/lukabrx/uber-clone/internal/models"
@@ -9,61 +12,357 @@ import (
 )
 
 type Service struct {
-	repo          *MemoryRepository
-	driverClient  pb_driver.DriverServiceClient
-	kafkaProducer *KafkaProducer
+	repo               TripRepository
+	driverClient       pb_driver.DriverServiceClient
+	dispatcher         *Dispatcher
+	pricing            *pricecalculator.Calculator
+	quotes             *Quotes
+	cancellationPolicy CancellationPolicy
+	fareTolerance      FareTolerance
+	watchers           *watchers
 }
 
-func NewService(repo *MemoryRepository, driverClient pb_driver.DriverServiceClient, kafkaProducer *KafkaProducer) *Service {
-	return &Service{repo: repo, driverClient: driverClient, kafkaProducer: kafkaProducer}
+func NewService(repo TripRepository, driverClient pb_driver.DriverServiceClient, dispatcher *Dispatcher, pricing *pricecalculator.Calculator, quotes *Quotes) *Service {
+	return &Service{
+		repo:               repo,
+		driverClient:       driverClient,
+		dispatcher:         dispatcher,
+		pricing:            pricing,
+		quotes:             quotes,
+		cancellationPolicy: DefaultCancellationPolicy,
+		fareTolerance:      DefaultFareTolerance,
+		watchers:           newWatchers(),
+	}
 }
 
-func (s *Service) CreateTrip(req models.Trip) (models.Trip, error) {
-	price, err := pricecalculator.CalculatePrice(req.StartLat, req.StartLon, req.EndLat, req.EndLon)
+// GetFareQuote prices a trip upfront. The returned quote can be passed to
+// CreateTrip to book at the quoted price.
+func (s *Service) GetFareQuote(ctx context.Context, req models.Trip) (FareQuote, error) {
+	if req.VehicleType == "" {
+		req.VehicleType = models.VehicleEconomy
+	}
+	fare, err := s.pricing.CalculatePrice(ctx, pricecalculator.FareRequest{
+		StartLat:    req.StartLat,
+		StartLon:    req.StartLon,
+		EndLat:      req.EndLat,
+		EndLon:      req.EndLon,
+		VehicleType: req.VehicleType,
+	})
 	if err != nil {
-		return models.Trip{}, err
+		return FareQuote{}, err
+	}
+
+	quote := FareQuote{
+		RiderID:     req.RiderID,
+		StartLat:    req.StartLat,
+		StartLon:    req.StartLon,
+		EndLat:      req.EndLat,
+		EndLon:      req.EndLon,
+		VehicleType: req.VehicleType,
+		Fare:        fare,
+		PickupETA:   s.pickupETA(ctx, req),
 	}
+	if err := s.quotes.Sign(&quote); err != nil {
+		return FareQuote{}, err
+	}
+	return quote, nil
+}
 
+// pickupETA is the ETA of the nearest available driver of the trip's class. A
+// failed lookup only leaves the ETA out of the quote.
+func (s *Service) pickupETA(ctx context.Context, req models.Trip) time.Duration {
+	res, err := s.driverClient.FindAvailableDrivers(ctx, &pb_driver.FindAvailableDriversRequest{
+		Lat:          req.StartLat,
+		Lon:          req.StartLon,
+		Limit:        1,
+		VehicleTypes: []string{string(req.VehicleType)},
+	})
+	if err != nil {
+		log.Printf("Failed to look up pickup ETA: %v", err)
+		return 0
+	}
+	if len(res.Drivers) == 0 {
+		return 0
+	}
+	return time.Duration(res.Drivers[0].EtaSeconds) * time.Second
+}
+
+// CreateTrip books a trip. With a quoteID the trip is booked at the quoted
+// price, provided the quote is valid, unused and for the same route; without
+// one it is priced now.
+func (s *Service) CreateTrip(ctx context.Context, req models.Trip, quoteID string) (models.Trip, error) {
 	trip := models.Trip{
-		RiderID:  req.RiderID,
-		DriverID: req.DriverID,
-		Status:   "in_progress",
-		Price:    price,
+		RiderID:     req.RiderID,
+		StartLat:    req.StartLat,
+		StartLon:    req.StartLon,
+		EndLat:      req.EndLat,
+		EndLon:      req.EndLon,
+		VehicleType: req.VehicleType,
+		Status:      models.TripStatusRequested,
+		RequestTime: time.Now(),
+	}
+
+	var quote quotePayload
+	if quoteID != "" {
+		var err error
+		quote, err = s.quotes.Open(quoteID, req.RiderID)
+		if err != nil {
+			return models.Trip{}, err
+		}
+		if !quote.matches(req) {
+			return models.Trip{}, ErrQuoteMismatch
+		}
+		trip.StartLat, trip.StartLon = quote.StartLat, quote.StartLon
+		trip.EndLat, trip.EndLon = quote.EndLat, quote.EndLon
+		trip.VehicleType = quote.VehicleType
+		trip.Price = quote.Price
+		trip.SurgeMultiplier = quote.SurgeMultiplier
+	} else {
+		if trip.VehicleType == "" {
+			trip.VehicleType = models.VehicleEconomy
+		}
+		fare, err := s.pricing.CalculatePrice(ctx, pricecalculator.FareRequest{
+			StartLat:    trip.StartLat,
+			StartLon:    trip.StartLon,
+			EndLat:      trip.EndLat,
+			EndLon:      trip.EndLon,
+			VehicleType: trip.VehicleType,
+		})
+		if err != nil {
+			return models.Trip{}, err
+		}
+		trip.Price = fare.Total
+		trip.SurgeMultiplier = fare.SurgeMultiplier
+	}
+
+	var createdTrip models.Trip
+	var err error
+	if quoteID != "" {
+		createdTrip, err = s.repo.CreateQuotedTrip(ctx, trip, quote.Nonce, quote.ExpiresAt)
+	} else {
+		createdTrip, err = s.repo.CreateTrip(ctx, trip)
 	}
-	createdTrip, err := s.repo.CreateTrip(trip)
 	if err != nil {
 		return models.Trip{}, err
 	}
 
-	s.kafkaProducer.ProduceTripCreated(createdTrip.ID, createdTrip.DriverID)
+	go s.dispatch(createdTrip)
 
 	return createdTrip, nil
 }
 
-func (s *Service) CompleteTrip(tripID string) (models.Trip, error) {
-	trip, err := s.repo.GetTripByID(tripID)
+// dispatch looks for a driver in the background. Riders follow the trip until it
+// reaches driver_assigned, or cancelled if nobody accepted it.
+func (s *Service) dispatch(trip models.Trip) {
+	ctx := context.Background()
+	driverID, err := s.dispatcher.Dispatch(trip)
 	if err != nil {
-		return models.Trip{}, err
+		if errors.Is(err, context.Canceled) {
+			return
+		}
+		log.Printf("Dispatch failed for trip %s: %v", trip.ID, err)
+		if _, err := s.CancelTrip(ctx, trip.ID, models.CancelledBySystem, "", ErrNoDriverAvailable.Error()); err != nil {
+			log.Printf("Failed to cancel undispatched trip %s: %v", trip.ID, err)
+		}
+		return
+	}
+
+	if _, err := s.AssignDriver(ctx, trip.ID, driverID); err != nil {
+		log.Printf("Failed to assign driver %s to trip %s: %v", driverID, trip.ID, err)
+		s.dispatcher.Release(driverID, trip.ID)
+	}
+}
+
+func (s *Service) AssignDriver(ctx context.Context, tripID, driverID string) (models.Trip, error) {
+	return s.transition(ctx, tripID, models.TripStatusDriverAssigned, func(t *models.Trip) {
+		t.DriverID = driverID
+	})
+}
+
+// UpdateTripStatus advances a trip to the given status. Assigning a driver goes
+// through AssignDriver because it needs a driver ID, and cancelling goes through
+// CancelTrip because it needs to know who cancelled.
+func (s *Service) UpdateTripStatus(ctx context.Context, tripID string, status models.TripStatus) (models.Trip, error) {
+	if !isKnownStatus(status) {
+		return models.Trip{}, ErrUnknownStatus
+	}
+	if status == models.TripStatusCancelled {
+		return models.Trip{}, ErrCancelViaCancel
+	}
+	if status == models.TripStatusDriverAssigned || status == models.TripStatusRequested {
+		current, err := s.repo.GetTripByID(ctx, tripID)
+		if err != nil {
+			return models.Trip{}, err
+		}
+		return models.Trip{}, &InvalidTransitionError{TripID: tripID, From: current.Status, To: status}
 	}
 
-	trip.Status = models.TripStatusCompleted
-	if err := s.repo.UpdateTrip(trip); err != nil {
+	var apply func(*models.Trip)
+	switch status {
+	case models.TripStatusInProgress:
+		apply = func(t *models.Trip) { t.StartedAt = time.Now() }
+	case models.TripStatusCompleted:
+		trace, err := s.repo.GetTrace(ctx, tripID)
+		if err != nil {
+			return models.Trip{}, err
+		}
+		apply = func(t *models.Trip) { s.settleFare(t, trace, time.Now()) }
+	}
+
+	trip, err := s.transition(ctx, tripID, status, apply)
+	if err != nil {
 		return models.Trip{}, err
 	}
 
-	updateReq := &pb_driver.UpdateDriverStatusRequest{
-		Id:          trip.DriverID,
-		IsAvailable: true, // The driver is now free
+	if isTerminal(trip.Status) {
+		if err := s.releaseDriver(ctx, trip); err != nil {
+			return models.Trip{}, err
+		}
 	}
-	_, err = s.driverClient.UpdateDriverStatus(context.Background(), updateReq)
+	return trip, nil
+}
+
+// settleFare re-prices a completing trip from its GPS trace and elapsed time,
+// then decides with the fare tolerance what the rider is charged. Without a
+// usable trace the estimate is charged.
+func (s *Service) settleFare(t *models.Trip, trace []models.TracePoint, now time.Time) {
+	t.CompletedAt = now
+	t.FinalPrice = t.Price
+	if len(trace) < 2 {
+		log.Printf("Trip %s has no usable GPS trace, charging the estimate", t.ID)
+		return
+	}
+
+	t.DistanceKm = traceDistanceKm(trace)
+	fare, err := s.pricing.RecalculatePrice(pricecalculator.FareRequest{
+		StartLat:    t.StartLat,
+		StartLon:    t.StartLon,
+		EndLat:      t.EndLat,
+		EndLon:      t.EndLon,
+		VehicleType: t.VehicleType,
+	}, t.DistanceKm, elapsedSince(t.StartedAt, now), t.SurgeMultiplier)
+	if err != nil {
+		log.Printf("Failed to re-price trip %s, charging the estimate: %v", t.ID, err)
+		return
+	}
+
+	t.FinalPrice = s.fareTolerance.Settle(t.Price, fare.Total)
+	log.Printf("Trip %s drove %.2f km: estimate %s, re-priced %s, charged %s",
+		t.ID, t.DistanceKm, t.Price, fare.Total, t.FinalPrice)
+}
+
+// RecordLocation adds a driver position to the trace of the trip they are
+// driving. Positions before pickup or from other drivers are ignored.
+func (s *Service) RecordLocation(ctx context.Context, driver models.Driver) error {
+	if driver.TripID == "" {
+		return nil
+	}
+	trip, err := s.repo.GetTripByID(ctx, driver.TripID)
+	if err != nil {
+		return err
+	}
+	if trip.Status != models.TripStatusInProgress || trip.DriverID != driver.ID {
+		return nil
+	}
+
+	return s.repo.AppendTracePoint(ctx, trip.ID, models.TracePoint{
+		Lat:        driver.Lat,
+		Lon:        driver.Lon,
+		RecordedAt: driver.LocationUpdatedAt,
+	})
+}
+
+func (s *Service) CancelTrip(ctx context.Context, tripID string, by models.CancellationParty, cancellerID, reason string) (models.Trip, error) {
+	current, err := s.repo.GetTripByID(ctx, tripID)
 	if err != nil {
 		return models.Trip{}, err
 	}
 
-	s.kafkaProducer.ProduceTripCompleted(trip.ID, trip.DriverID)
+	switch by {
+	case models.CancelledByRider:
+		if cancellerID != current.RiderID {
+			return models.Trip{}, ErrNotTripParticipant
+		}
+	case models.CancelledByDriver:
+		if current.DriverID == "" || cancellerID != current.DriverID {
+			return models.Trip{}, ErrNotTripParticipant
+		}
+	case models.CancelledBySystem:
+	default:
+		return models.Trip{}, ErrInvalidCanceller
+	}
 
+	now := time.Now()
+	trip, err := s.transition(ctx, tripID, models.TripStatusCancelled, func(t *models.Trip) {
+		t.CancelledBy = by
+		t.CancellerID = cancellerID
+		t.CancellationReason = reason
+		t.CancellationFee = s.cancellationPolicy.FeeFor(*t, by, now)
+		t.CancelledAt = now
+	})
+	if err != nil {
+		return models.Trip{}, err
+	}
+
+	s.dispatcher.Abort(tripID)
+	if err := s.releaseDriver(ctx, trip); err != nil {
+		return models.Trip{}, err
+	}
 	return trip, nil
 }
-func (s *Service) GetInProgressTrips() ([]models.Trip, error) {
-	return s.repo.GetInProgressTrips()
+
+func (s *Service) CompleteTrip(ctx context.Context, tripID string) (models.Trip, error) {
+	return s.UpdateTripStatus(ctx, tripID, models.TripStatusCompleted)
+}
+
+func (s *Service) GetTrip(ctx context.Context, tripID string) (models.Trip, error) {
+	return s.repo.GetTripByID(ctx, tripID)
+}
+
+// GetSurge returns the surge riders would currently pay at a pickup point.
+func (s *Service) GetSurge(lat, lon float64) pricecalculator.Surge {
+	return s.pricing.Surge(lat, lon)
+}
+
+func (s *Service) ListTrips(ctx context.Context, filter TripFilter) ([]models.Trip, string, error) {
+	if filter.Status != "" && !isKnownStatus(filter.Status) {
+		return nil, "", ErrUnknownStatus
+	}
+	return s.repo.ListTrips(ctx, filter)
+}
+
+func (s *Service) GetActiveTrips(ctx context.Context) ([]models.Trip, error) {
+	return s.repo.GetTripsByStatus(ctx,
+		models.TripStatusDriverAssigned,
+		models.TripStatusDriverArriving,
+		models.TripStatusInProgress,
+	)
+}
+
+// transition validates and stores a single status change. The repository
+// stores the trip event with it.
+func (s *Service) transition(ctx context.Context, tripID string, to models.TripStatus, apply func(*models.Trip)) (models.Trip, error) {
+	current, err := s.repo.GetTripByID(ctx, tripID)
+	if err != nil {
+		return models.Trip{}, err
+	}
+
+	if !canTransition(current.Status, to) {
+		return models.Trip{}, &InvalidTransitionError{TripID: tripID, From: current.Status, To: to}
+	}
+
+	return s.repo.UpdateTripStatus(ctx, tripID, current.Status, to, apply)
+}
+
+func (s *Service) releaseDriver(ctx context.Context, trip models.Trip) error {
+	if trip.DriverID == "" {
+		return nil
+	}
+
+	// The driver is now free
+	_, err := s.driverClient.ReleaseDriver(ctx, &pb_driver.ReleaseDriverRequest{
+		DriverId: trip.DriverID,
+		TripId:   trip.ID,
+	})
+	return err
 }
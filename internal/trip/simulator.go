@@ -1,50 +1,57 @@
 package trip
 
 import (
+	"context"
 	"log"
 	"time"
+
+	"github.com/lukabrx/uber-clone/internal/models"
 )
 
+// simulatedNextStatus is the step the simulator takes for each active status.
+var simulatedNextStatus = map[models.TripStatus]models.TripStatus{
+	models.TripStatusDriverAssigned: models.TripStatusDriverArriving,
+	models.TripStatusDriverArriving: models.TripStatusInProgress,
+	models.TripStatusInProgress:     models.TripStatusCompleted,
+}
+
 type TripSimulator struct {
-	service  *Service
-	producer *KafkaProducer
+	service *Service
 }
 
-func NewTripSimulator(service *Service, producer *KafkaProducer) *TripSimulator {
-	return &TripSimulator{
-		service:  service,
-		producer: producer,
-	}
+func NewTripSimulator(service *Service) *TripSimulator {
+	return &TripSimulator{service: service}
 }
 
 func (ts *TripSimulator) Start() {
-	log.Println("Starting trip completion simulator...")
+	log.Println("Starting trip lifecycle simulator...")
 	go func() {
 		ticker := time.NewTicker(1 * time.Minute)
 		defer ticker.Stop()
 
+		ctx := context.Background()
 		for range ticker.C {
-			log.Println("Simulator checking for in-progress trips...")
-			inProgressTrips, err := ts.service.GetInProgressTrips()
+			log.Println("Simulator checking for active trips...")
+			activeTrips, err := ts.service.GetActiveTrips(ctx)
 			if err != nil {
-				log.Printf("Simulator failed to get in-progress trips: %v", err)
+				log.Printf("Simulator failed to get active trips: %v", err)
 				continue
 			}
 
-			if len(inProgressTrips) == 0 {
-				log.Println("No in-progress trips to complete.")
+			if len(activeTrips) == 0 {
+				log.Println("No active trips to advance.")
 				continue
 			}
 
-			for _, trip := range inProgressTrips {
-				_, err := ts.service.CompleteTrip(trip.ID)
+			for _, trip := range activeTrips {
+				next := simulatedNextStatus[trip.Status]
+				_, err := ts.service.UpdateTripStatus(ctx, trip.ID, next)
 				if err != nil {
-					log.Printf("Simulator failed to complete trip %s: %v", trip.ID, err)
+					log.Printf("Simulator failed to move trip %s to %s: %v", trip.ID, next, err)
 					continue
 				}
 
-				log.Printf("Simulator completed trip %s for driver %s", trip.ID, trip.DriverID)
-
+				log.Printf("Simulator moved trip %s for driver %s to %s", trip.ID, trip.DriverID, next)
 			}
 		}
 	}()
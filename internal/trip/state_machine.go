@@ -0,0 +1,60 @@
+package trip
+
+import (
+	"github.com/lukabrx/uber-clone/internal/models"
+	"github.com/lukabrx/uber-clone/internal/types"
+)
+
+// tripTransitions lists, for every status, the statuses a trip may move to next.
+// Statuses that are missing from the map are terminal.
+var tripTransitions = map[models.TripStatus][]models.TripStatus{
+	models.TripStatusRequested: {
+		models.TripStatusDriverAssigned,
+		models.TripStatusCancelled,
+	},
+	models.TripStatusDriverAssigned: {
+		models.TripStatusDriverArriving,
+		models.TripStatusCancelled,
+	},
+	models.TripStatusDriverArriving: {
+		models.TripStatusInProgress,
+		models.TripStatusCancelled,
+		models.TripStatusNoShow,
+	},
+	models.TripStatusInProgress: {
+		models.TripStatusCompleted,
+	},
+}
+
+// statusEvents maps the status a trip enters to the event published for it.
+var statusEvents = map[models.TripStatus]types.EventType{
+	models.TripStatusDriverAssigned: types.TripDriverAssignedEvent,
+	models.TripStatusDriverArriving: types.TripDriverArrivingEvent,
+	models.TripStatusInProgress:     types.TripStartedEvent,
+	models.TripStatusCompleted:      types.TripCompletedEvent,
+	models.TripStatusCancelled:      types.TripCancelledEvent,
+	models.TripStatusNoShow:         types.TripNoShowEvent,
+}
+
+func canTransition(from, to models.TripStatus) bool {
+	for _, next := range tripTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+func isKnownStatus(status models.TripStatus) bool {
+	if status == models.TripStatusRequested {
+		return true
+	}
+	_, ok := statusEvents[status]
+	return ok
+}
+
+// isTerminal reports whether a trip in this status has released its driver.
+func isTerminal(status models.TripStatus) bool {
+	_, ok := tripTransitions[status]
+	return !ok
+}
@@ -0,0 +1,120 @@
+package trip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lukabrx/uber-clone/internal/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to models.TripStatus
+		want     bool
+	}{
+		{models.TripStatusRequested, models.TripStatusDriverAssigned, true},
+		{models.TripStatusRequested, models.TripStatusCancelled, true},
+		{models.TripStatusDriverAssigned, models.TripStatusDriverArriving, true},
+		{models.TripStatusDriverAssigned, models.TripStatusCancelled, true},
+		{models.TripStatusDriverArriving, models.TripStatusInProgress, true},
+		{models.TripStatusDriverArriving, models.TripStatusCancelled, true},
+		{models.TripStatusDriverArriving, models.TripStatusNoShow, true},
+		{models.TripStatusInProgress, models.TripStatusCompleted, true},
+
+		{models.TripStatusRequested, models.TripStatusInProgress, false},
+		{models.TripStatusRequested, models.TripStatusCompleted, false},
+		{models.TripStatusDriverAssigned, models.TripStatusRequested, false},
+		{models.TripStatusDriverAssigned, models.TripStatusNoShow, false},
+		{models.TripStatusInProgress, models.TripStatusCancelled, false},
+		{models.TripStatusInProgress, models.TripStatusDriverArriving, false},
+		{models.TripStatusCompleted, models.TripStatusCompleted, false},
+		{models.TripStatusCompleted, models.TripStatusInProgress, false},
+		{models.TripStatusCancelled, models.TripStatusDriverAssigned, false},
+		{models.TripStatusNoShow, models.TripStatusCompleted, false},
+	}
+	for _, tt := range tests {
+		if got := canTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("canTransition(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestIsTerminal(t *testing.T) {
+	terminal := map[models.TripStatus]bool{
+		models.TripStatusRequested:      false,
+		models.TripStatusDriverAssigned: false,
+		models.TripStatusDriverArriving: false,
+		models.TripStatusInProgress:     false,
+		models.TripStatusCompleted:      true,
+		models.TripStatusCancelled:      true,
+		models.TripStatusNoShow:         true,
+	}
+	for s, want := range terminal {
+		if !isKnownStatus(s) {
+			t.Errorf("isKnownStatus(%s) = false", s)
+		}
+		if got := isTerminal(s); got != want {
+			t.Errorf("isTerminal(%s) = %v, want %v", s, got, want)
+		}
+	}
+	if isKnownStatus("teleported") {
+		t.Error(`isKnownStatus("teleported") = true`)
+	}
+}
+
+// TestCompleteTwice checks that only one of two callers completing the same
+// trip from in_progress succeeds.
+func TestCompleteTwice(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository(nil)
+	trip, err := repo.CreateTrip(ctx, models.Trip{RiderID: "rider-1", Status: models.TripStatusInProgress})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := repo.UpdateTripStatus(ctx, trip.ID, models.TripStatusInProgress, models.TripStatusCompleted, nil); err != nil {
+		t.Fatalf("first completion: %v", err)
+	}
+	_, err = repo.UpdateTripStatus(ctx, trip.ID, models.TripStatusInProgress, models.TripStatusCompleted, nil)
+	var transitionErr *InvalidTransitionError
+	if !errors.As(err, &transitionErr) {
+		t.Fatalf("second completion: err = %v, want *InvalidTransitionError", err)
+	}
+	if transitionErr.From != models.TripStatusCompleted {
+		t.Errorf("second completion: From = %s, want %s", transitionErr.From, models.TripStatusCompleted)
+	}
+
+	history, err := repo.GetTripStatusHistory(ctx, trip.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 1 {
+		t.Errorf("history has %d entries, want 1", len(history))
+	}
+}
+
+func TestGrpcError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want codes.Code
+	}{
+		{ErrTripNotFound, codes.NotFound},
+		{fmt.Errorf("load: %w", ErrTripNotFound), codes.NotFound},
+		{ErrUnknownStatus, codes.InvalidArgument},
+		{&InvalidTransitionError{TripID: "t", From: models.TripStatusCompleted, To: models.TripStatusCompleted}, codes.FailedPrecondition},
+		{errors.New("boom"), codes.Unknown},
+	}
+	for _, tt := range tests {
+		got := grpcError(tt.err)
+		if code := status.Code(got); code != tt.want {
+			t.Errorf("grpcError(%v) code = %s, want %s", tt.err, code, tt.want)
+		}
+		if msg := status.Convert(got).Message(); msg != tt.err.Error() {
+			t.Errorf("grpcError(%v) message = %q, want %q", tt.err, msg, tt.err.Error())
+		}
+	}
+}
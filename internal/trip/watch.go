@@ -0,0 +1,183 @@
+package trip
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/lukabrx/uber-clone/internal/geo"
+	"github.com/lukabrx/uber-clone/internal/models"
+)
+
+// watchBuffer is how many updates a watcher may fall behind before it is
+// dropped.
+const watchBuffer = 32
+
+// TripUpdate is sent to WatchTrip callers. Exactly one of Trip and Location is
+// set.
+type TripUpdate struct {
+	Trip     *models.Trip
+	Location *DriverLocation
+	// ETA is how long until the driver reaches the pickup, or the dropoff once
+	// the trip has started; zero when there is no driver or no known location.
+	ETA time.Duration
+}
+
+type DriverLocation struct {
+	DriverID   string
+	Lat        float64
+	Lon        float64
+	Heading    float64
+	SpeedKmh   float64
+	RecordedAt time.Time
+}
+
+// watchers fans trip updates out to the WatchTrip streams open in this
+// process, and remembers each active trip's last driver location for the ETA.
+// It is fed from the event bus by WatchFeed.
+type watchers struct {
+	mu        sync.Mutex
+	subs      map[string]map[chan TripUpdate]struct{}
+	locations map[string]DriverLocation
+}
+
+func newWatchers() *watchers {
+	return &watchers{
+		subs:      make(map[string]map[chan TripUpdate]struct{}),
+		locations: make(map[string]DriverLocation),
+	}
+}
+
+// subscribe returns a channel of updates for tripID. The channel is closed if
+// the watcher falls too far behind.
+func (w *watchers) subscribe(tripID string) (<-chan TripUpdate, func()) {
+	ch := make(chan TripUpdate, watchBuffer)
+	w.mu.Lock()
+	if w.subs[tripID] == nil {
+		w.subs[tripID] = make(map[chan TripUpdate]struct{})
+	}
+	w.subs[tripID][ch] = struct{}{}
+	w.mu.Unlock()
+
+	return ch, func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		if _, ok := w.subs[tripID][ch]; ok {
+			delete(w.subs[tripID], ch)
+			close(ch)
+		}
+		if len(w.subs[tripID]) == 0 {
+			delete(w.subs, tripID)
+		}
+	}
+}
+
+func (w *watchers) tripChanged(trip models.Trip) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	loc, ok := w.locations[trip.ID]
+	if isTerminal(trip.Status) {
+		delete(w.locations, trip.ID)
+	}
+	update := TripUpdate{Trip: &trip}
+	if ok {
+		update.ETA = estimateETA(trip, loc)
+	}
+	w.send(trip.ID, update)
+}
+
+func (w *watchers) driverMoved(trip models.Trip, loc DriverLocation) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.locations[trip.ID] = loc
+	w.send(trip.ID, TripUpdate{Location: &loc, ETA: estimateETA(trip, loc)})
+}
+
+// tracking reports whether anything is kept for tripID: a watcher or a last
+// location.
+func (w *watchers) tracking(tripID string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, watched := w.subs[tripID]
+	_, located := w.locations[tripID]
+	return watched || located
+}
+
+// lastLocation returns the driver's last known location on tripID.
+func (w *watchers) lastLocation(tripID string) (DriverLocation, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	loc, ok := w.locations[tripID]
+	return loc, ok
+}
+
+// send must be called with w.mu held. A watcher whose buffer is full is
+// dropped rather than left to block everyone else.
+func (w *watchers) send(tripID string, update TripUpdate) {
+	for ch := range w.subs[tripID] {
+		select {
+		case ch <- update:
+		default:
+			delete(w.subs[tripID], ch)
+			close(ch)
+		}
+	}
+}
+
+// estimateETA is the time for the driver at loc to reach the pickup, or the
+// dropoff once the trip is in progress.
+func estimateETA(trip models.Trip, loc DriverLocation) time.Duration {
+	switch trip.Status {
+	case models.TripStatusDriverAssigned, models.TripStatusDriverArriving:
+		return geo.EstimateETA(geo.DistanceKm(loc.Lat, loc.Lon, trip.StartLat, trip.StartLon))
+	case models.TripStatusInProgress:
+		return geo.EstimateETA(geo.DistanceKm(loc.Lat, loc.Lon, trip.EndLat, trip.EndLon))
+	default:
+		return 0
+	}
+}
+
+// WatchTrip calls send with the trip as it is now and then with every status
+// change and driver location, until the trip ends or ctx is cancelled. Changes
+// arrive through WatchFeed, so they are seen whichever instance made them.
+func (s *Service) WatchTrip(ctx context.Context, tripID string, send func(TripUpdate) error) error {
+	// Subscribe before reading the trip so no change falls in between.
+	updates, unsubscribe := s.watchers.subscribe(tripID)
+	defer unsubscribe()
+
+	trip, err := s.repo.GetTripByID(ctx, tripID)
+	if err != nil {
+		return err
+	}
+	current := TripUpdate{Trip: &trip}
+	if loc, ok := s.watchers.lastLocation(tripID); ok {
+		current.ETA = estimateETA(trip, loc)
+	}
+	if err := send(current); err != nil {
+		return err
+	}
+
+	for !isTerminal(trip.Status) {
+		select {
+		case <-ctx.Done():
+			return nil
+		case update, ok := <-updates:
+			if !ok {
+				return ErrWatcherTooSlow
+			}
+			if update.Trip != nil {
+				// Skip changes already reflected in the first update.
+				if update.Trip.Version <= trip.Version {
+					continue
+				}
+				trip = *update.Trip
+			}
+			if err := send(update); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
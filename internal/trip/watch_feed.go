@@ -0,0 +1,99 @@
+package trip
+
+import (
+	"context"
+	"log"
+
+	"github.com/lukabrx/uber-clone/internal/eventbus"
+	"github.com/lukabrx/uber-clone/internal/events"
+	"github.com/lukabrx/uber-clone/internal/models"
+	"github.com/lukabrx/uber-clone/internal/types"
+)
+
+// WatchFeed passes trip events and driver locations from the bus to the
+// WatchTrip streams open in this process. Every instance needs all events, so
+// each runs its own feed on a subscription no other instance shares.
+type WatchFeed struct {
+	subscriber eventbus.Subscriber
+	service    *Service
+}
+
+func NewWatchFeed(subscriber eventbus.Subscriber, service *Service) *WatchFeed {
+	return &WatchFeed{subscriber: subscriber, service: service}
+}
+
+func (f *WatchFeed) SubscribeAndListen(ctx context.Context) {
+	log.Println("Trip watch feed subscribed and listening for trip events and driver locations...")
+	go func() {
+		topics := []string{types.TripEventsTopic, types.DriverLocationTopic}
+		if err := f.subscriber.Subscribe(ctx, topics, f.handle); err != nil {
+			log.Fatalf("Failed to subscribe to topic: %v", err)
+		}
+		log.Println("Stopping trip watch feed.")
+	}()
+}
+
+// handle never fails: watchers only want the latest state, so an update that
+// can't be passed on is skipped rather than redelivered.
+func (f *WatchFeed) handle(ctx context.Context, msg eventbus.Message) error {
+	switch msg.Topic {
+	case types.TripEventsTopic:
+		event, err := events.DecodeTripEvent(msg.Value)
+		if err != nil {
+			log.Printf("Could not decode trip event: %v", err)
+			return nil
+		}
+		if err := f.service.tripChanged(ctx, event.TripID); err != nil {
+			log.Printf("Failed to pass trip %s on to its watchers: %v", event.TripID, err)
+		}
+	case types.DriverLocationTopic:
+		driver, err := events.DecodeDriver(msg.Value)
+		if err != nil {
+			log.Printf("Could not decode driver update: %v", err)
+			return nil
+		}
+		if err := f.service.driverMoved(ctx, driver); err != nil {
+			log.Printf("Failed to pass location of driver %s on to watchers of trip %s: %v", driver.ID, driver.TripID, err)
+		}
+	}
+	return nil
+}
+
+// tripChanged sends a trip's current state to its watchers. Trips nothing is
+// kept for are not loaded.
+func (s *Service) tripChanged(ctx context.Context, tripID string) error {
+	if !s.watchers.tracking(tripID) {
+		return nil
+	}
+	trip, err := s.repo.GetTripByID(ctx, tripID)
+	if err != nil {
+		return err
+	}
+	s.watchers.tripChanged(trip)
+	return nil
+}
+
+// driverMoved sends a driver's position to the watchers of the trip the
+// driver is reserved for, while that trip is under way.
+func (s *Service) driverMoved(ctx context.Context, driver models.Driver) error {
+	if driver.TripID == "" {
+		return nil
+	}
+	trip, err := s.repo.GetTripByID(ctx, driver.TripID)
+	if err != nil {
+		return err
+	}
+	if trip.DriverID != driver.ID || isTerminal(trip.Status) {
+		return nil
+	}
+
+	s.watchers.driverMoved(trip, DriverLocation{
+		DriverID:   driver.ID,
+		Lat:        driver.Lat,
+		Lon:        driver.Lon,
+		Heading:    driver.Heading,
+		SpeedKmh:   driver.SpeedKmh,
+		RecordedAt: driver.LocationUpdatedAt,
+	})
+	return nil
+}
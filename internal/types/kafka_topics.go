@@ -1,19 +1,55 @@
 package types
 
+import "github.com/lukabrx/uber-clone/internal/models"
+
 var (
+	// DriverLocationTopic carries a driver's state whenever they register,
+	// change availability or report a new location. It and TripEventsTopic
+	// carry events.v1 envelopes; see package events.
 	DriverLocationTopic = "driver_locations"
 	TripEventsTopic     = "trip_events"
+	DriverOffersTopic   = "driver_offers"
+	// DeadLetterTopic holds messages consumers gave up on; see package dlq.
+	DeadLetterTopic = "dead_letters"
 )
 
 type EventType string
 
 const (
-	TripCreatedEvent   EventType = "TRIP_CREATED"
-	TripCompletedEvent EventType = "TRIP_COMPLETED"
+	TripCreatedEvent        EventType = "TRIP_CREATED"
+	TripDriverAssignedEvent EventType = "TRIP_DRIVER_ASSIGNED"
+	TripDriverArrivingEvent EventType = "TRIP_DRIVER_ARRIVING"
+	TripStartedEvent        EventType = "TRIP_STARTED"
+	TripCompletedEvent      EventType = "TRIP_COMPLETED"
+	TripCancelledEvent      EventType = "TRIP_CANCELLED"
+	TripNoShowEvent         EventType = "TRIP_NO_SHOW"
+
+	TripOfferCreatedEvent EventType = "TRIP_OFFER_CREATED"
+	TripOfferClosedEvent  EventType = "TRIP_OFFER_CLOSED"
 )
 
+// TripEvent is published on TripEventsTopic, keyed by trip ID, as an
+// events.v1 envelope. EventID is unique per event; Version orders the events of
+// one trip, starting at 1, so consumers can drop redelivered and out-of-date
+// events.
 type TripEvent struct {
-	EventType EventType `json:"event_type"`
-	TripID    string    `json:"trip_id"`
-	DriverID  string    `json:"driver_id"`
+	EventID        string    `json:"event_id"`
+	Version        int64     `json:"version"`
+	EventType      EventType `json:"event_type"`
+	TripID         string    `json:"trip_id"`
+	DriverID       string    `json:"driver_id"`
+	Status         string    `json:"status,omitempty"`
+	PreviousStatus string    `json:"previous_status,omitempty"`
+	CancelledBy    string    `json:"cancelled_by,omitempty"`
+	Reason         string    `json:"reason,omitempty"`
+	PickupLat      float64   `json:"pickup_lat"`
+	PickupLon      float64   `json:"pickup_lon"`
+}
+
+// TripOfferEvent tells a driver's app that an offer was made to them or is no
+// longer open. Outcome is only set when the offer is closed.
+type TripOfferEvent struct {
+	EventType EventType        `json:"event_type"`
+	Offer     models.TripOffer `json:"offer"`
+	Outcome   string           `json:"outcome,omitempty"`
 }
@@ -0,0 +1,45 @@
+package user
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresRepository(pool *pgxpool.Pool) *PostgresRepository {
+	return &PostgresRepository{pool: pool}
+}
+
+func (r *PostgresRepository) CreateOrUpdateUser(ctx context.Context, user User) (User, error) {
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO users (id, email, name) VALUES ($1, $2, $3)
+		ON CONFLICT (email) DO UPDATE SET name = EXCLUDED.name
+		RETURNING id`,
+		uuid.New().String(), user.Email, user.Name,
+	).Scan(&user.ID)
+	if err != nil {
+		return User{}, err
+	}
+	return user, nil
+}
+
+func (r *PostgresRepository) GetUserByID(ctx context.Context, id string) (User, error) {
+	var user User
+	err := r.pool.QueryRow(ctx,
+		`SELECT id, email, name FROM users WHERE id = $1`, id,
+	).Scan(&user.ID, &user.Email, &user.Name)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return User{}, ErrUserNotFound
+	}
+	if err != nil {
+		return User{}, err
+	}
+	return user, nil
+}
@@ -1,18 +1,29 @@
 package user
 
 import (
+	"context"
 	"errors"
 	"sync"
 
 	"github.com/google/uuid"
 )
 
+var ErrUserNotFound = errors.New("user not found")
+
 type User struct {
 	ID    string
 	Email string
 	Name  string
 }
 
+// UserRepository stores users. Users are identified by email when they sign in.
+type UserRepository interface {
+	// CreateOrUpdateUser creates the user, or updates the name of the user
+	// with the same email, and returns it with its ID.
+	CreateOrUpdateUser(ctx context.Context, user User) (User, error)
+	GetUserByID(ctx context.Context, id string) (User, error)
+}
+
 type MemoryRepository struct {
 	users map[string]*User
 	mu    sync.RWMutex
@@ -24,23 +35,30 @@ func NewMemoryRepository() *MemoryRepository {
 	}
 }
 
-func (r *MemoryRepository) CreateOrUpdateUser(user User) (User, error) {
+func (r *MemoryRepository) CreateOrUpdateUser(ctx context.Context, user User) (User, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	for _, existing := range r.users {
+		if existing.Email == user.Email {
+			existing.Name = user.Name
+			return *existing, nil
+		}
+	}
+
 	user.ID = uuid.New().String()
 	r.users[user.ID] = &user
 
 	return user, nil
 }
 
-func (r *MemoryRepository) GetUserByID(id string) (User, error) {
+func (r *MemoryRepository) GetUserByID(ctx context.Context, id string) (User, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	user, ok := r.users[id]
 	if !ok {
-		return User{}, errors.New("user not found")
+		return User{}, ErrUserNotFound
 	}
 
 	return *user, nil
@@ -0,0 +1,14 @@
+package user_test
+
+import (
+	"testing"
+
+	"github.com/lukabrx/uber-clone/internal/repotest"
+	"github.com/lukabrx/uber-clone/internal/user"
+)
+
+func TestMemoryRepository(t *testing.T) {
+	repotest.RunUserRepository(t, func(t *testing.T) user.UserRepository {
+		return user.NewMemoryRepository()
+	})
+}
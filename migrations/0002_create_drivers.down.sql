@@ -0,0 +1,2 @@
+DROP TABLE driver_offer_stats;
+DROP TABLE drivers;
@@ -0,0 +1,28 @@
+CREATE TABLE drivers (
+    id                  TEXT PRIMARY KEY,
+    name                TEXT NOT NULL,
+    vehicle_type        TEXT NOT NULL,
+    is_available        BOOLEAN NOT NULL,
+    lat                 DOUBLE PRECISION NOT NULL,
+    lon                 DOUBLE PRECISION NOT NULL,
+    heading             DOUBLE PRECISION NOT NULL DEFAULT 0,
+    speed_kmh           DOUBLE PRECISION NOT NULL DEFAULT 0,
+    location_updated_at TIMESTAMPTZ NOT NULL,
+    trip_id             TEXT NOT NULL DEFAULT '',
+    -- The user account the driver signs in with.
+    user_id             TEXT NOT NULL DEFAULT ''
+);
+
+-- Nearby searches filter available drivers by a lat/lon bounding box.
+CREATE INDEX drivers_available_location_idx ON drivers (lat, lon) WHERE is_available;
+
+-- A user can register at most one driver.
+CREATE UNIQUE INDEX drivers_user_id_idx ON drivers (user_id) WHERE user_id <> '';
+
+CREATE TABLE driver_offer_stats (
+    driver_id TEXT PRIMARY KEY REFERENCES drivers (id) ON DELETE CASCADE,
+    offered   INTEGER NOT NULL DEFAULT 0,
+    accepted  INTEGER NOT NULL DEFAULT 0,
+    declined  INTEGER NOT NULL DEFAULT 0,
+    expired   INTEGER NOT NULL DEFAULT 0
+);
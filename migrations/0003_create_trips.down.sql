@@ -0,0 +1,4 @@
+DROP TABLE redeemed_quotes;
+DROP TABLE trip_trace_points;
+DROP TABLE trip_status_changes;
+DROP TABLE trips;
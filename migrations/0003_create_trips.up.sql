@@ -0,0 +1,63 @@
+-- Money columns hold minor units (e.g. cents) next to their ISO currency.
+CREATE TABLE trips (
+    id                        TEXT PRIMARY KEY,
+    rider_id                  TEXT NOT NULL,
+    driver_id                 TEXT NOT NULL DEFAULT '',
+    start_lat                 DOUBLE PRECISION NOT NULL,
+    start_lon                 DOUBLE PRECISION NOT NULL,
+    end_lat                   DOUBLE PRECISION NOT NULL,
+    end_lon                   DOUBLE PRECISION NOT NULL,
+    vehicle_type              TEXT NOT NULL,
+    status                    TEXT NOT NULL,
+    price_amount              BIGINT NOT NULL,
+    price_currency            TEXT NOT NULL,
+    surge_multiplier          DOUBLE PRECISION NOT NULL DEFAULT 1,
+    request_time              TIMESTAMPTZ NOT NULL,
+    updated_at                TIMESTAMPTZ NOT NULL,
+    started_at                TIMESTAMPTZ,
+    completed_at              TIMESTAMPTZ,
+    distance_km               DOUBLE PRECISION NOT NULL DEFAULT 0,
+    final_price_amount        BIGINT NOT NULL DEFAULT 0,
+    final_price_currency      TEXT NOT NULL DEFAULT '',
+    cancelled_by              TEXT NOT NULL DEFAULT '',
+    canceller_id              TEXT NOT NULL DEFAULT '',
+    cancellation_reason       TEXT NOT NULL DEFAULT '',
+    cancellation_fee_amount   BIGINT NOT NULL DEFAULT 0,
+    cancellation_fee_currency TEXT NOT NULL DEFAULT '',
+    cancelled_at              TIMESTAMPTZ
+);
+
+-- ListTrips pages newest first by (request_time, id).
+CREATE INDEX trips_rider_request_time_idx ON trips (rider_id, request_time DESC, id DESC);
+CREATE INDEX trips_driver_request_time_idx ON trips (driver_id, request_time DESC, id DESC);
+CREATE INDEX trips_status_idx ON trips (status);
+
+CREATE TABLE trip_status_changes (
+    id                        BIGSERIAL PRIMARY KEY,
+    trip_id                   TEXT NOT NULL REFERENCES trips (id) ON DELETE CASCADE,
+    from_status               TEXT NOT NULL,
+    to_status                 TEXT NOT NULL,
+    changed_at                TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX trip_status_changes_trip_idx ON trip_status_changes (trip_id, id);
+
+CREATE TABLE trip_trace_points (
+    id                        BIGSERIAL PRIMARY KEY,
+    trip_id                   TEXT NOT NULL REFERENCES trips (id) ON DELETE CASCADE,
+    lat                       DOUBLE PRECISION NOT NULL,
+    lon                       DOUBLE PRECISION NOT NULL,
+    recorded_at               TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX trip_trace_points_trip_idx ON trip_trace_points (trip_id, id);
+
+-- Nonces of the fare quotes trips were booked with, so each quote is used at
+-- most once. Rows can be dropped once the quote has expired.
+CREATE TABLE redeemed_quotes (
+    nonce      TEXT PRIMARY KEY,
+    trip_id    TEXT NOT NULL REFERENCES trips (id) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX redeemed_quotes_expires_at_idx ON redeemed_quotes (expires_at);
@@ -0,0 +1,17 @@
+-- Events written together with the state change they describe. The relay
+-- publishes them to Kafka and deletes each row once delivery is confirmed.
+CREATE TABLE outbox (
+    id              BIGSERIAL PRIMARY KEY,
+    topic           TEXT NOT NULL,
+    key             TEXT NOT NULL,
+    payload         BYTEA NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    attempts        INTEGER NOT NULL DEFAULT 0,
+    last_error      TEXT NOT NULL DEFAULT '',
+    -- A message is claimed by pushing this into the future.
+    available_at    TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Messages for one key are published in id order.
+CREATE INDEX outbox_topic_key_idx ON outbox (topic, key, id);
+CREATE INDEX outbox_available_at_idx ON outbox (available_at);
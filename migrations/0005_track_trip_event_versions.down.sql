@@ -0,0 +1,2 @@
+DROP TABLE processed_trip_events;
+ALTER TABLE trips DROP COLUMN version;
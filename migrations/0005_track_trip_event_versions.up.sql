@@ -0,0 +1,12 @@
+-- Counts a trip's events so consumers can order them.
+ALTER TABLE trips ADD COLUMN version BIGINT NOT NULL DEFAULT 0;
+
+-- Trip events the driver service has handled, so redeliveries are skipped.
+CREATE TABLE processed_trip_events (
+    event_id     TEXT PRIMARY KEY,
+    trip_id      TEXT NOT NULL,
+    version      BIGINT NOT NULL,
+    processed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX processed_trip_events_trip_idx ON processed_trip_events (trip_id, version DESC);
@@ -0,0 +1,11 @@
+// Package migrations embeds the SQL migrations so binaries can apply them
+// without the source tree.
+package migrations
+
+import "embed"
+
+// FS holds the migration files. Each migration is NNNN_name.up.sql with an
+// optional NNNN_name.down.sql, applied in version order.
+//
+//go:embed *.sql
+var FS embed.FS
@@ -11,7 +11,7 @@ import {
   CardHeader,
   CardTitle,
 } from "./ui/card";
-import { bookTrip, Driver, TripResponse } from "~/lib/api";
+import { bookTrip, Driver, formatMoney, TripResponse } from "~/lib/api";
 
 type View = "drivers" | "confirmation";
 
@@ -23,15 +23,11 @@ export function BookRide({ drivers }: BookRideProps) {
   const [view, setView] = useState<View>("drivers");
   const [trip, setTrip] = useState<TripResponse | null>(null);
   const [isLoading, setIsLoading] = useState(false);
-  const [selectedDriver, setSelectedDriver] = useState<Driver | null>(null);
 
   const handleBookTrip = async () => {
-    if (!selectedDriver) return;
     setIsLoading(true);
     try {
       const result = await bookTrip({
-        rider_id: "rider-xyz",
-        driver_id: selectedDriver.id,
         start_lat: 34.06,
         start_lon: -118.26,
         end_lat: 34.07,
@@ -40,8 +36,8 @@ export function BookRide({ drivers }: BookRideProps) {
       setTrip(result);
       setView("confirmation");
       console.log({
-        title: "Trip Booked!",
-        description: `Your driver is on the way.`,
+        title: "Trip Requested!",
+        description: `We are finding you a driver.`,
       });
     } catch (error) {
       console.log({
@@ -57,29 +53,33 @@ export function BookRide({ drivers }: BookRideProps) {
   const reset = () => {
     setView("drivers");
     setTrip(null);
-    setSelectedDriver(null);
   };
 
   if (view === "confirmation" && trip) {
     return (
       <Card>
         <CardHeader>
-          <CardTitle className="text-green-600">Trip Confirmed!</CardTitle>
-          <CardDescription>Your driver is on the way.</CardDescription>
+          <CardTitle className="text-green-600">Trip Requested!</CardTitle>
+          <CardDescription>We are finding you a driver.</CardDescription>
         </CardHeader>
         <CardContent className="space-y-4">
           <p>
             <strong>Trip ID:</strong> {trip.id}
           </p>
           <p>
-            <strong>Assigned Driver:</strong> {trip.driver_id}
+            <strong>Assigned Driver:</strong> {trip.driver_id || "Searching..."}
           </p>
           <p>
             <strong>Status:</strong> {trip.status}
           </p>
           <p>
-            <strong>Price:</strong> ${trip.price.toFixed(2)}
+            <strong>Price:</strong> {formatMoney(trip.price)}
           </p>
+          {trip.surge_multiplier && trip.surge_multiplier > 1 && (
+            <p className="text-orange-600">
+              <strong>Surge:</strong> {trip.surge_multiplier.toFixed(1)}x
+            </p>
+          )}
         </CardContent>
         <CardFooter>
           <Button onClick={reset} className="w-full">
@@ -96,7 +96,7 @@ export function BookRide({ drivers }: BookRideProps) {
         <CardTitle>Available Drivers Near You</CardTitle>
         <CardDescription>
           {drivers?.length > 0
-            ? "Please select a driver to book your trip."
+            ? "The closest available driver will be matched to your trip."
             : "Searching for drivers..."}
         </CardDescription>
       </CardHeader>
@@ -104,12 +104,7 @@ export function BookRide({ drivers }: BookRideProps) {
         {drivers?.map((driver) => (
           <div
             key={driver.id}
-            className={`flex items-center justify-between p-2 border rounded-md cursor-pointer ${
-              selectedDriver?.id === driver.id
-                ? "bg-blue-100 border-blue-500"
-                : ""
-            }`}
-            onClick={() => setSelectedDriver(driver)}
+            className="flex items-center justify-between p-2 border rounded-md"
           >
             <div className="flex items-center gap-2">
               <Car className="h-5 w-5 text-gray-600" />
@@ -122,7 +117,7 @@ export function BookRide({ drivers }: BookRideProps) {
         <Button
           className="w-full"
           onClick={handleBookTrip}
-          disabled={isLoading || !selectedDriver || drivers?.length === 0}
+          disabled={isLoading || drivers?.length === 0}
         >
           {isLoading ? "Booking..." : "Book Ride Now"}
         </Button>
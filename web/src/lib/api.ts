@@ -6,20 +6,55 @@ export interface User {
   email: string;
 }
 
+export type VehicleType = "economy" | "comfort" | "xl";
+
 export interface Driver {
   id: string;
   name: string;
   lat: number;
   lon: number;
+  vehicle_type?: VehicleType;
+  // Only set on search results.
+  distance_km?: number;
+  eta_seconds?: number;
+}
+
+// Money is an amount in minor units (e.g. cents) of an ISO 4217 currency.
+export interface Money {
+  amount: number;
+  currency: string;
 }
 
+const minorUnitDigits: Record<string, number> = { JPY: 0, KWD: 3 };
+
+export const formatMoney = (money: Money): string => {
+  const digits = minorUnitDigits[money.currency] ?? 2;
+  return new Intl.NumberFormat(undefined, {
+    style: "currency",
+    currency: money.currency,
+    minimumFractionDigits: digits,
+    maximumFractionDigits: digits,
+  }).format(money.amount / 10 ** digits);
+};
+
 export interface TripRequest {
-  rider_id: string;
-  driver_id: string;
   start_lat: number;
   start_lon: number;
   end_lat: number;
   end_lon: number;
+  vehicle_type?: VehicleType;
+  // From getFareQuote; books the trip at the quoted price.
+  quote_id?: string;
+}
+
+export interface FareQuote {
+  quote_id: string;
+  price: Money;
+  vehicle_type: VehicleType;
+  distance_km: number;
+  duration_seconds: number;
+  pickup_eta_seconds: number;
+  expires_at: number;
 }
 
 export interface TripResponse {
@@ -27,7 +62,16 @@ export interface TripResponse {
   rider_id: string;
   driver_id: string;
   status: string;
-  price: number;
+  price: Money;
+  surge_multiplier?: number;
+  // Set once the trip completes; what the rider is actually charged.
+  final_price?: Money;
+  distance_km?: number;
+}
+
+export interface Surge {
+  zone_id: string;
+  multiplier: number;
 }
 
 let getAccessToken: () => string | null = () => null;
@@ -118,6 +162,12 @@ export const findAvailableDrivers = async (
   return response.json();
 };
 
+export const getSurge = async (lat: number, lon: number): Promise<Surge> => {
+  const response = await apiClient(`surge?lat=${lat}&lon=${lon}`);
+  if (!response.ok) throw new Error("Failed to fetch surge");
+  return response.json();
+};
+
 export const getMe = async (): Promise<User> => {
   const response = await apiClient("me");
   if (!response.ok) throw new Error("Failed to fetch user data");
@@ -137,6 +187,17 @@ export const registerDriver = async (
   return response.json();
 };
 
+export const getFareQuote = async (
+  tripRequest: Omit<TripRequest, "quote_id">
+): Promise<FareQuote> => {
+  const response = await apiClient("quotes", {
+    method: "POST",
+    body: JSON.stringify(tripRequest),
+  });
+  if (!response.ok) throw new Error("Failed to get fare quote");
+  return response.json();
+};
+
 export const bookTrip = async (
   tripRequest: TripRequest
 ): Promise<TripResponse> => {